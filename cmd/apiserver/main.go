@@ -1,9 +1,18 @@
 package main
 
 import (
+	"encoding/json"
+	"flag"
+	"fmt"
 	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
 
 	"filmoteka/internal/app/apiserver"
+	"filmoteka/internal/app/auth"
+	"filmoteka/internal/app/importer"
 
 	"github.com/BurntSushi/toml"
 )
@@ -19,7 +28,122 @@ func main() {
 		log.Fatal(err)
 	}
 
+	if len(os.Args) > 1 {
+		var err error
+		switch os.Args[1] {
+		case "import":
+			err = runImport(config, os.Args[2:])
+		case "purge":
+			err = runPurge(config, os.Args[2:])
+		case "token":
+			err = runToken(config, os.Args[2:])
+		default:
+			err = fmt.Errorf("unknown command %q, use import, purge or token", os.Args[1])
+		}
+		if err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
 	if err := apiserver.Start(config); err != nil {
 		log.Fatal(err)
 	}
 }
+
+// runImport handles `filmoteka import films|actors [flags] FILE`.
+func runImport(config *apiserver.Config, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: filmoteka import films|actors [-format csv|ndjson] [-best-effort] [-batch-size N] FILE")
+	}
+	entity := args[0]
+
+	fs := flag.NewFlagSet("import", flag.ExitOnError)
+	format := fs.String("format", "", "input format: csv or ndjson (default from file extension)")
+	bestEffort := fs.Bool("best-effort", false, "skip failing rows instead of rolling back the whole import")
+	batchSize := fs.Int("batch-size", 0, "rows per transaction in best-effort mode")
+	fs.Parse(args[1:])
+	if fs.NArg() != 1 {
+		return fmt.Errorf("import: expected exactly one input file")
+	}
+	path := fs.Arg(0)
+
+	if *format == "" {
+		*format = strings.TrimPrefix(filepath.Ext(path), ".")
+	}
+	f, err := importer.ParseFormat(*format)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	report, err := apiserver.Import(config, entity, file, importer.Options{
+		Format:    f,
+		Atomic:    !*bestEffort,
+		BatchSize: *batchSize,
+	})
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(report); err != nil {
+		return err
+	}
+	if report.RolledBack {
+		return fmt.Errorf("import rolled back: %d rows failed", report.Failed)
+	}
+
+	return nil
+}
+
+// runPurge handles `filmoteka purge [-days N]`.
+func runPurge(config *apiserver.Config, args []string) error {
+	fs := flag.NewFlagSet("purge", flag.ExitOnError)
+	days := fs.Int("days", 30, "remove rows soft deleted more than N days ago")
+	fs.Parse(args)
+	if *days < 0 {
+		return fmt.Errorf("purge: -days must not be negative")
+	}
+
+	report, err := apiserver.Purge(config, time.Now().AddDate(0, 0, -*days))
+	if err != nil {
+		return err
+	}
+
+	return json.NewEncoder(os.Stdout).Encode(report)
+}
+
+// runToken handles `filmoteka token -user N [-role user|admin] [-ttl D]`
+// and prints a bearer token signed with the configured session key.
+func runToken(config *apiserver.Config, args []string) error {
+	fs := flag.NewFlagSet("token", flag.ExitOnError)
+	user := fs.Int("user", 0, "user id")
+	role := fs.String("role", auth.RoleUser, "role: user or admin")
+	ttl := fs.Duration("ttl", 24*time.Hour, "token lifetime, 0 for no expiry")
+	fs.Parse(args)
+	if *user <= 0 {
+		return fmt.Errorf("token: -user is required")
+	}
+	if *role != auth.RoleUser && *role != auth.RoleAdmin {
+		return fmt.Errorf("token: unknown role %q", *role)
+	}
+
+	claims := auth.Claims{UserID: *user, Role: *role}
+	if *ttl > 0 {
+		claims.ExpiresAt = time.Now().Add(*ttl).Unix()
+	}
+	token, err := auth.Sign([]byte(config.SessionKey), claims)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(token)
+	return nil
+}
@@ -0,0 +1,2 @@
+ALTER TABLE public.films DROP COLUMN IF EXISTS updated_at;
+ALTER TABLE public.actors DROP COLUMN IF EXISTS updated_at;
@@ -0,0 +1,3 @@
+ALTER TABLE public.films ADD COLUMN IF NOT EXISTS updated_at timestamptz NOT NULL DEFAULT now();
+
+ALTER TABLE public.actors ADD COLUMN IF NOT EXISTS updated_at timestamptz NOT NULL DEFAULT now();
@@ -0,0 +1,8 @@
+DELETE FROM public.films WHERE deleted_at IS NOT NULL;
+DELETE FROM public.actors WHERE deleted_at IS NOT NULL;
+
+DROP INDEX IF EXISTS public.films_name_release_year_idx;
+CREATE UNIQUE INDEX films_name_release_year_idx ON public.films(name, release_year);
+
+ALTER TABLE public.films DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE public.actors DROP COLUMN IF EXISTS deleted_at;
@@ -0,0 +1,7 @@
+ALTER TABLE public.films ADD COLUMN IF NOT EXISTS deleted_at timestamptz;
+
+ALTER TABLE public.actors ADD COLUMN IF NOT EXISTS deleted_at timestamptz;
+
+-- soft deleted films must not block re-creating the same (name, release_year)
+DROP INDEX IF EXISTS public.films_name_release_year_idx;
+CREATE UNIQUE INDEX films_name_release_year_idx ON public.films(name, release_year) WHERE deleted_at IS NULL;
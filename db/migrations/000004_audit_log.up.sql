@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS public.audit_log (
+    id bigserial PRIMARY KEY,
+    user_id integer,
+    request_id text NOT NULL DEFAULT '',
+    entity text NOT NULL,
+    entity_id integer NOT NULL,
+    action text NOT NULL,
+    before jsonb,
+    after jsonb,
+    created_at timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS audit_log_entity_idx ON public.audit_log(entity, entity_id, id);
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS public.film_revisions (
+    film_id integer NOT NULL REFERENCES public.films(id) ON DELETE CASCADE,
+    revision integer NOT NULL,
+    snapshot jsonb NOT NULL,
+    user_id integer,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    PRIMARY KEY (film_id, revision)
+);
+
+-- current state of existing films becomes their first revision
+INSERT INTO public.film_revisions (film_id, revision, snapshot, created_at)
+SELECT id, 1, jsonb_build_object('id', id, 'name', name, 'description', description, 'release_year', release_year, 'rating', rating), updated_at
+FROM public.films
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS public.film_genres;
+
+DROP TABLE IF EXISTS public.genres;
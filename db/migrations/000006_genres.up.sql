@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS public.genres (
+	id SERIAL PRIMARY KEY,
+	name varchar(50) NOT NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS genres_name_idx ON public.genres(lower(name));
+
+CREATE TABLE IF NOT EXISTS public.film_genres (
+	film_id integer NOT NULL REFERENCES public.films(id) ON DELETE CASCADE,
+	genre_id integer NOT NULL REFERENCES public.genres(id) ON DELETE CASCADE,
+	PRIMARY KEY (film_id, genre_id)
+);
+
+CREATE INDEX IF NOT EXISTS film_genres_genre_id_idx ON public.film_genres(genre_id);
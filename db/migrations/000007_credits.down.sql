@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS public.credits;
+
+DROP TYPE IF EXISTS crew_job;
@@ -0,0 +1,12 @@
+CREATE TYPE crew_job AS ENUM ('director', 'writer', 'composer', 'cinematographer', 'producer');
+
+-- People are the rows of actors: a person credited both on screen and in
+-- the crew keeps a single record.
+CREATE TABLE IF NOT EXISTS public.credits (
+	film_id integer NOT NULL REFERENCES public.films(id) ON DELETE CASCADE,
+	person_id integer NOT NULL REFERENCES public.actors(id) ON DELETE CASCADE,
+	job crew_job NOT NULL,
+	PRIMARY KEY (film_id, person_id, job)
+);
+
+CREATE INDEX IF NOT EXISTS credits_person_id_idx ON public.credits(person_id);
@@ -0,0 +1,8 @@
+ALTER TABLE public.films
+	DROP COLUMN IF EXISTS runtime,
+	DROP COLUMN IF EXISTS original_title,
+	DROP COLUMN IF EXISTS original_language,
+	DROP COLUMN IF EXISTS countries,
+	DROP COLUMN IF EXISTS age_rating;
+
+DROP TYPE IF EXISTS age_rating;
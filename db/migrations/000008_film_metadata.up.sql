@@ -0,0 +1,10 @@
+CREATE TYPE age_rating AS ENUM ('G', 'PG', 'PG-13', 'R', 'NC-17');
+
+ALTER TABLE public.films
+	ADD COLUMN IF NOT EXISTS runtime smallint CHECK (runtime > 0),
+	ADD COLUMN IF NOT EXISTS original_title varchar(150),
+	ADD COLUMN IF NOT EXISTS original_language char(2),
+	ADD COLUMN IF NOT EXISTS countries char(2)[] NOT NULL DEFAULT '{}',
+	ADD COLUMN IF NOT EXISTS age_rating age_rating;
+
+CREATE INDEX IF NOT EXISTS films_countries_idx ON public.films USING gin (countries);
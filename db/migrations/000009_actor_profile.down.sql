@@ -0,0 +1,5 @@
+ALTER TABLE public.actors
+	DROP COLUMN IF EXISTS death_date,
+	DROP COLUMN IF EXISTS birthplace,
+	DROP COLUMN IF EXISTS biography,
+	DROP COLUMN IF EXISTS aliases;
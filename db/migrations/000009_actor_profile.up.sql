@@ -0,0 +1,5 @@
+ALTER TABLE public.actors
+	ADD COLUMN IF NOT EXISTS death_date date CHECK (death_date > birth_date),
+	ADD COLUMN IF NOT EXISTS birthplace varchar(150),
+	ADD COLUMN IF NOT EXISTS biography text,
+	ADD COLUMN IF NOT EXISTS aliases varchar(100)[] NOT NULL DEFAULT '{}';
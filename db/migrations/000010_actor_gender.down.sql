@@ -0,0 +1,7 @@
+-- genders without a legacy code are stored as NULL and fail the NOT NULL
+-- constraint, so they have to be resolved before migrating down
+CREATE TYPE g AS ENUM ('M', 'F');
+
+ALTER TABLE public.actors
+	DROP CONSTRAINT IF EXISTS actors_gender_check,
+	ALTER COLUMN gender TYPE g USING CASE gender WHEN 'male' THEN 'M'::g WHEN 'female' THEN 'F'::g END;
@@ -0,0 +1,5 @@
+ALTER TABLE public.actors
+	ALTER COLUMN gender TYPE varchar(20) USING CASE gender WHEN 'M' THEN 'male' WHEN 'F' THEN 'female' END,
+	ADD CONSTRAINT actors_gender_check CHECK (gender IN ('male', 'female', 'non-binary', 'other', 'unspecified'));
+
+DROP TYPE IF EXISTS g;
@@ -0,0 +1,5 @@
+ALTER TABLE public.films
+	DROP COLUMN IF EXISTS user_rating_count,
+	DROP COLUMN IF EXISTS user_rating_sum;
+
+DROP TABLE IF EXISTS public.user_ratings;
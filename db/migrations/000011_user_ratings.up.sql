@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS public.user_ratings (
+	film_id integer NOT NULL REFERENCES public.films(id) ON DELETE CASCADE,
+	user_id integer NOT NULL,
+	rating smallint NOT NULL CHECK (rating BETWEEN 1 AND 10),
+	updated_at timestamptz NOT NULL DEFAULT now(),
+	PRIMARY KEY (film_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS user_ratings_user_id_idx ON public.user_ratings(user_id);
+
+-- running totals kept in step with user_ratings, so reads do not aggregate
+ALTER TABLE public.films
+	ADD COLUMN IF NOT EXISTS user_rating_count integer NOT NULL DEFAULT 0,
+	ADD COLUMN IF NOT EXISTS user_rating_sum integer NOT NULL DEFAULT 0;
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS public.reviews;
+
+DROP TYPE IF EXISTS review_status;
@@ -0,0 +1,19 @@
+CREATE TYPE review_status AS ENUM ('pending', 'approved', 'rejected');
+
+-- reviews of soft deleted films are hidden by joining live films and
+-- come back with the film; purging the film removes them
+CREATE TABLE IF NOT EXISTS public.reviews (
+	id SERIAL PRIMARY KEY,
+	film_id integer NOT NULL REFERENCES public.films(id) ON DELETE CASCADE,
+	user_id integer NOT NULL,
+	title varchar(150),
+	body text NOT NULL,
+	status review_status NOT NULL DEFAULT 'pending',
+	created_at timestamptz NOT NULL DEFAULT now(),
+	updated_at timestamptz NOT NULL DEFAULT now(),
+	UNIQUE (film_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS reviews_film_id_idx ON public.reviews(film_id, status, created_at);
+CREATE INDEX IF NOT EXISTS reviews_status_idx ON public.reviews(status, created_at);
+CREATE INDEX IF NOT EXISTS reviews_user_id_idx ON public.reviews(user_id, created_at);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS public.watch_log;
+DROP TABLE IF EXISTS public.watchlist;
@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS public.watchlist (
+	user_id integer NOT NULL,
+	film_id integer NOT NULL REFERENCES public.films(id) ON DELETE CASCADE,
+	added_at timestamptz NOT NULL DEFAULT now(),
+	PRIMARY KEY (user_id, film_id)
+);
+
+CREATE INDEX IF NOT EXISTS watchlist_film_id_idx ON public.watchlist(film_id);
+
+CREATE TABLE IF NOT EXISTS public.watch_log (
+	id SERIAL PRIMARY KEY,
+	user_id integer NOT NULL,
+	film_id integer NOT NULL REFERENCES public.films(id) ON DELETE CASCADE,
+	watched_on date NOT NULL,
+	created_at timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS watch_log_user_id_idx ON public.watch_log(user_id, watched_on);
+CREATE INDEX IF NOT EXISTS watch_log_film_id_idx ON public.watch_log(film_id);
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS public.collection_films;
+DROP TABLE IF EXISTS public.collections;
+
+DROP TYPE IF EXISTS collection_visibility;
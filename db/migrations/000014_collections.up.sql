@@ -0,0 +1,25 @@
+CREATE TYPE collection_visibility AS ENUM ('public', 'private');
+
+CREATE TABLE IF NOT EXISTS public.collections (
+	id SERIAL PRIMARY KEY,
+	title varchar(200) NOT NULL,
+	description text,
+	owner_id integer NOT NULL,
+	visibility collection_visibility NOT NULL DEFAULT 'private',
+	created_at timestamptz NOT NULL DEFAULT now(),
+	updated_at timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS collections_owner_id_idx ON public.collections(owner_id);
+
+-- positions are unique per collection once a reorder commits, so moves
+-- may shift them through each other within a transaction
+CREATE TABLE IF NOT EXISTS public.collection_films (
+	collection_id integer NOT NULL REFERENCES public.collections(id) ON DELETE CASCADE,
+	film_id integer NOT NULL REFERENCES public.films(id) ON DELETE CASCADE,
+	position integer NOT NULL CHECK (position > 0),
+	PRIMARY KEY (collection_id, film_id),
+	UNIQUE (collection_id, position) DEFERRABLE INITIALLY DEFERRED
+);
+
+CREATE INDEX IF NOT EXISTS collection_films_film_id_idx ON public.collection_films(film_id);
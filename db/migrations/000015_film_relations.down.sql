@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS public.film_relations;
+
+DROP TYPE IF EXISTS film_relation;
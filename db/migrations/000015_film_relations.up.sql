@@ -0,0 +1,13 @@
+CREATE TYPE film_relation AS ENUM ('sequel', 'remake', 'franchise');
+
+-- related_id is the <type> of film_id; prequels and originals are stored
+-- as the inverse sequels and remakes, franchise links from the lower id
+CREATE TABLE IF NOT EXISTS public.film_relations (
+	film_id integer NOT NULL REFERENCES public.films(id) ON DELETE CASCADE,
+	related_id integer NOT NULL REFERENCES public.films(id) ON DELETE CASCADE,
+	type film_relation NOT NULL,
+	PRIMARY KEY (film_id, related_id, type),
+	CHECK (film_id <> related_id)
+);
+
+CREATE INDEX IF NOT EXISTS film_relations_related_id_idx ON public.film_relations(related_id);
@@ -0,0 +1,2 @@
+ALTER TABLE public.actors DROP COLUMN IF EXISTS photo;
+ALTER TABLE public.films DROP COLUMN IF EXISTS poster;
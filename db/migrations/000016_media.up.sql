@@ -0,0 +1,4 @@
+-- uploaded pictures as {"key", "url", "thumbnails"}; the files live in
+-- blob storage under key
+ALTER TABLE public.films ADD COLUMN IF NOT EXISTS poster jsonb;
+ALTER TABLE public.actors ADD COLUMN IF NOT EXISTS photo jsonb;
@@ -0,0 +1,10 @@
+-- locale is an ISO 639-1 language code with an optional ISO 3166-1
+-- region, e.g. 'pt' or 'pt-BR'; an empty description keeps the original
+CREATE TABLE IF NOT EXISTS public.film_translations (
+	film_id integer NOT NULL REFERENCES public.films(id) ON DELETE CASCADE,
+	locale varchar(5) NOT NULL,
+	name varchar(150) NOT NULL,
+	description varchar(500),
+	updated_at timestamptz NOT NULL DEFAULT now(),
+	PRIMARY KEY (film_id, locale)
+);
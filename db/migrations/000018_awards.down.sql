@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS public.nominations;
+
+DROP TABLE IF EXISTS public.awards;
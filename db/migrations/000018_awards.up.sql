@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS public.awards (
+	id SERIAL PRIMARY KEY,
+	ceremony varchar(100) NOT NULL,
+	year smallint NOT NULL,
+	category varchar(100) NOT NULL,
+	UNIQUE (ceremony, year, category)
+);
+
+-- actor_id is NULL for nominations of the film itself
+CREATE TABLE IF NOT EXISTS public.nominations (
+	id SERIAL PRIMARY KEY,
+	award_id integer NOT NULL REFERENCES public.awards(id) ON DELETE CASCADE,
+	film_id integer NOT NULL REFERENCES public.films(id) ON DELETE CASCADE,
+	actor_id integer REFERENCES public.actors(id) ON DELETE CASCADE,
+	won boolean NOT NULL DEFAULT false
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS nominations_award_film_actor_idx ON public.nominations(award_id, film_id, COALESCE(actor_id, 0));
+CREATE INDEX IF NOT EXISTS nominations_film_id_idx ON public.nominations(film_id);
+CREATE INDEX IF NOT EXISTS nominations_actor_id_idx ON public.nominations(actor_id);
@@ -2,36 +2,140 @@ package apiserver
 
 import (
 	"database/sql"
+	"log/slog"
 	"net/http"
+	"time"
 
 	"filmoteka/internal/app/apiserver/handlers"
+	"filmoteka/internal/app/media"
+	"filmoteka/internal/app/store"
+	"filmoteka/internal/app/store/cachestore"
 	"filmoteka/internal/app/store/sqlstore"
 
 	_ "github.com/lib/pq"
 )
 
 func Start(config *Config) error {
-	db, err := newDB(config.DatabaseURL)
+	db, err := newDB(config)
 	if err != nil {
 		return err
 	}
 	defer db.Close()
 
-	store := sqlstore.New(db)
-	srv := handlers.NewServer(store)
+	replicas, err := newReplicaDBs(config)
+	if err != nil {
+		return err
+	}
+	for _, r := range replicas {
+		defer r.Close()
+	}
+
+	sqlStore := sqlstore.New(db,
+		sqlstore.WithRetry(config.retryPolicy()),
+		sqlstore.WithReplicas(replicas...),
+		sqlstore.WithRatingWeight(config.RatingPriorWeight),
+		sqlstore.WithReviewLimit(sqlstore.RateLimit{
+			Count:  config.ReviewRateLimit,
+			Window: config.ReviewRateWindow,
+		}),
+	)
+	stop := sqlStore.StartHealthChecks(config.ReplicaHealthInterval)
+	defer stop()
+
+	var st store.IStore = sqlStore
+	if config.CacheEnabled {
+		st = cachestore.New(st, config.CacheSize, config.CacheTTL)
+	}
+	files := media.NewLocal(config.MediaDir, config.MediaURL)
+	srv := handlers.NewServer(st,
+		handlers.WithCacheControl(config.CacheControl),
+		handlers.WithAuthKey([]byte(config.SessionKey)),
+		handlers.WithMedia(files, config.UploadMaxBytes, config.UploadMaxPixels),
+		handlers.WithImportLimits(config.ImportMaxBytes, config.ImportMaxRows),
+	)
 
-	return http.ListenAndServe(config.BindAddr, srv)
+	mux := http.NewServeMux()
+	mux.Handle(files.Prefix(), files)
+	mux.Handle("/", srv)
+
+	return http.ListenAndServe(config.BindAddr, mux)
 }
 
-func newDB(dbURL string) (*sql.DB, error) {
-	db, err := sql.Open("postgres", dbURL)
+func newDB(config *Config) (*sql.DB, error) {
+	db, err := openDB(config, config.DatabaseURL)
 	if err != nil {
 		return nil, err
 	}
 
-	if err := db.Ping(); err != nil {
+	if err := pingWithBackoff(
+		db.Ping,
+		config.DBPingAttempts,
+		config.DBPingBackoff,
+		config.DBPingMaxDelay,
+		time.Sleep,
+	); err != nil {
+		db.Close()
 		return nil, err
 	}
 
 	return db, nil
 }
+
+// newReplicaDBs opens the read replica pools. Unreachable replicas do not
+// block startup, the health checks keep them out of rotation.
+func newReplicaDBs(config *Config) ([]*sql.DB, error) {
+	dbs := make([]*sql.DB, 0, len(config.DatabaseReplicaURLs))
+	for _, url := range config.DatabaseReplicaURLs {
+		db, err := openDB(config, url)
+		if err != nil {
+			for _, opened := range dbs {
+				opened.Close()
+			}
+			return nil, err
+		}
+		dbs = append(dbs, db)
+	}
+
+	return dbs, nil
+}
+
+func openDB(config *Config, url string) (*sql.DB, error) {
+	db, err := sql.Open("postgres", url)
+	if err != nil {
+		return nil, err
+	}
+
+	db.SetMaxOpenConns(config.DBMaxOpenConns)
+	db.SetMaxIdleConns(config.DBMaxIdleConns)
+	db.SetConnMaxLifetime(config.DBConnMaxLifetime)
+	db.SetConnMaxIdleTime(config.DBConnMaxIdleTime)
+
+	return db, nil
+}
+
+// pingWithBackoff calls ping up to attempts times, doubling the delay
+// between attempts from base up to maxDelay.
+func pingWithBackoff(ping func() error, attempts int, base, maxDelay time.Duration, sleep func(time.Duration)) error {
+	delay := base
+	for attempt := 1; ; attempt++ {
+		err := ping()
+		if err == nil || attempt >= attempts {
+			return err
+		}
+
+		slog.Warn("database not ready, retrying", "attempt", attempt, "delay", delay, "error", err)
+		sleep(delay)
+		delay *= 2
+		if maxDelay > 0 && delay > maxDelay {
+			delay = maxDelay
+		}
+	}
+}
+
+func (c *Config) retryPolicy() sqlstore.RetryPolicy {
+	return sqlstore.RetryPolicy{
+		MaxAttempts: c.RetryMaxAttempts,
+		BaseDelay:   c.RetryBaseDelay,
+		MaxDelay:    c.RetryMaxDelay,
+	}
+}
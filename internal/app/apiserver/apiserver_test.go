@@ -0,0 +1,83 @@
+package apiserver
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"filmoteka/internal/app/media"
+)
+
+func TestPingWithBackoff(t *testing.T) {
+	errNotReady := errors.New("connection refused")
+
+	tests := []struct {
+		name      string
+		failures  int
+		attempts  int
+		wantErr   bool
+		wantSleep []time.Duration
+	}{
+		{
+			name:      "Ready at once",
+			failures:  0,
+			attempts:  5,
+			wantSleep: nil,
+		},
+		{
+			name:      "Ready after retries",
+			failures:  3,
+			attempts:  5,
+			wantSleep: []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 250 * time.Millisecond},
+		},
+		{
+			name:      "Gives up",
+			failures:  10,
+			attempts:  2,
+			wantErr:   true,
+			wantSleep: []time.Duration{100 * time.Millisecond},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			ping := func() error {
+				calls++
+				if calls <= tt.failures {
+					return errNotReady
+				}
+				return nil
+			}
+			var slept []time.Duration
+			sleep := func(d time.Duration) { slept = append(slept, d) }
+
+			err := pingWithBackoff(ping, tt.attempts, 100*time.Millisecond, 250*time.Millisecond, sleep)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, errNotReady)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantSleep, slept)
+		})
+	}
+}
+
+func TestRemoveImages(t *testing.T) {
+	dir := t.TempDir()
+	files := media.NewLocal(dir, "/media/")
+	for _, key := range []string{"films/1/poster/ab/original.png", "films/2/poster/cd/original.png"} {
+		assert.NoError(t, files.Put(key, []byte("data")))
+	}
+
+	assert.NoError(t, removeImages(files, "films", []int{1, 3}))
+
+	_, err := os.Stat(filepath.Join(dir, "films", "1"))
+	assert.True(t, os.IsNotExist(err))
+	_, err = os.Stat(filepath.Join(dir, "films", "2", "poster", "cd", "original.png"))
+	assert.NoError(t, err)
+}
@@ -1,17 +1,89 @@
 package apiserver
 
+import "time"
+
 // Config ...
 type Config struct {
 	BindAddr    string `toml:"bind_addr"`
 	LogLevel    string `toml:"log_level"`
 	DatabaseURL string `toml:"database_url"`
-	SessionKey  string `toml:"session_key"`
+	// Read replicas serving reads outside transactions, health checked
+	// every ReplicaHealthInterval.
+	DatabaseReplicaURLs   []string      `toml:"database_replica_urls"`
+	ReplicaHealthInterval time.Duration `toml:"replica_health_interval"`
+	// SessionKey signs the bearer tokens identifying API users.
+	SessionKey string `toml:"session_key"`
+	// CacheControl maps cacheable routes (films, film, actors, actor)
+	// to the Cache-Control header value sent with them.
+	CacheControl map[string]string `toml:"cache_control"`
+	// Repository cache; disabled unless CacheEnabled is set.
+	CacheEnabled bool          `toml:"cache_enabled"`
+	CacheSize    int           `toml:"cache_size"`
+	CacheTTL     time.Duration `toml:"cache_ttl"`
+	// Retries of transient database failures; 1 attempt disables them.
+	RetryMaxAttempts int           `toml:"retry_max_attempts"`
+	RetryBaseDelay   time.Duration `toml:"retry_base_delay"`
+	RetryMaxDelay    time.Duration `toml:"retry_max_delay"`
+	// Connection pool; zero values keep the database/sql defaults.
+	DBMaxOpenConns    int           `toml:"db_max_open_conns"`
+	DBMaxIdleConns    int           `toml:"db_max_idle_conns"`
+	DBConnMaxLifetime time.Duration `toml:"db_conn_max_lifetime"`
+	DBConnMaxIdleTime time.Duration `toml:"db_conn_max_idle_time"`
+	// Startup ping, retried with backoff while the database comes up.
+	DBPingAttempts int           `toml:"db_ping_attempts"`
+	DBPingBackoff  time.Duration `toml:"db_ping_backoff"`
+	DBPingMaxDelay time.Duration `toml:"db_ping_max_delay"`
+	// RatingPriorWeight is the number of user ratings the editor rating
+	// of a film counts as in its score; 0 scores by the plain mean.
+	RatingPriorWeight int `toml:"rating_prior_weight"`
+	// Reviews a user may write per ReviewRateWindow; 0 is unlimited.
+	ReviewRateLimit  int           `toml:"review_rate_limit"`
+	ReviewRateWindow time.Duration `toml:"review_rate_window"`
+	// Uploaded images are stored under MediaDir and served under the
+	// MediaURL path; uploads over UploadMaxBytes, or UploadMaxPixels once
+	// decoded, are refused.
+	MediaDir        string `toml:"media_dir"`
+	MediaURL        string `toml:"media_url"`
+	UploadMaxBytes  int64  `toml:"upload_max_bytes"`
+	UploadMaxPixels int    `toml:"upload_max_pixels"`
+	// Imports over the HTTP API are refused beyond ImportMaxBytes of body
+	// or ImportMaxRows rows; 0 is unlimited.
+	ImportMaxBytes int64 `toml:"import_max_bytes"`
+	ImportMaxRows  int   `toml:"import_max_rows"`
 }
 
 // NewConfig ...
 func NewConfig() *Config {
 	return &Config{
-		BindAddr: ":8080",
-		LogLevel: "debug",
+		BindAddr:  ":8080",
+		LogLevel:  "debug",
+		CacheSize: 1000,
+
+		ReplicaHealthInterval: 5 * time.Second,
+
+		CacheTTL: 5 * time.Minute,
+
+		RetryMaxAttempts: 3,
+		RetryBaseDelay:   50 * time.Millisecond,
+		RetryMaxDelay:    time.Second,
+
+		DBMaxOpenConns:    25,
+		DBMaxIdleConns:    25,
+		DBConnMaxLifetime: 30 * time.Minute,
+		DBConnMaxIdleTime: 5 * time.Minute,
+		DBPingAttempts:    10,
+		DBPingBackoff:     500 * time.Millisecond,
+		DBPingMaxDelay:    10 * time.Second,
+
+		ReviewRateLimit:  5,
+		ReviewRateWindow: time.Hour,
+
+		MediaDir:        "media",
+		MediaURL:        "/media/",
+		UploadMaxBytes:  10 << 20,
+		UploadMaxPixels: 16_000_000,
+
+		ImportMaxBytes: 32 << 20,
+		ImportMaxRows:  10000,
 	}
 }
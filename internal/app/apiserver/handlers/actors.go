@@ -14,6 +14,11 @@ type RequestActor struct {
 	Name      string `json:"name"`
 	Gender    string `json:"gender"`
 	BirthDate string `json:"birth_date"`
+	// The profile below is optional.
+	DeathDate  string   `json:"death_date"`
+	Birthplace string   `json:"birthplace"`
+	Biography  string   `json:"biography"`
+	Aliases    []string `json:"aliases"`
 }
 
 func (s *server) handleActorCreate() http.HandlerFunc {
@@ -26,13 +31,17 @@ func (s *server) handleActorCreate() http.HandlerFunc {
 		}
 
 		actor := models.Actor{
-			Name:      req.Name,
-			Gender:    req.Gender,
-			BirthDate: req.BirthDate,
+			Name:       req.Name,
+			Gender:     models.NormalizeGender(req.Gender),
+			BirthDate:  req.BirthDate,
+			DeathDate:  req.DeathDate,
+			Birthplace: req.Birthplace,
+			Biography:  req.Biography,
+			Aliases:    req.Aliases,
 		}
-		id, err := s.store.ActorRepo().Create(actor)
+		id, err := s.storeFor(r).ActorRepo().Create(actor)
 		if err != nil {
-			w.WriteHeader(http.StatusBadRequest)
+			w.WriteHeader(errorStatus(err))
 			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
 			return
 		}
@@ -52,13 +61,14 @@ func (s *server) handleActorFind() http.HandlerFunc {
 			return
 		}
 
-		film, err := s.store.ActorRepo().Find(id)
+		film, err := s.storeFor(r).ActorRepo().Find(id)
 		if err != nil {
-			w.WriteHeader(http.StatusBadRequest)
+			w.WriteHeader(errorStatus(err))
 			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
 			return
 		}
 
+		setLastModified(w, film.UpdatedAt)
 		w.WriteHeader(http.StatusOK)
 		json.NewEncoder(w).Encode(film)
 	})
@@ -67,14 +77,27 @@ func (s *server) handleActorFind() http.HandlerFunc {
 
 func (s *server) handleAllActors() http.HandlerFunc {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		filter, filtered, err := actorFilter(r)
+		if err != nil {
+			w.WriteHeader(filterStatus(err))
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
 
-		actors, err := s.store.ActorRepo().FindAll()
+		var actors []models.Actor
+		if filtered {
+			actors, err = s.storeFor(r).ActorRepo().FindBy(filter)
+		} else {
+			actors, err = s.storeFor(r).ActorRepo().FindAll()
+		}
 		if err != nil {
-			w.WriteHeader(http.StatusBadRequest)
+			w.WriteHeader(errorStatus(err))
 			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
 			return
 		}
 
+		// no Last-Modified: rows leaving the list do not move it, the
+		// ETag covers the whole body
 		w.WriteHeader(http.StatusOK)
 		json.NewEncoder(w).Encode(actors)
 	})
@@ -89,14 +112,35 @@ func (s *server) handleActorDelete() http.HandlerFunc {
 			return
 		}
 
-		err = s.store.ActorRepo().Delete(id)
+		err = s.storeFor(r).ActorRepo().Delete(id)
 		// fmt.Println("Controller deleted:", deleted)
+		if err != nil {
+			w.WriteHeader(errorStatus(err))
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(map[string]bool{"result": true})
+	})
+}
+
+// handleActorRestore brings back a soft deleted actor.
+func (s *server) handleActorRestore() http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(mux.Vars(r)["id"])
 		if err != nil {
 			w.WriteHeader(http.StatusBadRequest)
 			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
 			return
 		}
 
+		if err := s.storeFor(r).ActorRepo().Restore(id); err != nil {
+			w.WriteHeader(errorStatus(err))
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+
 		w.WriteHeader(http.StatusOK)
 		json.NewEncoder(w).Encode(map[string]bool{"result": true})
 	})
@@ -119,15 +163,19 @@ func (s *server) handleActorUpdate() http.HandlerFunc {
 		}
 
 		actor := models.Actor{
-			Id:        id,
-			Name:      req.Name,
-			Gender:    req.Gender,
-			BirthDate: req.BirthDate,
+			Id:         id,
+			Name:       req.Name,
+			Gender:     models.NormalizeGender(req.Gender),
+			BirthDate:  req.BirthDate,
+			DeathDate:  req.DeathDate,
+			Birthplace: req.Birthplace,
+			Biography:  req.Biography,
+			Aliases:    req.Aliases,
 		}
 
-		err = s.store.ActorRepo().Update(actor)
+		err = s.storeFor(r).ActorRepo().Update(actor)
 		if err != nil {
-			w.WriteHeader(http.StatusBadRequest)
+			w.WriteHeader(errorStatus(err))
 			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
 			return
 		}
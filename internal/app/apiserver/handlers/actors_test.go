@@ -13,6 +13,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"filmoteka/internal/app/models"
+	"filmoteka/internal/app/store"
 	"filmoteka/internal/app/store/mock_store"
 )
 
@@ -23,7 +24,7 @@ func TestHandler_ActorCreate(t *testing.T) {
 	// testActor := models.Actor{
 	// 	Id:        1,
 	// 	Name:      "Name 1",
-	// 	Gender:    "M",
+	// 	Gender:    "male",
 	// 	BirthDate: "1995-01-12",
 	// }
 
@@ -37,10 +38,10 @@ func TestHandler_ActorCreate(t *testing.T) {
 	}{
 		{
 			name:      "Ok",
-			inputBody: `{"name":"Name 1","gender":"M","birth_date":"1995-01-12"}`,
+			inputBody: `{"name":"Name 1","gender":"male","birth_date":"1995-01-12"}`,
 			input: models.Actor{
 				Name:      "Name 1",
-				Gender:    "M",
+				Gender:    "male",
 				BirthDate: "1995-01-12",
 			},
 			mockBehavior: func(r *mock_store.MockIActorRepository, a models.Actor) {
@@ -49,6 +50,37 @@ func TestHandler_ActorCreate(t *testing.T) {
 			expectedStatusCode:   201,
 			expectedResponseBody: `{"id":1}`,
 		},
+		{
+			name:      "Legacy Gender Code",
+			inputBody: `{"name":"Name 1","gender":"F","birth_date":"1995-01-12"}`,
+			input: models.Actor{
+				Name:      "Name 1",
+				Gender:    "female",
+				BirthDate: "1995-01-12",
+			},
+			mockBehavior: func(r *mock_store.MockIActorRepository, a models.Actor) {
+				r.EXPECT().Create(a).Return(1, nil)
+			},
+			expectedStatusCode:   201,
+			expectedResponseBody: `{"id":1}`,
+		},
+		{
+			name:      "With Profile",
+			inputBody: `{"name":"Name 1","gender":"male","birth_date":"1925-01-12","death_date":"1990-06-01","birthplace":"Lyon","aliases":["N. One"]}`,
+			input: models.Actor{
+				Name:       "Name 1",
+				Gender:     "male",
+				BirthDate:  "1925-01-12",
+				DeathDate:  "1990-06-01",
+				Birthplace: "Lyon",
+				Aliases:    []string{"N. One"},
+			},
+			mockBehavior: func(r *mock_store.MockIActorRepository, a models.Actor) {
+				r.EXPECT().Create(a).Return(1, nil)
+			},
+			expectedStatusCode:   201,
+			expectedResponseBody: `{"id":1}`,
+		},
 		{
 			name:                 "Wrong Input",
 			inputBody:            "",
@@ -59,10 +91,10 @@ func TestHandler_ActorCreate(t *testing.T) {
 		},
 		{
 			name:      "Service Error",
-			inputBody: `{"name":"Name 1","gender":"M","birth_date":"1995-01-12"}`,
+			inputBody: `{"name":"Name 1","gender":"male","birth_date":"1995-01-12"}`,
 			input: models.Actor{
 				Name:      "Name 1",
-				Gender:    "M",
+				Gender:    "male",
 				BirthDate: "1995-01-12",
 			},
 			mockBehavior: func(r *mock_store.MockIActorRepository, a models.Actor) {
@@ -112,7 +144,7 @@ func TestHandler_ActorFind(t *testing.T) {
 	testActor := models.Actor{
 		Id:        1,
 		Name:      "Name 1",
-		Gender:    "M",
+		Gender:    "male",
 		BirthDate: "1995-01-12",
 	}
 
@@ -132,7 +164,7 @@ func TestHandler_ActorFind(t *testing.T) {
 				r.EXPECT().Find(id).Return(testActor, nil)
 			},
 			expectedStatusCode:   200,
-			expectedResponseBody: `{"id":1,"name":"Name 1","gender":"M","birth_date":"1995-01-12"}`,
+			expectedResponseBody: `{"id":1,"name":"Name 1","gender":"male","birth_date":"1995-01-12"}`,
 		},
 		{
 			name:      "Service Error",
@@ -187,13 +219,13 @@ func TestHandler_ActorFindAll(t *testing.T) {
 		{
 			Id:        1,
 			Name:      "Name 1",
-			Gender:    "M",
+			Gender:    "male",
 			BirthDate: "1995-01-12",
 		},
 		{
 			Id:        2,
 			Name:      "Name 2",
-			Gender:    "F",
+			Gender:    "female",
 			BirthDate: "1995-02-12",
 		},
 	}
@@ -212,7 +244,7 @@ func TestHandler_ActorFindAll(t *testing.T) {
 				r.EXPECT().FindAll().Return(actors, nil)
 			},
 			expectedStatusCode:   200,
-			expectedResponseBody: `[{"id":1,"name":"Name 1","gender":"M","birth_date":"1995-01-12"},{"id":2,"name":"Name 2","gender":"F","birth_date":"1995-02-12"}]`,
+			expectedResponseBody: `[{"id":1,"name":"Name 1","gender":"male","birth_date":"1995-01-12"},{"id":2,"name":"Name 2","gender":"female","birth_date":"1995-02-12"}]`,
 		},
 		{
 			name:      "Service Error",
@@ -327,7 +359,7 @@ func TestHandler_ActorUpdate(t *testing.T) {
 	testActor := models.Actor{
 		Id:        1,
 		Name:      "Name 1",
-		Gender:    "M",
+		Gender:    "male",
 		BirthDate: "1995-01-12",
 	}
 
@@ -342,17 +374,17 @@ func TestHandler_ActorUpdate(t *testing.T) {
 		{
 			name:      "Ok",
 			input:     testActor,
-			inputBody: `{"name":"Name 1","gender":"M","birth_date":"1995-01-12"}`,
+			inputBody: `{"name":"Name 1","gender":"male","birth_date":"1995-01-12"}`,
 			mockBehavior: func(r *mock_store.MockIActorRepository, a models.Actor) {
 				r.EXPECT().Update(a).Return(nil)
 			},
 			expectedStatusCode:   200,
-			expectedResponseBody: `{"id":1,"name":"Name 1","gender":"M","birth_date":"1995-01-12"}`,
+			expectedResponseBody: `{"id":1,"name":"Name 1","gender":"male","birth_date":"1995-01-12"}`,
 		},
 		{
 			name:      "Service Error",
 			input:     testActor,
-			inputBody: `{"name":"Name 1","gender":"M","birth_date":"1995-01-12"}`,
+			inputBody: `{"name":"Name 1","gender":"male","birth_date":"1995-01-12"}`,
 			mockBehavior: func(r *mock_store.MockIActorRepository, a models.Actor) {
 				r.EXPECT().Update(a).Return(errors.New(`something went wrong`))
 			},
@@ -391,3 +423,21 @@ func TestHandler_ActorUpdate(t *testing.T) {
 		})
 	}
 }
+
+func TestHandler_ActorFindAllByName(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	filmRepo := mock_store.NewMockIFilmRepository(c)
+	actorRepo := mock_store.NewMockIActorRepository(c)
+	actorRepo.EXPECT().FindBy(store.ActorFilter{Name: "rock"}).Return([]models.Actor{
+		{Id: 1, Name: "Dwayne Johnson", Gender: "male", BirthDate: "1972-05-02", Aliases: []string{"The Rock"}},
+	}, nil)
+	server := NewServer(mock_store.New(filmRepo, actorRepo))
+
+	w := httptest.NewRecorder()
+	server.ServeHTTP(w, httptest.NewRequest("GET", "/actors?name=rock", nil))
+
+	assert.Equal(t, 200, w.Code)
+	assert.Equal(t, `[{"id":1,"name":"Dwayne Johnson","gender":"male","birth_date":"1972-05-02","aliases":["The Rock"]}]`, strings.TrimRight(w.Body.String(), "\n"))
+}
@@ -0,0 +1,41 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"filmoteka/internal/app/store"
+)
+
+// handleAudit lists the audit log of an entity type given as ?entity=,
+// narrowed to a single entity by ?id=.
+func (s *server) handleAudit() http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		filter := store.AuditFilter{Entity: r.URL.Query().Get("entity")}
+		if filter.Entity != store.EntityFilm && filter.Entity != store.EntityActor {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": "entity must be film or actor"})
+			return
+		}
+		if v := r.URL.Query().Get("id"); v != "" {
+			id, err := strconv.Atoi(v)
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+				return
+			}
+			filter.EntityID = id
+		}
+
+		entries, err := s.storeFor(r).AuditRepo().Find(filter)
+		if err != nil {
+			w.WriteHeader(errorStatus(err))
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(entries)
+	})
+}
@@ -0,0 +1,121 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"filmoteka/internal/app/auth"
+	"filmoteka/internal/app/models"
+	"filmoteka/internal/app/store"
+	"filmoteka/internal/app/store/mock_store"
+)
+
+func TestHandler_Audit(t *testing.T) {
+	key := []byte("test-key")
+	adminToken, _ := auth.Sign(key, auth.Claims{UserID: 1, Role: auth.RoleAdmin})
+	userToken, _ := auth.Sign(key, auth.Claims{UserID: 2, Role: auth.RoleUser})
+
+	user := 2
+	entries := []models.AuditEntry{
+		{
+			Id:        1,
+			UserID:    &user,
+			RequestID: "req-1",
+			Entity:    "film",
+			EntityID:  1,
+			Action:    "update",
+			Before:    json.RawMessage(`{"rating":5}`),
+			After:     json.RawMessage(`{"rating":7}`),
+			CreatedAt: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+		},
+	}
+
+	tests := []struct {
+		name                 string
+		token                string
+		url                  string
+		mockBehavior         func(r *mock_store.MockIAuditRepository)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:  "Ok",
+			token: adminToken,
+			url:   "/audit?entity=film&id=1",
+			mockBehavior: func(r *mock_store.MockIAuditRepository) {
+				r.EXPECT().Find(store.AuditFilter{Entity: "film", EntityID: 1}).Return(entries, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `[{"id":1,"user_id":2,"request_id":"req-1","entity":"film","entity_id":1,"action":"update","before":{"rating":5},"after":{"rating":7},"created_at":"2024-05-01T12:00:00Z"}]`,
+		},
+		{
+			name:                 "Unknown Entity",
+			token:                adminToken,
+			url:                  "/audit?entity=user",
+			mockBehavior:         func(r *mock_store.MockIAuditRepository) {},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"error":"entity must be film or actor"}`,
+		},
+		{
+			name:                 "Not Admin",
+			token:                userToken,
+			url:                  "/audit?entity=film&id=1",
+			mockBehavior:         func(r *mock_store.MockIAuditRepository) {},
+			expectedStatusCode:   403,
+			expectedResponseBody: `{"error":"admin role required"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			auditRepo := mock_store.NewMockIAuditRepository(c)
+			test.mockBehavior(auditRepo)
+			store := mock_store.New(mock_store.NewMockIFilmRepository(c), mock_store.NewMockIActorRepository(c)).
+				WithAuditRepo(auditRepo)
+			server := NewServer(store, WithAuthKey(key))
+
+			// Create Request
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", test.url, nil)
+			req.Header.Set("Authorization", "Bearer "+test.token)
+
+			// Make Request
+			server.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, strings.TrimRight(w.Body.String(), "\n"))
+		})
+	}
+}
+
+func TestRequestID(t *testing.T) {
+	s := NewServer(nil)
+	var got string
+	h := s.requestID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = requestIDFrom(r)
+	}))
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set("X-Request-ID", "req-1")
+	h.ServeHTTP(w, req)
+	assert.Equal(t, "req-1", got)
+	assert.Equal(t, "req-1", w.Header().Get("X-Request-ID"))
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+	assert.Len(t, got, 32)
+	assert.Equal(t, got, w.Header().Get("X-Request-ID"))
+}
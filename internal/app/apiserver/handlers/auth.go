@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"filmoteka/internal/app/auth"
+)
+
+type ctxKey int
+
+const (
+	ctxKeyClaims ctxKey = iota
+	ctxKeyRequestID
+)
+
+// WithAuthKey sets the key bearer tokens are verified with.
+func WithAuthKey(key []byte) Option {
+	return func(s *server) {
+		s.authKey = key
+	}
+}
+
+// authenticate verifies an "Authorization: Bearer" token and attaches its
+// claims to the request context. Requests without a token stay anonymous.
+func (s *server) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header := r.Header.Get("Authorization")
+		if header == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		token, ok := strings.CutPrefix(header, "Bearer ")
+		if !ok {
+			w.WriteHeader(http.StatusUnauthorized)
+			json.NewEncoder(w).Encode(map[string]string{"error": auth.ErrInvalidToken.Error()})
+			return
+		}
+		claims, err := auth.Verify(s.authKey, token, time.Now())
+		if err != nil {
+			w.WriteHeader(http.StatusUnauthorized)
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), ctxKeyClaims, claims)))
+	})
+}
+
+func claimsFrom(r *http.Request) (auth.Claims, bool) {
+	c, ok := r.Context().Value(ctxKeyClaims).(auth.Claims)
+	return c, ok
+}
+
+func isAdmin(r *http.Request) bool {
+	c, ok := claimsFrom(r)
+	return ok && c.IsAdmin()
+}
+
+// requireUser rejects anonymous requests.
+func (s *server) requireUser(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := claimsFrom(r); !ok {
+			w.WriteHeader(http.StatusUnauthorized)
+			json.NewEncoder(w).Encode(map[string]string{"error": "authentication required"})
+			return
+		}
+		next(w, r)
+	}
+}
+
+// requireAdmin rejects requests not made by an admin.
+func (s *server) requireAdmin(next http.HandlerFunc) http.HandlerFunc {
+	return s.requireUser(func(w http.ResponseWriter, r *http.Request) {
+		if !isAdmin(r) {
+			w.WriteHeader(http.StatusForbidden)
+			json.NewEncoder(w).Encode(map[string]string{"error": "admin role required"})
+			return
+		}
+		next(w, r)
+	})
+}
@@ -0,0 +1,197 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+
+	"filmoteka/internal/app/models"
+	"filmoteka/internal/app/store"
+)
+
+type RequestAward struct {
+	Ceremony string `json:"ceremony"`
+	Year     uint16 `json:"year"`
+	Category string `json:"category"`
+}
+
+type RequestNomination struct {
+	FilmID int `json:"film_id"`
+	// ActorID is left out for nominations of the film itself.
+	ActorID int  `json:"actor_id"`
+	Won     bool `json:"won"`
+}
+
+type RequestWon struct {
+	Won bool `json:"won"`
+}
+
+func (s *server) handleAwardCreate() http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := &RequestAward{}
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+
+		award := models.Award{Ceremony: req.Ceremony, Year: req.Year, Category: req.Category}
+		id, err := s.storeFor(r).AwardRepo().Create(award)
+		if err != nil {
+			w.WriteHeader(errorStatus(err))
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(map[string]int{"id": id})
+	})
+}
+
+func (s *server) handleAllAwards() http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		awards, err := s.storeFor(r).AwardRepo().FindAll()
+		if err != nil {
+			w.WriteHeader(errorStatus(err))
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(awards)
+	})
+}
+
+func (s *server) handleAwardDelete() http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(mux.Vars(r)["id"])
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+
+		if err := s.storeFor(r).AwardRepo().Delete(id); err != nil {
+			w.WriteHeader(errorStatus(err))
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(map[string]bool{"result": true})
+	})
+}
+
+func (s *server) handleNominate() http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(mux.Vars(r)["id"])
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+
+		req := &RequestNomination{}
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+
+		nomination := models.Nomination{AwardID: id, FilmID: req.FilmID, ActorID: req.ActorID, Won: req.Won}
+		nominationID, err := s.storeFor(r).AwardRepo().Nominate(nomination)
+		if err != nil {
+			w.WriteHeader(errorStatus(err))
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(map[string]int{"id": nominationID})
+	})
+}
+
+// handleNominationWon marks a nomination as won or not.
+func (s *server) handleNominationWon() http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(mux.Vars(r)["id"])
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+
+		req := &RequestWon{}
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+
+		if err := s.storeFor(r).AwardRepo().SetWon(id, req.Won); err != nil {
+			w.WriteHeader(errorStatus(err))
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(map[string]bool{"result": true})
+	})
+}
+
+func (s *server) handleNominationRemove() http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(mux.Vars(r)["id"])
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+
+		if err := s.storeFor(r).AwardRepo().RemoveNomination(id); err != nil {
+			w.WriteHeader(errorStatus(err))
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(map[string]bool{"result": true})
+	})
+}
+
+func (s *server) handleFilmAwards() http.HandlerFunc {
+	return s.nominations(func(st store.IStore, id int) ([]models.Nomination, error) {
+		return st.AwardRepo().ByFilm(id)
+	})
+}
+
+func (s *server) handleActorAwards() http.HandlerFunc {
+	return s.nominations(func(st store.IStore, id int) ([]models.Nomination, error) {
+		return st.AwardRepo().ByActor(id)
+	})
+}
+
+// nominations returns a handler listing the nominations list reads for
+// the {id} of the route.
+func (s *server) nominations(list func(store.IStore, int) ([]models.Nomination, error)) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(mux.Vars(r)["id"])
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+
+		nominations, err := list(s.storeFor(r), id)
+		if err != nil {
+			w.WriteHeader(errorStatus(err))
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(nominations)
+	})
+}
@@ -0,0 +1,145 @@
+package handlers
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"filmoteka/internal/app/models"
+	"filmoteka/internal/app/store"
+	"filmoteka/internal/app/store/mock_store"
+)
+
+func TestHandler_Awards(t *testing.T) {
+	type mockBehavior func(f *mock_store.MockIFilmRepository, r *mock_store.MockIAwardRepository)
+
+	tests := []struct {
+		name                 string
+		method               string
+		url                  string
+		inputBody            string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "Create",
+			method:    "POST",
+			url:       "/awards",
+			inputBody: `{"ceremony":"Academy Awards","year":1995,"category":"Best Picture"}`,
+			mockBehavior: func(f *mock_store.MockIFilmRepository, r *mock_store.MockIAwardRepository) {
+				r.EXPECT().Create(models.Award{Ceremony: "Academy Awards", Year: 1995, Category: "Best Picture"}).Return(1, nil)
+			},
+			expectedStatusCode:   201,
+			expectedResponseBody: `{"id":1}`,
+		},
+		{
+			name:      "Create Duplicate",
+			method:    "POST",
+			url:       "/awards",
+			inputBody: `{"ceremony":"Academy Awards","year":1995,"category":"Best Picture"}`,
+			mockBehavior: func(f *mock_store.MockIFilmRepository, r *mock_store.MockIAwardRepository) {
+				r.EXPECT().Create(models.Award{Ceremony: "Academy Awards", Year: 1995, Category: "Best Picture"}).Return(0, store.ErrUniqueConstraints)
+			},
+			expectedStatusCode:   409,
+			expectedResponseBody: `{"error":"unique constraints violation"}`,
+		},
+		{
+			name:      "Nominate",
+			method:    "POST",
+			url:       "/awards/3/nominations",
+			inputBody: `{"film_id":2,"actor_id":5}`,
+			mockBehavior: func(f *mock_store.MockIFilmRepository, r *mock_store.MockIAwardRepository) {
+				r.EXPECT().Nominate(models.Nomination{AwardID: 3, FilmID: 2, ActorID: 5}).Return(8, nil)
+			},
+			expectedStatusCode:   201,
+			expectedResponseBody: `{"id":8}`,
+		},
+		{
+			name:      "Won",
+			method:    "PUT",
+			url:       "/nominations/8",
+			inputBody: `{"won":true}`,
+			mockBehavior: func(f *mock_store.MockIFilmRepository, r *mock_store.MockIAwardRepository) {
+				r.EXPECT().SetWon(8, true).Return(nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"result":true}`,
+		},
+		{
+			name:   "Remove Not Found",
+			method: "DELETE",
+			url:    "/nominations/9",
+			mockBehavior: func(f *mock_store.MockIFilmRepository, r *mock_store.MockIAwardRepository) {
+				r.EXPECT().RemoveNomination(9).Return(store.ErrResourceNotFound)
+			},
+			expectedStatusCode:   404,
+			expectedResponseBody: `{"error":"resource not found"}`,
+		},
+		{
+			name:   "Film Awards",
+			method: "GET",
+			url:    "/films/2/awards",
+			mockBehavior: func(f *mock_store.MockIFilmRepository, r *mock_store.MockIAwardRepository) {
+				r.EXPECT().ByFilm(2).Return([]models.Nomination{
+					{Id: 7, AwardID: 1, Ceremony: "Academy Awards", Year: 1995, Category: "Best Picture", FilmID: 2, Film: "Forrest Gump", Won: true},
+				}, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `[{"id":7,"award_id":1,"ceremony":"Academy Awards","year":1995,"category":"Best Picture","film_id":2,"film":"Forrest Gump","won":true}]`,
+		},
+		{
+			name:   "Actor Awards",
+			method: "GET",
+			url:    "/actors/5/awards",
+			mockBehavior: func(f *mock_store.MockIFilmRepository, r *mock_store.MockIAwardRepository) {
+				r.EXPECT().ByActor(5).Return([]models.Nomination{
+					{Id: 8, AwardID: 3, Ceremony: "Academy Awards", Year: 1995, Category: "Best Actor", FilmID: 2, Film: "Forrest Gump", ActorID: 5, Actor: "Tom Hanks", Won: true},
+				}, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `[{"id":8,"award_id":3,"ceremony":"Academy Awards","year":1995,"category":"Best Actor","film_id":2,"film":"Forrest Gump","actor_id":5,"actor":"Tom Hanks","won":true}]`,
+		},
+		{
+			name:   "Films Won",
+			method: "GET",
+			url:    "/films?won=Academy+Awards&won_category=Best+Picture",
+			mockBehavior: func(f *mock_store.MockIFilmRepository, r *mock_store.MockIAwardRepository) {
+				f.EXPECT().FindBy(store.FilmFilter{WonCeremony: "Academy Awards", WonCategory: "Best Picture"}).Return([]models.Film{
+					{Id: 2, Name: "Forrest Gump", Description: "Life is like a box of chocolates.", ReleaseYear: 1994, Rating: 8.8},
+				}, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `[{"id":2,"name":"Forrest Gump","description":"Life is like a box of chocolates.","release_year":1994,"rating":8.8}]`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			filmRepo := mock_store.NewMockIFilmRepository(c)
+			awardRepo := mock_store.NewMockIAwardRepository(c)
+			test.mockBehavior(filmRepo, awardRepo)
+			st := mock_store.New(filmRepo, mock_store.NewMockIActorRepository(c)).WithAwardRepo(awardRepo)
+			server := NewServer(st)
+
+			// Create Request
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(test.method, test.url, bytes.NewBufferString(test.inputBody))
+
+			// Make Request
+			server.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, strings.TrimRight(w.Body.String(), "\n"))
+		})
+	}
+}
@@ -0,0 +1,165 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+
+	"filmoteka/internal/app/auth"
+	"filmoteka/internal/app/models"
+	"filmoteka/internal/app/store"
+	"filmoteka/internal/app/store/mock_store"
+)
+
+func TestHandler_FilmFindConditional(t *testing.T) {
+	updatedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	testFilm := models.Film{
+		Id:          1,
+		Name:        "Test Name",
+		Description: "Desc1",
+		ReleaseYear: 2002,
+		Rating:      7.5,
+		UpdatedAt:   updatedAt,
+	}
+
+	// Init Dependencies
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	filmRepo := mock_store.NewMockIFilmRepository(c)
+	actorRepo := mock_store.NewMockIActorRepository(c)
+	filmRepo.EXPECT().Find(1).Return(testFilm, nil).AnyTimes()
+	store := mock_store.New(filmRepo, actorRepo)
+	server := NewServer(store, WithCacheControl(map[string]string{"film": "public, max-age=300"}))
+
+	// Init Endpoint
+	router := mux.NewRouter()
+	router.HandleFunc("/films/{id}", server.conditional("film", server.handleFilmFind())).Methods("GET")
+
+	// First request returns the body and validators
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/films/1", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	etag := w.Header().Get("ETag")
+	assert.NotEmpty(t, etag)
+	assert.Equal(t, "public, max-age=300", w.Header().Get("Cache-Control"))
+	assert.Equal(t, updatedAt.Format(http.TimeFormat), w.Header().Get("Last-Modified"))
+
+	tests := []struct {
+		name               string
+		headers            map[string]string
+		expectedStatusCode int
+	}{
+		{
+			name:               "If-None-Match matches",
+			headers:            map[string]string{"If-None-Match": etag},
+			expectedStatusCode: http.StatusNotModified,
+		},
+		{
+			name:               "If-None-Match weak matches",
+			headers:            map[string]string{"If-None-Match": `"other", W/` + etag},
+			expectedStatusCode: http.StatusNotModified,
+		},
+		{
+			name:               "If-None-Match differs",
+			headers:            map[string]string{"If-None-Match": `"other"`},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "If-Modified-Since not modified",
+			headers:            map[string]string{"If-Modified-Since": updatedAt.Format(http.TimeFormat)},
+			expectedStatusCode: http.StatusNotModified,
+		},
+		{
+			name:               "If-Modified-Since modified",
+			headers:            map[string]string{"If-Modified-Since": updatedAt.Add(-time.Hour).Format(http.TimeFormat)},
+			expectedStatusCode: http.StatusOK,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/films/1", nil)
+			for k, v := range test.headers {
+				req.Header.Set(k, v)
+			}
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, etag, w.Header().Get("ETag"))
+			if test.expectedStatusCode == http.StatusNotModified {
+				assert.Empty(t, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestHandler_FilmListConditional(t *testing.T) {
+	updatedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	films := []models.Film{{Id: 1, Name: "Test Name", Description: "Desc1", ReleaseYear: 2002, Rating: 7.5, UpdatedAt: updatedAt}}
+
+	// Init Dependencies
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	filmRepo := mock_store.NewMockIFilmRepository(c)
+	filmRepo.EXPECT().FindAll().Return(films, nil).Times(2)
+	server := NewServer(mock_store.New(filmRepo, mock_store.NewMockIActorRepository(c)))
+
+	// Lists carry no Last-Modified, a row leaving the list does not move it
+	w := httptest.NewRecorder()
+	server.ServeHTTP(w, httptest.NewRequest("GET", "/films", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Header().Get("Last-Modified"))
+
+	// so If-Modified-Since alone never answers 304
+	w = httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/films", nil)
+	req.Header.Set("If-Modified-Since", updatedAt.Format(http.TimeFormat))
+	server.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestHandler_FilmListCredentialed(t *testing.T) {
+	key := []byte("test-key")
+	adminToken, _ := auth.Sign(key, auth.Claims{UserID: 1, Role: auth.RoleAdmin})
+	deletedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	films := []models.Film{{Id: 1, Name: "Test Name", Description: "Desc1", ReleaseYear: 2002, Rating: 7.5, DeletedAt: &deletedAt}}
+
+	// Init Dependencies
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	filmRepo := mock_store.NewMockIFilmRepository(c)
+	filmRepo.EXPECT().FindBy(store.FilmFilter{IncludeDeleted: true}).Return(films, nil)
+	filmRepo.EXPECT().FindAll().Return(nil, nil)
+	server := NewServer(mock_store.New(filmRepo, mock_store.NewMockIActorRepository(c)),
+		WithAuthKey(key), WithCacheControl(map[string]string{"films": "public, max-age=60"}))
+
+	// soft deleted rows must not reach a shared cache
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/films?include_deleted=true", nil)
+	req.Header.Set("Authorization", "Bearer "+adminToken)
+	server.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "private, no-store", w.Header().Get("Cache-Control"))
+	assert.Contains(t, w.Header().Values("Vary"), "Authorization")
+
+	// anonymous responses keep the route value
+	w = httptest.NewRecorder()
+	server.ServeHTTP(w, httptest.NewRequest("GET", "/films", nil))
+
+	assert.Equal(t, "public, max-age=60", w.Header().Get("Cache-Control"))
+	assert.Contains(t, w.Header().Values("Vary"), "Authorization")
+}
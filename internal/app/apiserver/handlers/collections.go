@@ -0,0 +1,314 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+
+	"filmoteka/internal/app/models"
+	"filmoteka/internal/app/store"
+)
+
+var errNotOwner = errors.New("only the owner may change the collection")
+
+type RequestCollection struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	// Visibility defaults to private.
+	Visibility string `json:"visibility"`
+}
+
+type RequestPlacement struct {
+	// Position defaults to the end of the collection.
+	Position int `json:"position"`
+}
+
+type RequestReorder struct {
+	FilmIDs []int `json:"film_ids"`
+}
+
+func (req *RequestCollection) collection() models.Collection {
+	c := models.Collection{
+		Title:       req.Title,
+		Description: req.Description,
+		Visibility:  req.Visibility,
+	}
+	if c.Visibility == "" {
+		c.Visibility = models.VisibilityPrivate
+	}
+
+	return c
+}
+
+// collectionFor reads a collection on behalf of the caller. Private
+// collections of other users count as missing, and only their owner and
+// admins may change collections.
+func (s *server) collectionFor(r *http.Request, id int, change bool) (models.Collection, error) {
+	c, err := s.storeFor(r).CollectionRepo().Find(id)
+	if err != nil {
+		return models.Collection{}, err
+	}
+
+	if claims, ok := claimsFrom(r); (ok && claims.UserID == c.OwnerID) || isAdmin(r) {
+		return c, nil
+	}
+	if c.Visibility != models.VisibilityPublic {
+		return models.Collection{}, store.ErrResourceNotFound
+	}
+	if change {
+		return models.Collection{}, errNotOwner
+	}
+
+	return c, nil
+}
+
+func (s *server) handleCollectionCreate() http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := &RequestCollection{}
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+
+		claims, _ := claimsFrom(r)
+		c := req.collection()
+		c.OwnerID = claims.UserID
+		id, err := s.storeFor(r).CollectionRepo().Create(c)
+		if err != nil {
+			w.WriteHeader(errorStatus(err))
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(map[string]int{"id": id})
+	})
+}
+
+// handleAllCollections lists the public collections and the caller's
+// own, those of a single owner with ?owner=. Admins see all.
+func (s *server) handleAllCollections() http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		filter := store.CollectionFilter{IncludePrivate: isAdmin(r)}
+		if claims, ok := claimsFrom(r); ok {
+			filter.ViewerID = claims.UserID
+		}
+		if v := r.URL.Query().Get("owner"); v != "" {
+			owner, err := strconv.Atoi(v)
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+				return
+			}
+			filter.OwnerID = owner
+		}
+
+		collections, err := s.storeFor(r).CollectionRepo().FindBy(filter)
+		if err != nil {
+			w.WriteHeader(errorStatus(err))
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(collections)
+	})
+}
+
+func (s *server) handleCollectionFind() http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(mux.Vars(r)["id"])
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+
+		c, err := s.collectionFor(r, id, false)
+		if err != nil {
+			w.WriteHeader(errorStatus(err))
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(c)
+	})
+}
+
+func (s *server) handleCollectionUpdate() http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(mux.Vars(r)["id"])
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+
+		req := &RequestCollection{}
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+
+		if _, err := s.collectionFor(r, id, true); err != nil {
+			w.WriteHeader(errorStatus(err))
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+
+		c := req.collection()
+		c.Id = id
+		if err := s.storeFor(r).CollectionRepo().Update(c); err != nil {
+			w.WriteHeader(errorStatus(err))
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(map[string]bool{"result": true})
+	})
+}
+
+func (s *server) handleCollectionDelete() http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(mux.Vars(r)["id"])
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+
+		if _, err := s.collectionFor(r, id, true); err != nil {
+			w.WriteHeader(errorStatus(err))
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+
+		if err := s.storeFor(r).CollectionRepo().Delete(id); err != nil {
+			w.WriteHeader(errorStatus(err))
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(map[string]bool{"result": true})
+	})
+}
+
+// handleCollectionPlace adds a film to a collection or moves it within.
+func (s *server) handleCollectionPlace() http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		id, err := strconv.Atoi(vars["id"])
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+		filmID, err := strconv.Atoi(vars["film_id"])
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+
+		// an empty body places the film last
+		req := &RequestPlacement{}
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil && err != io.EOF {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+
+		if _, err := s.collectionFor(r, id, true); err != nil {
+			w.WriteHeader(errorStatus(err))
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+
+		position, err := s.storeFor(r).CollectionRepo().Place(id, filmID, req.Position)
+		if err != nil {
+			w.WriteHeader(errorStatus(err))
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(map[string]int{"position": position})
+	})
+}
+
+func (s *server) handleCollectionRemoveFilm() http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		id, err := strconv.Atoi(vars["id"])
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+		filmID, err := strconv.Atoi(vars["film_id"])
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+
+		if _, err := s.collectionFor(r, id, true); err != nil {
+			w.WriteHeader(errorStatus(err))
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+
+		if err := s.storeFor(r).CollectionRepo().RemoveFilm(id, filmID); err != nil {
+			w.WriteHeader(errorStatus(err))
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(map[string]bool{"result": true})
+	})
+}
+
+// handleCollectionReorder moves the listed films to the front of the
+// collection in the listed order.
+func (s *server) handleCollectionReorder() http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(mux.Vars(r)["id"])
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+
+		req := &RequestReorder{}
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+
+		if _, err := s.collectionFor(r, id, true); err != nil {
+			w.WriteHeader(errorStatus(err))
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+
+		if err := s.storeFor(r).CollectionRepo().Reorder(id, req.FilmIDs); err != nil {
+			w.WriteHeader(errorStatus(err))
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(map[string]bool{"result": true})
+	})
+}
@@ -0,0 +1,222 @@
+package handlers
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"filmoteka/internal/app/auth"
+	"filmoteka/internal/app/models"
+	"filmoteka/internal/app/store"
+	"filmoteka/internal/app/store/mock_store"
+)
+
+func TestHandler_Collections(t *testing.T) {
+	key := []byte("test-key")
+	adminToken, _ := auth.Sign(key, auth.Claims{UserID: 1, Role: auth.RoleAdmin})
+	userToken, _ := auth.Sign(key, auth.Claims{UserID: 2, Role: auth.RoleUser})
+	otherToken, _ := auth.Sign(key, auth.Claims{UserID: 3, Role: auth.RoleUser})
+	at := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	public := models.Collection{Id: 1, Title: "Best of 1990s", OwnerID: 2, Visibility: "public", CreatedAt: at, UpdatedAt: at}
+	private := models.Collection{Id: 2, Title: "Drafts", OwnerID: 2, Visibility: "private", CreatedAt: at, UpdatedAt: at}
+
+	type mockBehavior func(r *mock_store.MockICollectionRepository)
+
+	tests := []struct {
+		name                 string
+		method               string
+		url                  string
+		token                string
+		inputBody            string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "Create Defaults To Private",
+			method:    "POST",
+			url:       "/collections",
+			token:     userToken,
+			inputBody: `{"title":"Drafts"}`,
+			mockBehavior: func(r *mock_store.MockICollectionRepository) {
+				r.EXPECT().Create(models.Collection{Title: "Drafts", OwnerID: 2, Visibility: "private"}).Return(2, nil)
+			},
+			expectedStatusCode:   201,
+			expectedResponseBody: `{"id":2}`,
+		},
+		{
+			name:                 "Create Anonymous",
+			method:               "POST",
+			url:                  "/collections",
+			inputBody:            `{"title":"Drafts"}`,
+			mockBehavior:         func(r *mock_store.MockICollectionRepository) {},
+			expectedStatusCode:   401,
+			expectedResponseBody: `{"error":"authentication required"}`,
+		},
+		{
+			name:   "List Anonymous",
+			method: "GET",
+			url:    "/collections?owner=2",
+			mockBehavior: func(r *mock_store.MockICollectionRepository) {
+				r.EXPECT().FindBy(store.CollectionFilter{OwnerID: 2}).Return([]models.Collection{public}, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `[{"id":1,"title":"Best of 1990s","owner_id":2,"visibility":"public","created_at":"2024-05-01T12:00:00Z","updated_at":"2024-05-01T12:00:00Z"}]`,
+		},
+		{
+			name:   "List Admin",
+			method: "GET",
+			url:    "/collections",
+			token:  adminToken,
+			mockBehavior: func(r *mock_store.MockICollectionRepository) {
+				r.EXPECT().FindBy(store.CollectionFilter{ViewerID: 1, IncludePrivate: true}).Return([]models.Collection{}, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `[]`,
+		},
+		{
+			name:   "Find Private Of Another User",
+			method: "GET",
+			url:    "/collections/2",
+			token:  otherToken,
+			mockBehavior: func(r *mock_store.MockICollectionRepository) {
+				r.EXPECT().Find(2).Return(private, nil)
+			},
+			expectedStatusCode:   404,
+			expectedResponseBody: `{"error":"resource not found"}`,
+		},
+		{
+			name:   "Find Private Of Owner",
+			method: "GET",
+			url:    "/collections/2",
+			token:  userToken,
+			mockBehavior: func(r *mock_store.MockICollectionRepository) {
+				c := private
+				c.Films = []models.CollectionFilm{{Position: 1, FilmID: 7, Name: "Film 7", ReleaseYear: 1994}}
+				r.EXPECT().Find(2).Return(c, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"id":2,"title":"Drafts","owner_id":2,"visibility":"private","created_at":"2024-05-01T12:00:00Z","updated_at":"2024-05-01T12:00:00Z","films":[{"position":1,"film_id":7,"name":"Film 7","release_year":1994}]}`,
+		},
+		{
+			name:      "Update Public Of Another User",
+			method:    "PUT",
+			url:       "/collections/1",
+			token:     otherToken,
+			inputBody: `{"title":"Mine now","visibility":"public"}`,
+			mockBehavior: func(r *mock_store.MockICollectionRepository) {
+				r.EXPECT().Find(1).Return(public, nil)
+			},
+			expectedStatusCode:   403,
+			expectedResponseBody: `{"error":"only the owner may change the collection"}`,
+		},
+		{
+			name:      "Update By Admin",
+			method:    "PUT",
+			url:       "/collections/1",
+			token:     adminToken,
+			inputBody: `{"title":"Best of the 1990s","visibility":"public"}`,
+			mockBehavior: func(r *mock_store.MockICollectionRepository) {
+				r.EXPECT().Find(1).Return(public, nil)
+				r.EXPECT().Update(models.Collection{Id: 1, Title: "Best of the 1990s", Visibility: "public"}).Return(nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"result":true}`,
+		},
+		{
+			name:   "Delete",
+			method: "DELETE",
+			url:    "/collections/1",
+			token:  userToken,
+			mockBehavior: func(r *mock_store.MockICollectionRepository) {
+				r.EXPECT().Find(1).Return(public, nil)
+				r.EXPECT().Delete(1).Return(nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"result":true}`,
+		},
+		{
+			name:   "Place Without Body",
+			method: "PUT",
+			url:    "/collections/1/films/7",
+			token:  userToken,
+			mockBehavior: func(r *mock_store.MockICollectionRepository) {
+				r.EXPECT().Find(1).Return(public, nil)
+				r.EXPECT().Place(1, 7, 0).Return(4, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"position":4}`,
+		},
+		{
+			name:      "Move",
+			method:    "PUT",
+			url:       "/collections/1/films/7",
+			token:     userToken,
+			inputBody: `{"position":1}`,
+			mockBehavior: func(r *mock_store.MockICollectionRepository) {
+				r.EXPECT().Find(1).Return(public, nil)
+				r.EXPECT().Place(1, 7, 1).Return(1, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"position":1}`,
+		},
+		{
+			name:   "Remove Film Not In Collection",
+			method: "DELETE",
+			url:    "/collections/1/films/9",
+			token:  userToken,
+			mockBehavior: func(r *mock_store.MockICollectionRepository) {
+				r.EXPECT().Find(1).Return(public, nil)
+				r.EXPECT().RemoveFilm(1, 9).Return(store.ErrResourceNotFound)
+			},
+			expectedStatusCode:   404,
+			expectedResponseBody: `{"error":"resource not found"}`,
+		},
+		{
+			name:      "Reorder Foreign Film",
+			method:    "PUT",
+			url:       "/collections/1/films",
+			token:     userToken,
+			inputBody: `{"film_ids":[5,8]}`,
+			mockBehavior: func(r *mock_store.MockICollectionRepository) {
+				r.EXPECT().Find(1).Return(public, nil)
+				r.EXPECT().Reorder(1, []int{5, 8}).Return(store.ErrValidation)
+			},
+			expectedStatusCode:   422,
+			expectedResponseBody: `{"error":"validation error"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			collectionRepo := mock_store.NewMockICollectionRepository(c)
+			test.mockBehavior(collectionRepo)
+			st := mock_store.New(mock_store.NewMockIFilmRepository(c), mock_store.NewMockIActorRepository(c)).
+				WithCollectionRepo(collectionRepo)
+			server := NewServer(st, WithAuthKey(key))
+
+			// Create Request
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(test.method, test.url, bytes.NewBufferString(test.inputBody))
+			if test.token != "" {
+				req.Header.Set("Authorization", "Bearer "+test.token)
+			}
+
+			// Make Request
+			server.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, strings.TrimRight(w.Body.String(), "\n"))
+		})
+	}
+}
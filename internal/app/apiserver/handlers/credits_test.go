@@ -0,0 +1,107 @@
+package handlers
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"filmoteka/internal/app/models"
+	"filmoteka/internal/app/store"
+	"filmoteka/internal/app/store/mock_store"
+)
+
+func TestHandler_Credits(t *testing.T) {
+	type mockBehavior func(r *mock_store.MockICreditRepository)
+
+	director := models.Credit{FilmID: 1, Film: "Film 1", ReleaseYear: 2001, PersonID: 2, Person: "Person 2", Job: models.JobDirector}
+
+	tests := []struct {
+		name                 string
+		method               string
+		url                  string
+		inputBody            string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:   "Film Crew",
+			method: "GET",
+			url:    "/films/1/crew",
+			mockBehavior: func(r *mock_store.MockICreditRepository) {
+				r.EXPECT().ByFilm(1).Return([]models.Credit{director}, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `[{"film_id":1,"film":"Film 1","release_year":2001,"person_id":2,"person":"Person 2","job":"director"}]`,
+		},
+		{
+			name:   "Film Crew Not Found",
+			method: "GET",
+			url:    "/films/3/crew",
+			mockBehavior: func(r *mock_store.MockICreditRepository) {
+				r.EXPECT().ByFilm(3).Return(nil, store.ErrResourceNotFound)
+			},
+			expectedStatusCode:   404,
+			expectedResponseBody: `{"error":"resource not found"}`,
+		},
+		{
+			name:      "Add",
+			method:    "POST",
+			url:       "/films/1/crew",
+			inputBody: `{"person_id":2,"job":"director"}`,
+			mockBehavior: func(r *mock_store.MockICreditRepository) {
+				r.EXPECT().Add(models.Credit{FilmID: 1, PersonID: 2, Job: "director"}).Return(nil)
+			},
+			expectedStatusCode:   201,
+			expectedResponseBody: `{"result":true}`,
+		},
+		{
+			name:   "Remove",
+			method: "DELETE",
+			url:    "/films/1/crew/2/director",
+			mockBehavior: func(r *mock_store.MockICreditRepository) {
+				r.EXPECT().Remove(models.Credit{FilmID: 1, PersonID: 2, Job: "director"}).Return(nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"result":true}`,
+		},
+		{
+			name:   "Person Credits",
+			method: "GET",
+			url:    "/people/2/credits",
+			mockBehavior: func(r *mock_store.MockICreditRepository) {
+				r.EXPECT().ByPerson(2).Return([]models.Credit{director}, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `[{"film_id":1,"film":"Film 1","release_year":2001,"person_id":2,"person":"Person 2","job":"director"}]`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			creditRepo := mock_store.NewMockICreditRepository(c)
+			test.mockBehavior(creditRepo)
+			st := mock_store.New(mock_store.NewMockIFilmRepository(c), mock_store.NewMockIActorRepository(c)).WithCreditRepo(creditRepo)
+			server := NewServer(st)
+
+			// Create Request
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(test.method, test.url, bytes.NewBufferString(test.inputBody))
+
+			// Make Request
+			server.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, strings.TrimRight(w.Body.String(), "\n"))
+		})
+	}
+}
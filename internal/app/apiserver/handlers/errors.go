@@ -0,0 +1,32 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"filmoteka/internal/app/store"
+)
+
+// errorStatus maps repository errors to HTTP status codes. Unclassified
+// errors keep the historical 400.
+func errorStatus(err error) int {
+	switch {
+	case errors.Is(err, store.ErrResourceNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, store.ErrUniqueConstraints),
+		errors.Is(err, store.ErrForeignKey):
+		return http.StatusConflict
+	case errors.Is(err, store.ErrValidation),
+		errors.Is(err, store.ErrCheckConstraint),
+		errors.Is(err, store.ErrNotNull):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, errNotOwner):
+		return http.StatusForbidden
+	case errors.Is(err, store.ErrRateLimited):
+		return http.StatusTooManyRequests
+	case store.IsTransient(err):
+		return http.StatusServiceUnavailable
+	}
+
+	return http.StatusBadRequest
+}
@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"filmoteka/internal/app/exporter"
+)
+
+// exportFormat reads ?format=, falling back to the Accept header.
+func exportFormat(r *http.Request) (exporter.Format, error) {
+	if f := r.URL.Query().Get("format"); f != "" {
+		return exporter.ParseFormat(f)
+	}
+
+	return exporter.Negotiate(r.Header.Get("Accept"))
+}
+
+func (s *server) handleFilmExport() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		format, err := exportFormat(r)
+		if err != nil {
+			w.WriteHeader(http.StatusNotAcceptable)
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+
+		w.Header().Set("Content-Type", format.ContentType())
+		w.Header().Set("Content-Disposition", `attachment; filename="films.`+string(format)+`"`)
+		w.WriteHeader(http.StatusOK)
+		if err := exporter.Films(w, format, s.storeFor(r).FilmRepo().Each); err != nil {
+			// the status is already sent, the client sees a truncated body
+			s.logger.Error("film export aborted", "error", err)
+		}
+	}
+}
+
+func (s *server) handleActorExport() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		format, err := exportFormat(r)
+		if err != nil {
+			w.WriteHeader(http.StatusNotAcceptable)
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+
+		w.Header().Set("Content-Type", format.ContentType())
+		w.Header().Set("Content-Disposition", `attachment; filename="actors.`+string(format)+`"`)
+		w.WriteHeader(http.StatusOK)
+		if err := exporter.Actors(w, format, s.storeFor(r).ActorRepo().Each); err != nil {
+			// the status is already sent, the client sees a truncated body
+			s.logger.Error("actor export aborted", "error", err)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+
+	"filmoteka/internal/app/models"
+	"filmoteka/internal/app/store/mock_store"
+)
+
+func TestHandler_ActorExport(t *testing.T) {
+	actors := []models.Actor{
+		{Id: 1, Name: "Name 1", Gender: "male", BirthDate: "1995-01-12"},
+		{Id: 2, Name: "Name 2", Gender: "female", BirthDate: "1995-02-12"},
+	}
+	each := func(fn func(models.Actor) error) error {
+		for _, a := range actors {
+			if err := fn(a); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	tests := []struct {
+		name                 string
+		url                  string
+		accept               string
+		callsRepo            bool
+		expectedStatusCode   int
+		expectedContentType  string
+		expectedResponseBody string
+	}{
+		{
+			name:                 "CSV via Accept",
+			url:                  "/export/actors",
+			accept:               "text/csv",
+			callsRepo:            true,
+			expectedStatusCode:   200,
+			expectedContentType:  "text/csv; charset=utf-8",
+			expectedResponseBody: "id,name,gender,birth_date,death_date,birthplace,biography,aliases\n1,Name 1,male,1995-01-12,,,,\n2,Name 2,female,1995-02-12,,,,\n",
+		},
+		{
+			name:                "NDJSON via query",
+			url:                 "/export/actors?format=ndjson",
+			accept:              "text/csv",
+			callsRepo:           true,
+			expectedStatusCode:  200,
+			expectedContentType: "application/x-ndjson",
+			expectedResponseBody: `{"id":1,"name":"Name 1","gender":"male","birth_date":"1995-01-12"}` + "\n" +
+				`{"id":2,"name":"Name 2","gender":"female","birth_date":"1995-02-12"}` + "\n",
+		},
+		{
+			name:                 "Not Acceptable",
+			url:                  "/export/actors",
+			accept:               "image/png",
+			expectedStatusCode:   406,
+			expectedResponseBody: `{"error":"unknown export format, use csv, ndjson or json"}` + "\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			filmRepo := mock_store.NewMockIFilmRepository(c)
+			actorRepo := mock_store.NewMockIActorRepository(c)
+			if test.callsRepo {
+				actorRepo.EXPECT().Each(gomock.Any()).DoAndReturn(each)
+			}
+			store := mock_store.New(filmRepo, actorRepo)
+			server := NewServer(store)
+
+			// Init Endpoint
+			router := mux.NewRouter()
+			router.HandleFunc("/export/actors", server.handleActorExport()).Methods("GET")
+
+			// Create Request
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", test.url, nil)
+			req.Header.Set("Accept", test.accept)
+
+			// Make Request
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			if test.expectedContentType != "" {
+				assert.Equal(t, test.expectedContentType, w.Header().Get("Content-Type"))
+			}
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
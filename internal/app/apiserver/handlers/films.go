@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/gorilla/mux"
 
@@ -15,6 +16,15 @@ type RequestFilm struct {
 	Description string  `json:"description"`
 	ReleaseYear uint16  `json:"release_year"`
 	Rating      float32 `json:"rating"`
+	// The metadata below is optional. Language and country codes are
+	// accepted in any case.
+	Runtime          uint16   `json:"runtime"`
+	OriginalTitle    string   `json:"original_title"`
+	OriginalLanguage string   `json:"original_language"`
+	Countries        []string `json:"countries"`
+	AgeRating        string   `json:"age_rating"`
+	// Genres replaces the film's genres when present.
+	Genres []string `json:"genres"`
 }
 
 func (s *server) handleFilmCreate() http.HandlerFunc {
@@ -27,14 +37,20 @@ func (s *server) handleFilmCreate() http.HandlerFunc {
 		}
 
 		film := models.Film{
-			Name:        req.Name,
-			Description: req.Description,
-			ReleaseYear: req.ReleaseYear,
-			Rating:      req.Rating,
+			Name:             req.Name,
+			Description:      req.Description,
+			ReleaseYear:      req.ReleaseYear,
+			Rating:           req.Rating,
+			Runtime:          req.Runtime,
+			OriginalTitle:    req.OriginalTitle,
+			OriginalLanguage: strings.ToLower(req.OriginalLanguage),
+			Countries:        upper(req.Countries),
+			AgeRating:        req.AgeRating,
+			Genres:           req.Genres,
 		}
-		id, err := s.store.FilmRepo().Create(film)
+		id, err := s.storeFor(r).FilmRepo().Create(film)
 		if err != nil {
-			w.WriteHeader(http.StatusBadRequest)
+			w.WriteHeader(errorStatus(err))
 			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
 			return
 		}
@@ -54,13 +70,19 @@ func (s *server) handleFilmFind() http.HandlerFunc {
 			return
 		}
 
-		film, err := s.store.FilmRepo().Find(id)
+		film, err := s.storeFor(r).FilmRepo().Find(id)
 		if err != nil {
-			w.WriteHeader(http.StatusBadRequest)
+			w.WriteHeader(errorStatus(err))
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+		if err := s.localize(w, r, &film); err != nil {
+			w.WriteHeader(errorStatus(err))
 			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
 			return
 		}
 
+		setLastModified(w, film.UpdatedAt)
 		w.WriteHeader(http.StatusOK)
 		json.NewEncoder(w).Encode(film)
 	})
@@ -69,16 +91,54 @@ func (s *server) handleFilmFind() http.HandlerFunc {
 
 func (s *server) handleAllFilms() http.HandlerFunc {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		filter, filtered, err := filmFilter(r)
+		if err != nil {
+			w.WriteHeader(filterStatus(err))
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
 
-		film, err := s.store.FilmRepo().FindAll()
+		var film []models.Film
+		if filtered {
+			film, err = s.storeFor(r).FilmRepo().FindBy(filter)
+		} else {
+			film, err = s.storeFor(r).FilmRepo().FindAll()
+		}
 		if err != nil {
-			w.WriteHeader(http.StatusBadRequest)
+			w.WriteHeader(errorStatus(err))
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+		localized := make([]*models.Film, len(film))
+		for i := range film {
+			localized[i] = &film[i]
+		}
+		if err := s.localize(w, r, localized...); err != nil {
+			w.WriteHeader(errorStatus(err))
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+
+		// no Last-Modified: rows leaving the list do not move it, the
+		// ETag covers the whole body
+		if r.URL.Query().Get("facets") != "genre" {
+			w.WriteHeader(http.StatusOK)
+			json.NewEncoder(w).Encode(film)
+			return
+		}
+
+		facets, err := s.storeFor(r).FilmRepo().GenreFacets(filter)
+		if err != nil {
+			w.WriteHeader(errorStatus(err))
 			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
 			return
 		}
 
 		w.WriteHeader(http.StatusOK)
-		json.NewEncoder(w).Encode(film)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"films":  film,
+			"facets": map[string][]models.GenreCount{"genre": facets},
+		})
 	})
 }
 
@@ -91,14 +151,35 @@ func (s *server) handleFilmDelete() http.HandlerFunc {
 			return
 		}
 
-		err = s.store.FilmRepo().Delete(id)
+		err = s.storeFor(r).FilmRepo().Delete(id)
 		// fmt.Println("Controller deleted:", deleted)
+		if err != nil {
+			w.WriteHeader(errorStatus(err))
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(map[string]bool{"result": true})
+	})
+}
+
+// handleFilmRestore brings back a soft deleted film.
+func (s *server) handleFilmRestore() http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(mux.Vars(r)["id"])
 		if err != nil {
 			w.WriteHeader(http.StatusBadRequest)
 			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
 			return
 		}
 
+		if err := s.storeFor(r).FilmRepo().Restore(id); err != nil {
+			w.WriteHeader(errorStatus(err))
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+
 		w.WriteHeader(http.StatusOK)
 		json.NewEncoder(w).Encode(map[string]bool{"result": true})
 	})
@@ -121,16 +202,22 @@ func (s *server) handleFilmUpdate() http.HandlerFunc {
 		}
 
 		film := models.Film{
-			Id:          id,
-			Name:        req.Name,
-			Description: req.Description,
-			ReleaseYear: req.ReleaseYear,
-			Rating:      req.Rating,
+			Id:               id,
+			Name:             req.Name,
+			Description:      req.Description,
+			ReleaseYear:      req.ReleaseYear,
+			Rating:           req.Rating,
+			Runtime:          req.Runtime,
+			OriginalTitle:    req.OriginalTitle,
+			OriginalLanguage: strings.ToLower(req.OriginalLanguage),
+			Countries:        upper(req.Countries),
+			AgeRating:        req.AgeRating,
+			Genres:           req.Genres,
 		}
 
-		err = s.store.FilmRepo().Update(film)
+		err = s.storeFor(r).FilmRepo().Update(film)
 		if err != nil {
-			w.WriteHeader(http.StatusBadRequest)
+			w.WriteHeader(errorStatus(err))
 			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
 			return
 		}
@@ -139,3 +226,65 @@ func (s *server) handleFilmUpdate() http.HandlerFunc {
 		json.NewEncoder(w).Encode(film)
 	})
 }
+
+// handleFilmUpsert creates or updates the film identified by its
+// (name, release_year) key given as ?name= and ?year=.
+func (s *server) handleFilmUpsert() http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name := r.URL.Query().Get("name")
+		year, err := strconv.ParseUint(r.URL.Query().Get("year"), 10, 16)
+		if err != nil || name == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": "name and year query parameters are required"})
+			return
+		}
+
+		req := &RequestFilm{}
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+
+		film := models.Film{
+			Name:             name,
+			Description:      req.Description,
+			ReleaseYear:      uint16(year),
+			Rating:           req.Rating,
+			Runtime:          req.Runtime,
+			OriginalTitle:    req.OriginalTitle,
+			OriginalLanguage: strings.ToLower(req.OriginalLanguage),
+			Countries:        upper(req.Countries),
+			AgeRating:        req.AgeRating,
+			Genres:           req.Genres,
+		}
+
+		id, created, err := s.storeFor(r).FilmRepo().Upsert(film)
+		if err != nil {
+			w.WriteHeader(errorStatus(err))
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+
+		if created {
+			w.WriteHeader(http.StatusCreated)
+		} else {
+			w.WriteHeader(http.StatusOK)
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"id": id, "created": created})
+	})
+}
+
+// upper returns codes in upper case, keeping nil as nil.
+func upper(codes []string) []string {
+	if codes == nil {
+		return nil
+	}
+
+	out := make([]string, len(codes))
+	for i, c := range codes {
+		out[i] = strings.ToUpper(c)
+	}
+
+	return out
+}
@@ -7,12 +7,15 @@ import (
 	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/gorilla/mux"
 	"github.com/stretchr/testify/assert"
 
+	"filmoteka/internal/app/auth"
 	"filmoteka/internal/app/models"
+	"filmoteka/internal/app/store"
 	"filmoteka/internal/app/store/mock_store"
 )
 
@@ -50,6 +53,25 @@ func TestHandler_FilmCreate(t *testing.T) {
 			expectedStatusCode:   201,
 			expectedResponseBody: `{"id":1}`,
 		},
+		{
+			name:      "With Metadata",
+			inputBody: `{"name":"Test Name","description":"Desc1","release_year":2002,"rating":7.5,"runtime":104,"original_language":"FR","countries":["fr","be"],"age_rating":"R"}`,
+			inputFilm: models.Film{
+				Name:             "Test Name",
+				Description:      "Desc1",
+				ReleaseYear:      2002,
+				Rating:           7.5,
+				Runtime:          104,
+				OriginalLanguage: "fr",
+				Countries:        []string{"FR", "BE"},
+				AgeRating:        models.AgeRatingR,
+			},
+			mockBehavior: func(r *mock_store.MockIFilmRepository, film models.Film) {
+				r.EXPECT().Create(film).Return(1, nil)
+			},
+			expectedStatusCode:   201,
+			expectedResponseBody: `{"id":1}`,
+		},
 		{
 			name:                 "Wrong Input",
 			inputBody:            "",
@@ -397,3 +419,313 @@ func TestHandler_FilmUpdate(t *testing.T) {
 		})
 	}
 }
+
+func TestHandler_FilmUpsert(t *testing.T) {
+	// Init Test Table
+	type mockBehavior func(r *mock_store.MockIFilmRepository, film models.Film)
+
+	testFilm := models.Film{
+		Name:        "Test Name",
+		Description: "Desc1",
+		ReleaseYear: 2002,
+		Rating:      7.5,
+	}
+
+	tests := []struct {
+		name                 string
+		url                  string
+		inputFilm            models.Film
+		inputBody            string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "Created",
+			url:       "/films/by-key?name=Test+Name&year=2002",
+			inputFilm: testFilm,
+			inputBody: `{"description":"Desc1","rating":7.5}`,
+			mockBehavior: func(r *mock_store.MockIFilmRepository, film models.Film) {
+				r.EXPECT().Upsert(film).Return(1, true, nil)
+			},
+			expectedStatusCode:   201,
+			expectedResponseBody: `{"created":true,"id":1}`,
+		},
+		{
+			name:      "Updated",
+			url:       "/films/by-key?name=Test+Name&year=2002",
+			inputFilm: testFilm,
+			inputBody: `{"description":"Desc1","rating":7.5}`,
+			mockBehavior: func(r *mock_store.MockIFilmRepository, film models.Film) {
+				r.EXPECT().Upsert(film).Return(1, false, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"created":false,"id":1}`,
+		},
+		{
+			name:                 "Missing Key",
+			url:                  "/films/by-key?name=Test+Name",
+			inputBody:            `{"description":"Desc1","rating":7.5}`,
+			mockBehavior:         func(r *mock_store.MockIFilmRepository, film models.Film) {},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"error":"name and year query parameters are required"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			filmRepo := mock_store.NewMockIFilmRepository(c)
+			actorRepo := mock_store.NewMockIActorRepository(c)
+			test.mockBehavior(filmRepo, test.inputFilm)
+			store := mock_store.New(filmRepo, actorRepo)
+			server := NewServer(store)
+
+			// Init Endpoint
+			router := mux.NewRouter()
+			router.HandleFunc("/films/by-key", server.handleFilmUpsert()).Methods("PUT")
+
+			// Create Request
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("PUT", test.url,
+				bytes.NewBufferString(test.inputBody))
+
+			// Make Request
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, w.Code, test.expectedStatusCode)
+			assert.Equal(t, strings.TrimRight(w.Body.String(), "\n"), test.expectedResponseBody)
+		})
+	}
+}
+
+func TestHandler_FilmRestore(t *testing.T) {
+	type mockBehavior func(r *mock_store.MockIFilmRepository, id int)
+
+	key := []byte("test-key")
+	adminToken, _ := auth.Sign(key, auth.Claims{UserID: 1, Role: auth.RoleAdmin})
+	userToken, _ := auth.Sign(key, auth.Claims{UserID: 2, Role: auth.RoleUser})
+
+	tests := []struct {
+		name                 string
+		token                string
+		inputId              int
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:    "Ok",
+			token:   adminToken,
+			inputId: 1,
+			mockBehavior: func(r *mock_store.MockIFilmRepository, id int) {
+				r.EXPECT().Restore(id).Return(nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"result":true}`,
+		},
+		{
+			name:    "Not Deleted",
+			token:   adminToken,
+			inputId: 2,
+			mockBehavior: func(r *mock_store.MockIFilmRepository, id int) {
+				r.EXPECT().Restore(id).Return(store.ErrResourceNotFound)
+			},
+			expectedStatusCode:   404,
+			expectedResponseBody: `{"error":"resource not found"}`,
+		},
+		{
+			name:                 "Not Admin",
+			token:                userToken,
+			inputId:              1,
+			mockBehavior:         func(r *mock_store.MockIFilmRepository, id int) {},
+			expectedStatusCode:   403,
+			expectedResponseBody: `{"error":"admin role required"}`,
+		},
+		{
+			name:                 "Anonymous",
+			inputId:              1,
+			mockBehavior:         func(r *mock_store.MockIFilmRepository, id int) {},
+			expectedStatusCode:   401,
+			expectedResponseBody: `{"error":"authentication required"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			filmRepo := mock_store.NewMockIFilmRepository(c)
+			actorRepo := mock_store.NewMockIActorRepository(c)
+			test.mockBehavior(filmRepo, test.inputId)
+			server := NewServer(mock_store.New(filmRepo, actorRepo), WithAuthKey(key))
+
+			// Create Request
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/films/"+strconv.Itoa(test.inputId)+"/restore", nil)
+			if test.token != "" {
+				req.Header.Set("Authorization", "Bearer "+test.token)
+			}
+
+			// Make Request
+			server.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, strings.TrimRight(w.Body.String(), "\n"))
+		})
+	}
+}
+
+func TestHandler_FilmFindAllIncludeDeleted(t *testing.T) {
+	key := []byte("test-key")
+	adminToken, _ := auth.Sign(key, auth.Claims{UserID: 1, Role: auth.RoleAdmin})
+	userToken, _ := auth.Sign(key, auth.Claims{UserID: 2, Role: auth.RoleUser})
+
+	deletedAt := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	films := []models.Film{
+		{Id: 1, Name: "Film 1", ReleaseYear: 2001, Rating: 5, DeletedAt: &deletedAt},
+	}
+
+	tests := []struct {
+		name                 string
+		token                string
+		url                  string
+		mockBehavior         func(r *mock_store.MockIFilmRepository)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:  "Admin",
+			token: adminToken,
+			url:   "/films?include_deleted=true",
+			mockBehavior: func(r *mock_store.MockIFilmRepository) {
+				r.EXPECT().FindBy(store.FilmFilter{IncludeDeleted: true}).Return(films, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `[{"id":1,"name":"Film 1","description":"","release_year":2001,"rating":5,"deleted_at":"2024-06-01T12:00:00Z"}]`,
+		},
+		{
+			name:                 "Not Admin",
+			token:                userToken,
+			url:                  "/films?include_deleted=true",
+			mockBehavior:         func(r *mock_store.MockIFilmRepository) {},
+			expectedStatusCode:   403,
+			expectedResponseBody: `{"error":"admin role required"}`,
+		},
+		{
+			name:                 "Bad Value",
+			token:                adminToken,
+			url:                  "/films?include_deleted=maybe",
+			mockBehavior:         func(r *mock_store.MockIFilmRepository) {},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"error":"strconv.ParseBool: parsing \"maybe\": invalid syntax"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			filmRepo := mock_store.NewMockIFilmRepository(c)
+			actorRepo := mock_store.NewMockIActorRepository(c)
+			test.mockBehavior(filmRepo)
+			server := NewServer(mock_store.New(filmRepo, actorRepo), WithAuthKey(key))
+
+			// Create Request
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", test.url, nil)
+			req.Header.Set("Authorization", "Bearer "+test.token)
+
+			// Make Request
+			server.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, strings.TrimRight(w.Body.String(), "\n"))
+		})
+	}
+}
+
+func TestHandler_FilmFindAllFiltered(t *testing.T) {
+	films := []models.Film{
+		{Id: 1, Name: "Film 1", ReleaseYear: 2001, Rating: 5, Genres: []string{"Comedy", "Drama"}},
+	}
+
+	tests := []struct {
+		name                 string
+		url                  string
+		mockBehavior         func(r *mock_store.MockIFilmRepository)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name: "Filtered",
+			url:  "/films?genre=drama",
+			mockBehavior: func(r *mock_store.MockIFilmRepository) {
+				r.EXPECT().FindBy(store.FilmFilter{Genre: "drama"}).Return(films, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `[{"id":1,"name":"Film 1","description":"","release_year":2001,"rating":5,"genres":["Comedy","Drama"]}]`,
+		},
+		{
+			name: "By Metadata",
+			url:  "/films?language=FR&country=fr&age_rating=R&runtime_min=90&runtime_max=120",
+			mockBehavior: func(r *mock_store.MockIFilmRepository) {
+				r.EXPECT().FindBy(store.FilmFilter{Language: "fr", Country: "FR", AgeRating: "R", MinRuntime: 90, MaxRuntime: 120}).Return(films, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `[{"id":1,"name":"Film 1","description":"","release_year":2001,"rating":5,"genres":["Comedy","Drama"]}]`,
+		},
+		{
+			name:                 "Bad Runtime",
+			url:                  "/films?runtime_min=long",
+			mockBehavior:         func(r *mock_store.MockIFilmRepository) {},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"error":"strconv.ParseUint: parsing \"long\": invalid syntax"}`,
+		},
+		{
+			name: "With Facets",
+			url:  "/films?genre=drama&facets=genre",
+			mockBehavior: func(r *mock_store.MockIFilmRepository) {
+				filter := store.FilmFilter{Genre: "drama"}
+				r.EXPECT().FindBy(filter).Return(films, nil)
+				r.EXPECT().GenreFacets(filter).Return([]models.GenreCount{{Genre: "Comedy", Count: 1}, {Genre: "Drama", Count: 1}}, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"facets":{"genre":[{"genre":"Comedy","count":1},{"genre":"Drama","count":1}]},"films":[{"id":1,"name":"Film 1","description":"","release_year":2001,"rating":5,"genres":["Comedy","Drama"]}]}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			filmRepo := mock_store.NewMockIFilmRepository(c)
+			actorRepo := mock_store.NewMockIActorRepository(c)
+			test.mockBehavior(filmRepo)
+			server := NewServer(mock_store.New(filmRepo, actorRepo))
+
+			// Create Request
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", test.url, nil)
+
+			// Make Request
+			server.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, strings.TrimRight(w.Body.String(), "\n"))
+		})
+	}
+}
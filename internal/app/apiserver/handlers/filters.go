@@ -0,0 +1,139 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"filmoteka/internal/app/models"
+	"filmoteka/internal/app/store"
+)
+
+var errAdminOnly = errors.New("admin role required")
+
+// includeDeleted parses ?include_deleted=, which only admins may set.
+func includeDeleted(r *http.Request) (bool, error) {
+	v := r.URL.Query().Get("include_deleted")
+	if v == "" {
+		return false, nil
+	}
+
+	include, err := strconv.ParseBool(v)
+	if err != nil {
+		return false, err
+	}
+	if include && !isAdmin(r) {
+		return false, errAdminOnly
+	}
+
+	return include, nil
+}
+
+// filmFilter builds the list filter from the query string. ok is false
+// when the request asks for the plain list.
+func filmFilter(r *http.Request) (f store.FilmFilter, ok bool, err error) {
+	if f.IncludeDeleted, err = includeDeleted(r); err != nil {
+		return f, false, err
+	}
+	q := r.URL.Query()
+	f.Genre = q.Get("genre")
+	f.Language = strings.ToLower(q.Get("language"))
+	f.Country = strings.ToUpper(q.Get("country"))
+	f.AgeRating = q.Get("age_rating")
+	f.WonCeremony = q.Get("won")
+	f.WonCategory = q.Get("won_category")
+	if f.MinRuntime, err = parseRuntime(q.Get("runtime_min")); err != nil {
+		return f, false, err
+	}
+	if f.MaxRuntime, err = parseRuntime(q.Get("runtime_max")); err != nil {
+		return f, false, err
+	}
+
+	return f, f != store.FilmFilter{}, nil
+}
+
+// parseRuntime parses a runtime bound in minutes, empty meaning none.
+func parseRuntime(v string) (uint16, error) {
+	if v == "" {
+		return 0, nil
+	}
+
+	minutes, err := strconv.ParseUint(v, 10, 16)
+	if err != nil {
+		return 0, err
+	}
+
+	return uint16(minutes), nil
+}
+
+// actorFilter builds the list filter from the query string. ok is false
+// when the request asks for the plain list.
+func actorFilter(r *http.Request) (f store.ActorFilter, ok bool, err error) {
+	if f.IncludeDeleted, err = includeDeleted(r); err != nil {
+		return f, false, err
+	}
+	f.Name = r.URL.Query().Get("name")
+
+	return f, f != store.ActorFilter{}, nil
+}
+
+// Page sizes of paginated listings.
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+// page parses ?limit= and ?offset=, capping the limit at maxPageSize.
+func page(r *http.Request) (limit, offset int, err error) {
+	q := r.URL.Query()
+	limit = defaultPageSize
+	if v := q.Get("limit"); v != "" {
+		if limit, err = strconv.Atoi(v); err != nil {
+			return 0, 0, err
+		}
+		if limit <= 0 {
+			return 0, 0, errors.New("limit must be positive")
+		}
+		limit = min(limit, maxPageSize)
+	}
+	if v := q.Get("offset"); v != "" {
+		if offset, err = strconv.Atoi(v); err != nil {
+			return 0, 0, err
+		}
+		if offset < 0 {
+			return 0, 0, errors.New("offset must not be negative")
+		}
+	}
+
+	return limit, offset, nil
+}
+
+// reviewFilter builds the review list filter from the query string.
+// Listings default to status def; only admins may list another status.
+func reviewFilter(r *http.Request, def string) (f store.ReviewFilter, err error) {
+	if f.Limit, f.Offset, err = page(r); err != nil {
+		return f, err
+	}
+	f.Status = r.URL.Query().Get("status")
+	switch {
+	case f.Status == "":
+		f.Status = def
+	case !models.ValidReviewStatus(f.Status):
+		return f, fmt.Errorf("unknown review status %q", f.Status)
+	case f.Status != def && !isAdmin(r):
+		return f, errAdminOnly
+	}
+
+	return f, nil
+}
+
+// filterStatus maps a filter parsing error to a response status.
+func filterStatus(err error) int {
+	if errors.Is(err, errAdminOnly) {
+		return http.StatusForbidden
+	}
+
+	return http.StatusBadRequest
+}
@@ -0,0 +1,107 @@
+package handlers
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"filmoteka/internal/app/models"
+	"filmoteka/internal/app/store"
+	"filmoteka/internal/app/store/mock_store"
+)
+
+func TestHandler_Genres(t *testing.T) {
+	type mockBehavior func(r *mock_store.MockIGenreRepository)
+
+	tests := []struct {
+		name                 string
+		method               string
+		url                  string
+		inputBody            string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "Create",
+			method:    "POST",
+			url:       "/genres",
+			inputBody: `{"name":"Drama"}`,
+			mockBehavior: func(r *mock_store.MockIGenreRepository) {
+				r.EXPECT().Create(models.Genre{Name: "Drama"}).Return(1, nil)
+			},
+			expectedStatusCode:   201,
+			expectedResponseBody: `{"id":1}`,
+		},
+		{
+			name:      "Create Duplicate",
+			method:    "POST",
+			url:       "/genres",
+			inputBody: `{"name":"drama"}`,
+			mockBehavior: func(r *mock_store.MockIGenreRepository) {
+				r.EXPECT().Create(models.Genre{Name: "drama"}).Return(0, store.ErrUniqueConstraints)
+			},
+			expectedStatusCode:   409,
+			expectedResponseBody: `{"error":"unique constraints violation"}`,
+		},
+		{
+			name:   "List",
+			method: "GET",
+			url:    "/genres",
+			mockBehavior: func(r *mock_store.MockIGenreRepository) {
+				r.EXPECT().FindAll().Return([]models.Genre{{Id: 2, Name: "Comedy"}, {Id: 1, Name: "Drama"}}, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `[{"id":2,"name":"Comedy"},{"id":1,"name":"Drama"}]`,
+		},
+		{
+			name:      "Update",
+			method:    "PUT",
+			url:       "/genres/1",
+			inputBody: `{"name":"Melodrama"}`,
+			mockBehavior: func(r *mock_store.MockIGenreRepository) {
+				r.EXPECT().Update(models.Genre{Id: 1, Name: "Melodrama"}).Return(nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"id":1,"name":"Melodrama"}`,
+		},
+		{
+			name:   "Delete Not Found",
+			method: "DELETE",
+			url:    "/genres/3",
+			mockBehavior: func(r *mock_store.MockIGenreRepository) {
+				r.EXPECT().Delete(3).Return(store.ErrResourceNotFound)
+			},
+			expectedStatusCode:   404,
+			expectedResponseBody: `{"error":"resource not found"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			genreRepo := mock_store.NewMockIGenreRepository(c)
+			test.mockBehavior(genreRepo)
+			st := mock_store.New(mock_store.NewMockIFilmRepository(c), mock_store.NewMockIActorRepository(c)).WithGenreRepo(genreRepo)
+			server := NewServer(st)
+
+			// Create Request
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(test.method, test.url, bytes.NewBufferString(test.inputBody))
+
+			// Make Request
+			server.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, strings.TrimRight(w.Body.String(), "\n"))
+		})
+	}
+}
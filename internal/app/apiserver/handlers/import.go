@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"filmoteka/internal/app/importer"
+)
+
+var errUnknownMode = errors.New("unknown import mode, use atomic or best-effort")
+
+// WithImportLimits refuses import bodies larger than maxBytes or holding
+// more than maxRows rows; 0 leaves either unlimited.
+func WithImportLimits(maxBytes int64, maxRows int) Option {
+	return func(s *server) {
+		s.importLimit = maxBytes
+		s.importRows = maxRows
+	}
+}
+
+// importOptions reads ?format= (falling back to Content-Type),
+// ?mode=atomic|best-effort and ?batch_size= from the request.
+func (s *server) importOptions(r *http.Request) (importer.Options, error) {
+	opts := importer.Options{Atomic: true, MaxRows: s.importRows}
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = r.Header.Get("Content-Type")
+	}
+	f, err := importer.ParseFormat(format)
+	if err != nil {
+		return opts, err
+	}
+	opts.Format = f
+
+	switch mode := r.URL.Query().Get("mode"); mode {
+	case "", "atomic":
+	case "best-effort":
+		opts.Atomic = false
+	default:
+		return opts, errUnknownMode
+	}
+
+	if v := r.URL.Query().Get("batch_size"); v != "" {
+		size, err := strconv.Atoi(v)
+		if err != nil {
+			return opts, err
+		}
+		opts.BatchSize = size
+	}
+
+	return opts, nil
+}
+
+// importStatus maps an import error to a response status.
+func importStatus(err error) int {
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) || errors.Is(err, importer.ErrTooManyRows) {
+		return http.StatusRequestEntityTooLarge
+	}
+
+	return http.StatusBadRequest
+}
+
+func writeImportReport(w http.ResponseWriter, report *importer.Report) {
+	if report.RolledBack {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+	} else {
+		w.WriteHeader(http.StatusOK)
+	}
+	json.NewEncoder(w).Encode(report)
+}
+
+func (s *server) handleFilmImport() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		opts, err := s.importOptions(r)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+
+		if s.importLimit > 0 {
+			r.Body = http.MaxBytesReader(w, r.Body, s.importLimit)
+		}
+		report, err := importer.Films(s.storeFor(r).FilmRepo(), r.Body, opts)
+		if err != nil {
+			w.WriteHeader(importStatus(err))
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+
+		writeImportReport(w, report)
+	}
+}
+
+func (s *server) handleActorImport() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		opts, err := s.importOptions(r)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+
+		if s.importLimit > 0 {
+			r.Body = http.MaxBytesReader(w, r.Body, s.importLimit)
+		}
+		report, err := importer.Actors(s.storeFor(r).ActorRepo(), r.Body, opts)
+		if err != nil {
+			w.WriteHeader(importStatus(err))
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+
+		writeImportReport(w, report)
+	}
+}
@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"filmoteka/internal/app/auth"
+	"filmoteka/internal/app/models"
+	"filmoteka/internal/app/store/mock_store"
+)
+
+func TestHandler_FilmImport(t *testing.T) {
+	key := []byte("test-key")
+	adminToken, _ := auth.Sign(key, auth.Claims{UserID: 1, Role: auth.RoleAdmin})
+	userToken, _ := auth.Sign(key, auth.Claims{UserID: 2, Role: auth.RoleUser})
+
+	header := "name,description,release_year,rating\n"
+
+	tests := []struct {
+		name                 string
+		token                string
+		body                 string
+		mockBehavior         func(r *mock_store.MockIFilmRepository)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:  "Ok",
+			token: adminToken,
+			body:  header + "Film One,Description 1,2001,7.5\n",
+			mockBehavior: func(r *mock_store.MockIFilmRepository) {
+				r.EXPECT().CreateBatch([]models.Film{
+					{Name: "Film One", Description: "Description 1", ReleaseYear: 2001, Rating: 7.5},
+				}).Return([]int{10}, nil)
+			},
+			expectedStatusCode: 200,
+		},
+		{
+			name:                 "Anonymous",
+			body:                 header,
+			mockBehavior:         func(r *mock_store.MockIFilmRepository) {},
+			expectedStatusCode:   401,
+			expectedResponseBody: `{"error":"authentication required"}`,
+		},
+		{
+			name:                 "Not Admin",
+			token:                userToken,
+			body:                 header,
+			mockBehavior:         func(r *mock_store.MockIFilmRepository) {},
+			expectedStatusCode:   403,
+			expectedResponseBody: `{"error":"admin role required"}`,
+		},
+		{
+			name:                 "Too Many Rows",
+			token:                adminToken,
+			body:                 header + "A,a,2001,1\nB,b,2002,2\nC,c,2003,3\n",
+			mockBehavior:         func(r *mock_store.MockIFilmRepository) {},
+			expectedStatusCode:   413,
+			expectedResponseBody: `{"error":"too many rows"}`,
+		},
+		{
+			name:                 "Body Too Large",
+			token:                adminToken,
+			body:                 header + strings.Repeat("x", 256) + ",a,2001,1\n",
+			mockBehavior:         func(r *mock_store.MockIFilmRepository) {},
+			expectedStatusCode:   413,
+			expectedResponseBody: `{"error":"http: request body too large"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			filmRepo := mock_store.NewMockIFilmRepository(c)
+			test.mockBehavior(filmRepo)
+			store := mock_store.New(filmRepo, mock_store.NewMockIActorRepository(c))
+			server := NewServer(store, WithAuthKey(key), WithImportLimits(256, 2))
+
+			// Create Request
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/import/films", strings.NewReader(test.body))
+			req.Header.Set("Content-Type", "text/csv")
+			if test.token != "" {
+				req.Header.Set("Authorization", "Bearer "+test.token)
+			}
+
+			// Make Request
+			server.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			if test.expectedResponseBody != "" {
+				assert.Equal(t, test.expectedResponseBody, strings.TrimRight(w.Body.String(), "\n"))
+			}
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"filmoteka/internal/app/models"
+)
+
+type RequestWatch struct {
+	FilmID int `json:"film_id"`
+	// WatchedOn defaults to today.
+	WatchedOn string `json:"watched_on"`
+}
+
+// handleWatchlist lists the caller's watchlist, filtered like GET /films.
+func (s *server) handleWatchlist() http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		filter, _, err := filmFilter(r)
+		if err != nil {
+			w.WriteHeader(filterStatus(err))
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+
+		claims, _ := claimsFrom(r)
+		items, err := s.storeFor(r).WatchlistRepo().Films(claims.UserID, filter)
+		if err != nil {
+			w.WriteHeader(errorStatus(err))
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+		localized := make([]*models.Film, len(items))
+		for i := range items {
+			localized[i] = &items[i].Film
+		}
+		if err := s.localize(w, r, localized...); err != nil {
+			w.WriteHeader(errorStatus(err))
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(items)
+	})
+}
+
+func (s *server) handleWatchlistAdd() http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		filmID, err := strconv.Atoi(mux.Vars(r)["film_id"])
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+
+		claims, _ := claimsFrom(r)
+		if err := s.storeFor(r).WatchlistRepo().Add(claims.UserID, filmID); err != nil {
+			w.WriteHeader(errorStatus(err))
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(map[string]bool{"result": true})
+	})
+}
+
+func (s *server) handleWatchlistRemove() http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		filmID, err := strconv.Atoi(mux.Vars(r)["film_id"])
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+
+		claims, _ := claimsFrom(r)
+		if err := s.storeFor(r).WatchlistRepo().Remove(claims.UserID, filmID); err != nil {
+			w.WriteHeader(errorStatus(err))
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(map[string]bool{"result": true})
+	})
+}
+
+func (s *server) handleWatched() http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		claims, _ := claimsFrom(r)
+		watches, err := s.storeFor(r).WatchLogRepo().ByUser(claims.UserID)
+		if err != nil {
+			w.WriteHeader(errorStatus(err))
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(watches)
+	})
+}
+
+func (s *server) handleWatchedAdd() http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := &RequestWatch{}
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+		if req.WatchedOn == "" {
+			req.WatchedOn = time.Now().Format("2006-01-02")
+		}
+
+		claims, _ := claimsFrom(r)
+		id, err := s.storeFor(r).WatchLogRepo().Add(models.Watch{
+			UserID:    claims.UserID,
+			FilmID:    req.FilmID,
+			WatchedOn: req.WatchedOn,
+		})
+		if err != nil {
+			w.WriteHeader(errorStatus(err))
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(map[string]int{"id": id})
+	})
+}
+
+func (s *server) handleWatchedRemove() http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(mux.Vars(r)["id"])
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+
+		claims, _ := claimsFrom(r)
+		if err := s.storeFor(r).WatchLogRepo().Remove(claims.UserID, id); err != nil {
+			w.WriteHeader(errorStatus(err))
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(map[string]bool{"result": true})
+	})
+}
@@ -0,0 +1,135 @@
+package handlers
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"filmoteka/internal/app/auth"
+	"filmoteka/internal/app/models"
+	"filmoteka/internal/app/store"
+	"filmoteka/internal/app/store/mock_store"
+)
+
+func TestHandler_Me(t *testing.T) {
+	key := []byte("test-key")
+	userToken, _ := auth.Sign(key, auth.Claims{UserID: 2, Role: auth.RoleUser})
+	addedAt := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	type mockBehavior func(l *mock_store.MockIWatchlistRepository, h *mock_store.MockIWatchLogRepository)
+
+	tests := []struct {
+		name                 string
+		method               string
+		url                  string
+		token                string
+		inputBody            string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:   "Watchlist Filtered",
+			method: "GET",
+			url:    "/me/watchlist?genre=drama&language=FR",
+			token:  userToken,
+			mockBehavior: func(l *mock_store.MockIWatchlistRepository, h *mock_store.MockIWatchLogRepository) {
+				l.EXPECT().Films(2, store.FilmFilter{Genre: "drama", Language: "fr"}).Return([]models.WatchlistItem{
+					{Film: models.Film{Id: 1, Name: "Film 1", Description: "Descr 1", ReleaseYear: 2001, Rating: 5}, AddedAt: addedAt},
+				}, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `[{"id":1,"name":"Film 1","description":"Descr 1","release_year":2001,"rating":5,"added_at":"2024-06-01T12:00:00Z"}]`,
+		},
+		{
+			name:                 "Watchlist Anonymous",
+			method:               "GET",
+			url:                  "/me/watchlist",
+			mockBehavior:         func(l *mock_store.MockIWatchlistRepository, h *mock_store.MockIWatchLogRepository) {},
+			expectedStatusCode:   401,
+			expectedResponseBody: `{"error":"authentication required"}`,
+		},
+		{
+			name:   "Watchlist Add Missing Film",
+			method: "PUT",
+			url:    "/me/watchlist/9",
+			token:  userToken,
+			mockBehavior: func(l *mock_store.MockIWatchlistRepository, h *mock_store.MockIWatchLogRepository) {
+				l.EXPECT().Add(2, 9).Return(store.ErrResourceNotFound)
+			},
+			expectedStatusCode:   404,
+			expectedResponseBody: `{"error":"resource not found"}`,
+		},
+		{
+			name:   "Watchlist Remove",
+			method: "DELETE",
+			url:    "/me/watchlist/1",
+			token:  userToken,
+			mockBehavior: func(l *mock_store.MockIWatchlistRepository, h *mock_store.MockIWatchLogRepository) {
+				l.EXPECT().Remove(2, 1).Return(nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"result":true}`,
+		},
+		{
+			name:      "Log Watch",
+			method:    "POST",
+			url:       "/me/watched",
+			token:     userToken,
+			inputBody: `{"film_id":1,"watched_on":"2024-05-01"}`,
+			mockBehavior: func(l *mock_store.MockIWatchlistRepository, h *mock_store.MockIWatchLogRepository) {
+				h.EXPECT().Add(models.Watch{UserID: 2, FilmID: 1, WatchedOn: "2024-05-01"}).Return(3, nil)
+			},
+			expectedStatusCode:   201,
+			expectedResponseBody: `{"id":3}`,
+		},
+		{
+			name:   "Watched",
+			method: "GET",
+			url:    "/me/watched",
+			token:  userToken,
+			mockBehavior: func(l *mock_store.MockIWatchlistRepository, h *mock_store.MockIWatchLogRepository) {
+				h.EXPECT().ByUser(2).Return([]models.Watch{
+					{Id: 3, UserID: 2, FilmID: 1, Film: "Film 1", ReleaseYear: 2001, WatchedOn: "2024-05-01", CreatedAt: addedAt},
+				}, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `[{"id":3,"film_id":1,"film":"Film 1","release_year":2001,"watched_on":"2024-05-01","created_at":"2024-06-01T12:00:00Z"}]`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			watchlistRepo := mock_store.NewMockIWatchlistRepository(c)
+			watchLogRepo := mock_store.NewMockIWatchLogRepository(c)
+			test.mockBehavior(watchlistRepo, watchLogRepo)
+			st := mock_store.New(mock_store.NewMockIFilmRepository(c), mock_store.NewMockIActorRepository(c)).
+				WithWatchlistRepo(watchlistRepo).
+				WithWatchLogRepo(watchLogRepo)
+			server := NewServer(st, WithAuthKey(key))
+
+			// Create Request
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(test.method, test.url, bytes.NewBufferString(test.inputBody))
+			if test.token != "" {
+				req.Header.Set("Authorization", "Bearer "+test.token)
+			}
+
+			// Make Request
+			server.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, strings.TrimRight(w.Body.String(), "\n"))
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"filmoteka/internal/app/auth"
+	"filmoteka/internal/app/models"
+	"filmoteka/internal/app/store"
+	"filmoteka/internal/app/store/mock_store"
+)
+
+func TestHandler_MyRating(t *testing.T) {
+	key := []byte("test-key")
+	userToken, _ := auth.Sign(key, auth.Claims{UserID: 2, Role: auth.RoleUser})
+	updatedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	type mockBehavior func(r *mock_store.MockIRatingRepository)
+
+	tests := []struct {
+		name                 string
+		method               string
+		token                string
+		inputBody            string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "Rate",
+			method:    "PUT",
+			token:     userToken,
+			inputBody: `{"rating":9}`,
+			mockBehavior: func(r *mock_store.MockIRatingRepository) {
+				r.EXPECT().Rate(models.UserRating{FilmID: 1, UserID: 2, Rating: 9}).
+					Return(models.RatingSummary{Count: 2, Average: 8.5, Score: 8.5}, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"count":2,"average":8.5,"score":8.5}`,
+		},
+		{
+			name:      "Rate Out Of Range",
+			method:    "PUT",
+			token:     userToken,
+			inputBody: `{"rating":0}`,
+			mockBehavior: func(r *mock_store.MockIRatingRepository) {
+				r.EXPECT().Rate(models.UserRating{FilmID: 1, UserID: 2}).
+					Return(models.RatingSummary{}, store.ErrValidation)
+			},
+			expectedStatusCode:   422,
+			expectedResponseBody: `{"error":"` + store.ErrValidation.Error() + `"}`,
+		},
+		{
+			name:                 "Rate Anonymous",
+			method:               "PUT",
+			inputBody:            `{"rating":9}`,
+			mockBehavior:         func(r *mock_store.MockIRatingRepository) {},
+			expectedStatusCode:   401,
+			expectedResponseBody: `{"error":"authentication required"}`,
+		},
+		{
+			name:   "Find",
+			method: "GET",
+			token:  userToken,
+			mockBehavior: func(r *mock_store.MockIRatingRepository) {
+				r.EXPECT().Find(1, 2).Return(models.UserRating{FilmID: 1, UserID: 2, Rating: 9, UpdatedAt: updatedAt}, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"film_id":1,"rating":9,"updated_at":"2024-05-01T12:00:00Z"}`,
+		},
+		{
+			name:   "Remove Not Rated",
+			method: "DELETE",
+			token:  userToken,
+			mockBehavior: func(r *mock_store.MockIRatingRepository) {
+				r.EXPECT().Remove(1, 2).Return(models.RatingSummary{}, store.ErrResourceNotFound)
+			},
+			expectedStatusCode:   404,
+			expectedResponseBody: `{"error":"resource not found"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			ratingRepo := mock_store.NewMockIRatingRepository(c)
+			test.mockBehavior(ratingRepo)
+			st := mock_store.New(mock_store.NewMockIFilmRepository(c), mock_store.NewMockIActorRepository(c)).WithRatingRepo(ratingRepo)
+			server := NewServer(st, WithAuthKey(key))
+
+			// Create Request
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(test.method, "/films/1/my-rating", bytes.NewBufferString(test.inputBody))
+			if test.token != "" {
+				req.Header.Set("Authorization", "Bearer "+test.token)
+			}
+
+			// Make Request
+			server.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, strings.TrimRight(w.Body.String(), "\n"))
+		})
+	}
+}
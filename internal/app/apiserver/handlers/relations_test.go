@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"filmoteka/internal/app/models"
+	"filmoteka/internal/app/store"
+	"filmoteka/internal/app/store/mock_store"
+)
+
+func TestHandler_Relations(t *testing.T) {
+	type mockBehavior func(r *mock_store.MockIRelationRepository)
+
+	tests := []struct {
+		name                 string
+		method               string
+		url                  string
+		inputBody            string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:   "Related",
+			method: "GET",
+			url:    "/films/2/related",
+			mockBehavior: func(r *mock_store.MockIRelationRepository) {
+				r.EXPECT().ByFilm(2).Return(models.RelatedFilms{
+					"prequel": {{Id: 1, Name: "Alien", ReleaseYear: 1979}},
+					"sequel":  {{Id: 3, Name: "Alien 3", ReleaseYear: 1992}},
+				}, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"prequel":[{"id":1,"name":"Alien","release_year":1979}],"sequel":[{"id":3,"name":"Alien 3","release_year":1992}]}`,
+		},
+		{
+			name:   "Related Not Found",
+			method: "GET",
+			url:    "/films/9/related",
+			mockBehavior: func(r *mock_store.MockIRelationRepository) {
+				r.EXPECT().ByFilm(9).Return(nil, store.ErrResourceNotFound)
+			},
+			expectedStatusCode:   404,
+			expectedResponseBody: `{"error":"resource not found"}`,
+		},
+		{
+			name:      "Add",
+			method:    "POST",
+			url:       "/films/1/related",
+			inputBody: `{"related_id":2,"type":"sequel"}`,
+			mockBehavior: func(r *mock_store.MockIRelationRepository) {
+				r.EXPECT().Add(models.FilmRelation{FilmID: 1, RelatedID: 2, Type: "sequel"}).Return(nil)
+			},
+			expectedStatusCode:   201,
+			expectedResponseBody: `{"result":true}`,
+		},
+		{
+			name:      "Add Cycle",
+			method:    "POST",
+			url:       "/films/3/related",
+			inputBody: `{"related_id":1,"type":"sequel"}`,
+			mockBehavior: func(r *mock_store.MockIRelationRepository) {
+				r.EXPECT().Add(models.FilmRelation{FilmID: 3, RelatedID: 1, Type: "sequel"}).Return(store.ErrSequelCycle)
+			},
+			expectedStatusCode:   422,
+			expectedResponseBody: `{"error":"validation error: sequels would form a cycle"}`,
+		},
+		{
+			name:   "Remove",
+			method: "DELETE",
+			url:    "/films/2/related/1/prequel",
+			mockBehavior: func(r *mock_store.MockIRelationRepository) {
+				r.EXPECT().Remove(models.FilmRelation{FilmID: 2, RelatedID: 1, Type: "prequel"}).Return(nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"result":true}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			relationRepo := mock_store.NewMockIRelationRepository(c)
+			test.mockBehavior(relationRepo)
+			st := mock_store.New(mock_store.NewMockIFilmRepository(c), mock_store.NewMockIActorRepository(c)).WithRelationRepo(relationRepo)
+			server := NewServer(st)
+
+			// Create Request
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(test.method, test.url, bytes.NewBufferString(test.inputBody))
+
+			// Make Request
+			server.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, strings.TrimRight(w.Body.String(), "\n"))
+		})
+	}
+}
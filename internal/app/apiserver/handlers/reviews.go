@@ -0,0 +1,210 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+
+	"filmoteka/internal/app/models"
+	"filmoteka/internal/app/store"
+)
+
+type RequestReview struct {
+	Title string `json:"title"`
+	Body  string `json:"body"`
+}
+
+type RequestModeration struct {
+	Status string `json:"status"`
+}
+
+// ReviewPage is one page of a review listing.
+type ReviewPage struct {
+	Reviews []models.Review `json:"reviews"`
+	Total   int             `json:"total"`
+	Limit   int             `json:"limit"`
+	Offset  int             `json:"offset"`
+}
+
+func (s *server) handleReviewCreate() http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(mux.Vars(r)["id"])
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+
+		req := &RequestReview{}
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+
+		claims, _ := claimsFrom(r)
+		review, err := s.storeFor(r).ReviewRepo().Create(models.Review{
+			FilmID: id,
+			UserID: claims.UserID,
+			Title:  req.Title,
+			Body:   req.Body,
+		})
+		if err != nil {
+			w.WriteHeader(errorStatus(err))
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(review)
+	})
+}
+
+// handleFilmReviews lists the approved reviews of a film. Admins may
+// list the reviews in another state with ?status=.
+func (s *server) handleFilmReviews() http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(mux.Vars(r)["id"])
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+
+		filter, err := reviewFilter(r, models.ReviewApproved)
+		if err != nil {
+			w.WriteHeader(filterStatus(err))
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+		filter.FilmID = id
+
+		s.writeReviews(w, r, filter)
+	})
+}
+
+// handleReviews lists reviews of all films for moderation, pending ones
+// by default.
+func (s *server) handleReviews() http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		filter, err := reviewFilter(r, models.ReviewPending)
+		if err != nil {
+			w.WriteHeader(filterStatus(err))
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+
+		s.writeReviews(w, r, filter)
+	})
+}
+
+func (s *server) writeReviews(w http.ResponseWriter, r *http.Request, filter store.ReviewFilter) {
+	reviews, total, err := s.storeFor(r).ReviewRepo().FindBy(filter)
+	if err != nil {
+		w.WriteHeader(errorStatus(err))
+		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(ReviewPage{
+		Reviews: reviews,
+		Total:   total,
+		Limit:   filter.Limit,
+		Offset:  filter.Offset,
+	})
+}
+
+// handleReviewFind shows an approved review to anyone and reviews in
+// other states to their author and admins only.
+func (s *server) handleReviewFind() http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(mux.Vars(r)["id"])
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+
+		review, err := s.storeFor(r).ReviewRepo().Find(id)
+		if err == nil && review.Status != models.ReviewApproved && !isAdmin(r) {
+			if c, ok := claimsFrom(r); !ok || c.UserID != review.UserID {
+				err = store.ErrResourceNotFound
+			}
+		}
+		if err != nil {
+			w.WriteHeader(errorStatus(err))
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(review)
+	})
+}
+
+// handleReviewUpdate lets the author edit a review, which goes back to
+// moderation. Reviews of other users count as missing.
+func (s *server) handleReviewUpdate() http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(mux.Vars(r)["id"])
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+
+		req := &RequestReview{}
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+
+		claims, _ := claimsFrom(r)
+		review, err := s.storeFor(r).ReviewRepo().Update(models.Review{
+			Id:     id,
+			UserID: claims.UserID,
+			Title:  req.Title,
+			Body:   req.Body,
+		})
+		if err != nil {
+			w.WriteHeader(errorStatus(err))
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(review)
+	})
+}
+
+func (s *server) handleReviewModerate() http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(mux.Vars(r)["id"])
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+
+		req := &RequestModeration{}
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+
+		review, err := s.storeFor(r).ReviewRepo().Moderate(id, req.Status)
+		if err != nil {
+			w.WriteHeader(errorStatus(err))
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(review)
+	})
+}
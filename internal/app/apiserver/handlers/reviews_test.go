@@ -0,0 +1,200 @@
+package handlers
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"filmoteka/internal/app/auth"
+	"filmoteka/internal/app/models"
+	"filmoteka/internal/app/store"
+	"filmoteka/internal/app/store/mock_store"
+)
+
+func TestHandler_Reviews(t *testing.T) {
+	key := []byte("test-key")
+	adminToken, _ := auth.Sign(key, auth.Claims{UserID: 1, Role: auth.RoleAdmin})
+	userToken, _ := auth.Sign(key, auth.Claims{UserID: 2, Role: auth.RoleUser})
+	createdAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	body := "A slow burn worth the wait."
+	pending := models.Review{Id: 4, FilmID: 1, UserID: 2, Body: body, Status: models.ReviewPending, CreatedAt: createdAt, UpdatedAt: createdAt}
+	approved := pending
+	approved.Status = models.ReviewApproved
+	pendingJSON := `{"id":4,"film_id":1,"user_id":2,"body":"A slow burn worth the wait.","status":"pending","created_at":"2024-05-01T12:00:00Z","updated_at":"2024-05-01T12:00:00Z"}`
+	approvedJSON := strings.Replace(pendingJSON, "pending", "approved", 1)
+
+	type mockBehavior func(r *mock_store.MockIReviewRepository)
+
+	tests := []struct {
+		name                 string
+		method               string
+		url                  string
+		token                string
+		inputBody            string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "Create",
+			method:    "POST",
+			url:       "/films/1/reviews",
+			token:     userToken,
+			inputBody: `{"body":"A slow burn worth the wait."}`,
+			mockBehavior: func(r *mock_store.MockIReviewRepository) {
+				r.EXPECT().Create(models.Review{FilmID: 1, UserID: 2, Body: body}).Return(pending, nil)
+			},
+			expectedStatusCode:   201,
+			expectedResponseBody: pendingJSON,
+		},
+		{
+			name:      "Create Rate Limited",
+			method:    "POST",
+			url:       "/films/1/reviews",
+			token:     userToken,
+			inputBody: `{"body":"A slow burn worth the wait."}`,
+			mockBehavior: func(r *mock_store.MockIReviewRepository) {
+				r.EXPECT().Create(models.Review{FilmID: 1, UserID: 2, Body: body}).Return(models.Review{}, store.ErrRateLimited)
+			},
+			expectedStatusCode:   429,
+			expectedResponseBody: `{"error":"rate limit exceeded"}`,
+		},
+		{
+			name:                 "Create Anonymous",
+			method:               "POST",
+			url:                  "/films/1/reviews",
+			inputBody:            `{"body":"A slow burn worth the wait."}`,
+			mockBehavior:         func(r *mock_store.MockIReviewRepository) {},
+			expectedStatusCode:   401,
+			expectedResponseBody: `{"error":"authentication required"}`,
+		},
+		{
+			name:   "List Film Reviews",
+			method: "GET",
+			url:    "/films/1/reviews?limit=1&offset=1",
+			mockBehavior: func(r *mock_store.MockIReviewRepository) {
+				r.EXPECT().FindBy(store.ReviewFilter{FilmID: 1, Status: models.ReviewApproved, Limit: 1, Offset: 1}).
+					Return([]models.Review{approved}, 2, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"reviews":[` + approvedJSON + `],"total":2,"limit":1,"offset":1}`,
+		},
+		{
+			name:                 "List Pending As User",
+			method:               "GET",
+			url:                  "/films/1/reviews?status=pending",
+			token:                userToken,
+			mockBehavior:         func(r *mock_store.MockIReviewRepository) {},
+			expectedStatusCode:   403,
+			expectedResponseBody: `{"error":"admin role required"}`,
+		},
+		{
+			name:                 "List Bad Limit",
+			method:               "GET",
+			url:                  "/films/1/reviews?limit=0",
+			mockBehavior:         func(r *mock_store.MockIReviewRepository) {},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"error":"limit must be positive"}`,
+		},
+		{
+			name:   "Moderation Queue",
+			method: "GET",
+			url:    "/reviews?limit=500",
+			token:  adminToken,
+			mockBehavior: func(r *mock_store.MockIReviewRepository) {
+				r.EXPECT().FindBy(store.ReviewFilter{Status: models.ReviewPending, Limit: 100}).
+					Return([]models.Review{pending}, 1, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"reviews":[` + pendingJSON + `],"total":1,"limit":100,"offset":0}`,
+		},
+		{
+			name:   "Find Pending As Author",
+			method: "GET",
+			url:    "/reviews/4",
+			token:  userToken,
+			mockBehavior: func(r *mock_store.MockIReviewRepository) {
+				r.EXPECT().Find(4).Return(pending, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: pendingJSON,
+		},
+		{
+			name:   "Find Pending Anonymous",
+			method: "GET",
+			url:    "/reviews/4",
+			mockBehavior: func(r *mock_store.MockIReviewRepository) {
+				r.EXPECT().Find(4).Return(pending, nil)
+			},
+			expectedStatusCode:   404,
+			expectedResponseBody: `{"error":"resource not found"}`,
+		},
+		{
+			name:      "Update By Author",
+			method:    "PUT",
+			url:       "/reviews/4",
+			token:     userToken,
+			inputBody: `{"title":"Slow","body":"A slow burn worth the wait."}`,
+			mockBehavior: func(r *mock_store.MockIReviewRepository) {
+				r.EXPECT().Update(models.Review{Id: 4, UserID: 2, Title: "Slow", Body: body}).Return(pending, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: pendingJSON,
+		},
+		{
+			name:      "Moderate",
+			method:    "PUT",
+			url:       "/reviews/4/status",
+			token:     adminToken,
+			inputBody: `{"status":"approved"}`,
+			mockBehavior: func(r *mock_store.MockIReviewRepository) {
+				r.EXPECT().Moderate(4, models.ReviewApproved).Return(approved, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: approvedJSON,
+		},
+		{
+			name:                 "Moderate As User",
+			method:               "PUT",
+			url:                  "/reviews/4/status",
+			token:                userToken,
+			inputBody:            `{"status":"approved"}`,
+			mockBehavior:         func(r *mock_store.MockIReviewRepository) {},
+			expectedStatusCode:   403,
+			expectedResponseBody: `{"error":"admin role required"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			reviewRepo := mock_store.NewMockIReviewRepository(c)
+			test.mockBehavior(reviewRepo)
+			st := mock_store.New(mock_store.NewMockIFilmRepository(c), mock_store.NewMockIActorRepository(c)).WithReviewRepo(reviewRepo)
+			server := NewServer(st, WithAuthKey(key))
+
+			// Create Request
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(test.method, test.url, bytes.NewBufferString(test.inputBody))
+			if test.token != "" {
+				req.Header.Set("Authorization", "Bearer "+test.token)
+			}
+
+			// Make Request
+			server.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, strings.TrimRight(w.Body.String(), "\n"))
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package handlers
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+
+	"filmoteka/internal/app/auth"
+	"filmoteka/internal/app/models"
+	"filmoteka/internal/app/store"
+	"filmoteka/internal/app/store/mock_store"
+)
+
+func TestHandler_FilmRevision(t *testing.T) {
+	type mockBehavior func(r *mock_store.MockIFilmRepository)
+
+	film := models.Film{Id: 1, Name: "Film 1", Description: "Descr 1", ReleaseYear: 2001, Rating: 5}
+
+	tests := []struct {
+		name                 string
+		url                  string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name: "Ok",
+			url:  "/films/1/revisions/2",
+			mockBehavior: func(r *mock_store.MockIFilmRepository) {
+				r.EXPECT().Revision(1, 2).Return(models.FilmRevision{
+					FilmID:    1,
+					Number:    2,
+					Film:      film,
+					CreatedAt: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+				}, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"film_id":1,"revision":2,"film":{"id":1,"name":"Film 1","description":"Descr 1","release_year":2001,"rating":5},"user_id":null,"created_at":"2024-05-01T12:00:00Z"}`,
+		},
+		{
+			name: "Not Found",
+			url:  "/films/1/revisions/9",
+			mockBehavior: func(r *mock_store.MockIFilmRepository) {
+				r.EXPECT().Revision(1, 9).Return(models.FilmRevision{}, store.ErrResourceNotFound)
+			},
+			expectedStatusCode:   404,
+			expectedResponseBody: `{"error":"resource not found"}`,
+		},
+		{
+			name:                 "Wrong Revision",
+			url:                  "/films/1/revisions/last",
+			mockBehavior:         func(r *mock_store.MockIFilmRepository) {},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"error":"strconv.Atoi: parsing \"last\": invalid syntax"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			filmRepo := mock_store.NewMockIFilmRepository(c)
+			actorRepo := mock_store.NewMockIActorRepository(c)
+			test.mockBehavior(filmRepo)
+			store := mock_store.New(filmRepo, actorRepo)
+			server := NewServer(store)
+
+			// Init Endpoint
+			router := mux.NewRouter()
+			router.HandleFunc("/films/{id}/revisions/{n}", server.handleFilmRevision()).Methods("GET")
+
+			// Create Request
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", test.url, nil)
+
+			// Make Request
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, strings.TrimRight(w.Body.String(), "\n"))
+		})
+	}
+}
+
+func TestHandler_FilmRevert(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	key := []byte("test-key")
+	adminToken, _ := auth.Sign(key, auth.Claims{UserID: 1, Role: auth.RoleAdmin})
+	userToken, _ := auth.Sign(key, auth.Claims{UserID: 2, Role: auth.RoleUser})
+
+	filmRepo := mock_store.NewMockIFilmRepository(c)
+	filmRepo.EXPECT().Revert(1, 1).
+		Return(models.Film{Id: 1, Name: "Film 1", Description: "Descr 1", ReleaseYear: 2001, Rating: 5}, nil)
+	server := NewServer(mock_store.New(filmRepo, mock_store.NewMockIActorRepository(c)), WithAuthKey(key))
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/films/1/revisions/1/revert", nil)
+	req.Header.Set("Authorization", "Bearer "+adminToken)
+	server.ServeHTTP(w, req)
+
+	assert.Equal(t, 200, w.Code)
+	assert.Equal(t, `{"id":1,"name":"Film 1","description":"Descr 1","release_year":2001,"rating":5}`,
+		strings.TrimRight(w.Body.String(), "\n"))
+
+	// reverting is an admin action
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("POST", "/films/1/revisions/1/revert", nil)
+	req.Header.Set("Authorization", "Bearer "+userToken)
+	server.ServeHTTP(w, req)
+	assert.Equal(t, 403, w.Code)
+
+	w = httptest.NewRecorder()
+	server.ServeHTTP(w, httptest.NewRequest("POST", "/films/1/revisions/1/revert", nil))
+	assert.Equal(t, 401, w.Code)
+}
@@ -3,23 +3,50 @@ package handlers
 import (
 	"log/slog"
 	"net/http"
+	"strconv"
 
 	"github.com/gorilla/mux"
 
+	"filmoteka/internal/app/media"
 	"filmoteka/internal/app/store"
 )
 
 type server struct {
-	router *mux.Router
-	logger *slog.Logger
-	store  store.IStore
+	router       *mux.Router
+	logger       *slog.Logger
+	store        store.IStore
+	cacheControl map[string]string
+	authKey      []byte
+	media        media.Storage
+	uploadLimit  int64
+	uploadPixels int
+	importLimit  int64
+	importRows   int
 }
 
-func NewServer(store store.IStore) *server {
+// Option configures optional server behaviour.
+type Option func(*server)
+
+// WithCacheControl sets the Cache-Control header per cacheable route.
+// Route keys are "films", "film", "actors" and "actor".
+func WithCacheControl(cc map[string]string) Option {
+	return func(s *server) {
+		for route, value := range cc {
+			s.cacheControl[route] = value
+		}
+	}
+}
+
+func NewServer(store store.IStore, opts ...Option) *server {
 	s := &server{
-		router: mux.NewRouter(),
-		logger: slog.Default(),
-		store:  store,
+		router:       mux.NewRouter(),
+		logger:       slog.Default(),
+		store:        store,
+		cacheControl: map[string]string{},
+	}
+
+	for _, opt := range opts {
+		opt(s)
 	}
 
 	s.configureRouter()
@@ -31,15 +58,96 @@ func (s *server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	s.router.ServeHTTP(w, r)
 }
 
+// storeFor returns the store serving r. Clients that must see their own
+// writes send "X-Read-Your-Writes: true" to keep reads off replicas.
+// Changes made through the store are audited as made by the caller.
+func (s *server) storeFor(r *http.Request) store.IStore {
+	st := s.store
+	if ryw, _ := strconv.ParseBool(r.Header.Get("X-Read-Your-Writes")); ryw {
+		if ps, ok := st.(store.IPrimaryStore); ok {
+			st = ps.Primary()
+		}
+	}
+
+	if as, ok := st.(store.IAuditedStore); ok {
+		p := store.Principal{RequestID: requestIDFrom(r)}
+		if c, ok := claimsFrom(r); ok {
+			p.UserID = c.UserID
+		}
+		st = as.As(p)
+	}
+
+	return st
+}
+
 func (s *server) configureRouter() {
-	s.router.HandleFunc("/films/{id}", s.handleFilmFind()).Methods("GET")
+	s.router.Use(s.requestID, s.authenticate)
+
+	s.router.HandleFunc("/films/{id}", s.conditional("film", s.handleFilmFind())).Methods("GET")
 	s.router.HandleFunc("/films", s.handleFilmCreate()).Methods("POST")
-	s.router.HandleFunc("/films", s.handleAllFilms()).Methods("GET")
+	s.router.HandleFunc("/films", s.conditional("films", s.handleAllFilms())).Methods("GET")
 	s.router.HandleFunc("/films/{id}", s.handleFilmDelete()).Methods("DELETE")
+	s.router.HandleFunc("/films/by-key", s.handleFilmUpsert()).Methods("PUT")
 	s.router.HandleFunc("/films/{id}", s.handleFilmUpdate()).Methods("PUT")
-	s.router.HandleFunc("/actors/{id}", s.handleActorFind()).Methods("GET")
+	s.router.HandleFunc("/films/{id}/restore", s.requireAdmin(s.handleFilmRestore())).Methods("POST")
+	s.router.HandleFunc("/films/{id}/revisions", s.handleFilmRevisions()).Methods("GET")
+	s.router.HandleFunc("/films/{id}/revisions/{n}", s.handleFilmRevision()).Methods("GET")
+	s.router.HandleFunc("/films/{id}/revisions/{n}/revert", s.requireAdmin(s.handleFilmRevert())).Methods("POST")
+	s.router.HandleFunc("/films/{id}/crew", s.handleFilmCrew()).Methods("GET")
+	s.router.HandleFunc("/films/{id}/crew", s.handleCreditAdd()).Methods("POST")
+	s.router.HandleFunc("/films/{id}/crew/{person_id}/{job}", s.handleCreditRemove()).Methods("DELETE")
+	s.router.HandleFunc("/films/{id}/poster", s.requireUser(s.handleFilmPoster())).Methods("POST")
+	s.router.HandleFunc("/films/{id}/translations/{locale}", s.handleTranslationSet()).Methods("PUT")
+	s.router.HandleFunc("/films/{id}/awards", s.handleFilmAwards()).Methods("GET")
+	s.router.HandleFunc("/films/{id}/related", s.handleFilmRelated()).Methods("GET")
+	s.router.HandleFunc("/films/{id}/related", s.handleRelationAdd()).Methods("POST")
+	s.router.HandleFunc("/films/{id}/related/{related_id}/{type}", s.handleRelationRemove()).Methods("DELETE")
+	s.router.HandleFunc("/films/{id}/my-rating", s.requireUser(s.handleMyRatingSet())).Methods("PUT")
+	s.router.HandleFunc("/films/{id}/my-rating", s.requireUser(s.handleMyRatingFind())).Methods("GET")
+	s.router.HandleFunc("/films/{id}/my-rating", s.requireUser(s.handleMyRatingRemove())).Methods("DELETE")
+	s.router.HandleFunc("/films/{id}/reviews", s.handleFilmReviews()).Methods("GET")
+	s.router.HandleFunc("/films/{id}/reviews", s.requireUser(s.handleReviewCreate())).Methods("POST")
+	s.router.HandleFunc("/reviews", s.requireAdmin(s.handleReviews())).Methods("GET")
+	s.router.HandleFunc("/reviews/{id}", s.handleReviewFind()).Methods("GET")
+	s.router.HandleFunc("/reviews/{id}", s.requireUser(s.handleReviewUpdate())).Methods("PUT")
+	s.router.HandleFunc("/reviews/{id}/status", s.requireAdmin(s.handleReviewModerate())).Methods("PUT")
+	s.router.HandleFunc("/me/watchlist", s.requireUser(s.handleWatchlist())).Methods("GET")
+	s.router.HandleFunc("/me/watchlist/{film_id}", s.requireUser(s.handleWatchlistAdd())).Methods("PUT")
+	s.router.HandleFunc("/me/watchlist/{film_id}", s.requireUser(s.handleWatchlistRemove())).Methods("DELETE")
+	s.router.HandleFunc("/me/watched", s.requireUser(s.handleWatched())).Methods("GET")
+	s.router.HandleFunc("/me/watched", s.requireUser(s.handleWatchedAdd())).Methods("POST")
+	s.router.HandleFunc("/me/watched/{id}", s.requireUser(s.handleWatchedRemove())).Methods("DELETE")
+	s.router.HandleFunc("/collections", s.handleAllCollections()).Methods("GET")
+	s.router.HandleFunc("/collections", s.requireUser(s.handleCollectionCreate())).Methods("POST")
+	s.router.HandleFunc("/collections/{id}", s.handleCollectionFind()).Methods("GET")
+	s.router.HandleFunc("/collections/{id}", s.requireUser(s.handleCollectionUpdate())).Methods("PUT")
+	s.router.HandleFunc("/collections/{id}", s.requireUser(s.handleCollectionDelete())).Methods("DELETE")
+	s.router.HandleFunc("/collections/{id}/films", s.requireUser(s.handleCollectionReorder())).Methods("PUT")
+	s.router.HandleFunc("/collections/{id}/films/{film_id}", s.requireUser(s.handleCollectionPlace())).Methods("PUT")
+	s.router.HandleFunc("/collections/{id}/films/{film_id}", s.requireUser(s.handleCollectionRemoveFilm())).Methods("DELETE")
+	s.router.HandleFunc("/people/{id}/credits", s.handlePersonCredits()).Methods("GET")
+	s.router.HandleFunc("/actors/{id}", s.conditional("actor", s.handleActorFind())).Methods("GET")
 	s.router.HandleFunc("/actors", s.handleActorCreate()).Methods("POST")
-	s.router.HandleFunc("/actors", s.handleAllActors()).Methods("GET")
+	s.router.HandleFunc("/actors", s.conditional("actors", s.handleAllActors())).Methods("GET")
 	s.router.HandleFunc("/actors/{id}", s.handleActorDelete()).Methods("DELETE")
 	s.router.HandleFunc("/actors/{id}", s.handleActorUpdate()).Methods("PUT")
+	s.router.HandleFunc("/actors/{id}/photo", s.requireUser(s.handleActorPhoto())).Methods("POST")
+	s.router.HandleFunc("/actors/{id}/awards", s.handleActorAwards()).Methods("GET")
+	s.router.HandleFunc("/actors/{id}/restore", s.requireAdmin(s.handleActorRestore())).Methods("POST")
+	s.router.HandleFunc("/awards", s.handleAllAwards()).Methods("GET")
+	s.router.HandleFunc("/awards", s.handleAwardCreate()).Methods("POST")
+	s.router.HandleFunc("/awards/{id}", s.handleAwardDelete()).Methods("DELETE")
+	s.router.HandleFunc("/awards/{id}/nominations", s.handleNominate()).Methods("POST")
+	s.router.HandleFunc("/nominations/{id}", s.handleNominationWon()).Methods("PUT")
+	s.router.HandleFunc("/nominations/{id}", s.handleNominationRemove()).Methods("DELETE")
+	s.router.HandleFunc("/genres/{id}", s.handleGenreFind()).Methods("GET")
+	s.router.HandleFunc("/genres", s.handleGenreCreate()).Methods("POST")
+	s.router.HandleFunc("/genres", s.handleAllGenres()).Methods("GET")
+	s.router.HandleFunc("/genres/{id}", s.handleGenreDelete()).Methods("DELETE")
+	s.router.HandleFunc("/genres/{id}", s.handleGenreUpdate()).Methods("PUT")
+	s.router.HandleFunc("/import/films", s.requireAdmin(s.handleFilmImport())).Methods("POST")
+	s.router.HandleFunc("/import/actors", s.requireAdmin(s.handleActorImport())).Methods("POST")
+	s.router.HandleFunc("/export/films", s.handleFilmExport()).Methods("GET")
+	s.router.HandleFunc("/export/actors", s.handleActorExport()).Methods("GET")
+	s.router.HandleFunc("/audit", s.requireAdmin(s.handleAudit())).Methods("GET")
 }
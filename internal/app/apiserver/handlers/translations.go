@@ -0,0 +1,132 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	"filmoteka/internal/app/models"
+)
+
+// maxLocales bounds the locales taken from an Accept-Language header.
+const maxLocales = 10
+
+type RequestTranslation struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+func (s *server) handleTranslationSet() http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		id, err := strconv.Atoi(vars["id"])
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+
+		req := &RequestTranslation{}
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+
+		t := models.FilmTranslation{
+			Locale:      models.NormalizeLocale(vars["locale"]),
+			Name:        req.Name,
+			Description: req.Description,
+		}
+		if err := s.storeFor(r).TranslationRepo().Set(id, t); err != nil {
+			w.WriteHeader(errorStatus(err))
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(map[string]bool{"result": true})
+	})
+}
+
+// localize translates the films into the most preferred locale of the
+// Accept-Language header each has a translation in. Films without one
+// keep their original name and description.
+func (s *server) localize(w http.ResponseWriter, r *http.Request, films ...*models.Film) error {
+	w.Header().Add("Vary", "Accept-Language")
+
+	locales := acceptedLocales(r.Header.Get("Accept-Language"))
+	if len(locales) == 0 || len(films) == 0 {
+		return nil
+	}
+
+	ids := make([]int, len(films))
+	for i, f := range films {
+		ids[i] = f.Id
+	}
+	translations, err := s.storeFor(r).TranslationRepo().ForFilms(ids, locales)
+	if err != nil {
+		return err
+	}
+	for _, f := range films {
+		if t, ok := translations[f.Id]; ok {
+			f.Localize(t)
+		}
+	}
+
+	return nil
+}
+
+// acceptedLocales lists the locales of an Accept-Language header, most
+// preferred first, each region followed by its bare language as the
+// fallback, e.g. "de-AT, fr;q=0.8" as de-AT, de, fr.
+func acceptedLocales(header string) []string {
+	type weighted struct {
+		locale string
+		q      float64
+	}
+
+	var ranges []weighted
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if tag == "" || tag == "*" {
+			continue
+		}
+
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		if q <= 0 {
+			continue
+		}
+
+		ranges = append(ranges, weighted{locale: models.NormalizeLocale(tag), q: q})
+	}
+	sort.SliceStable(ranges, func(i, j int) bool { return ranges[i].q > ranges[j].q })
+
+	var locales []string
+	seen := map[string]bool{}
+	add := func(locale string) {
+		if !seen[locale] && len(locales) < maxLocales {
+			seen[locale] = true
+			locales = append(locales, locale)
+		}
+	}
+	for _, rng := range ranges {
+		add(rng.locale)
+		if language, _, found := strings.Cut(rng.locale, "-"); found {
+			add(language)
+		}
+	}
+
+	return locales
+}
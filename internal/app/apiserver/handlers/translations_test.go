@@ -0,0 +1,143 @@
+package handlers
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"filmoteka/internal/app/models"
+	"filmoteka/internal/app/store"
+	"filmoteka/internal/app/store/mock_store"
+)
+
+func TestHandler_Translations(t *testing.T) {
+	type mockBehavior func(f *mock_store.MockIFilmRepository, r *mock_store.MockITranslationRepository)
+
+	film := models.Film{Id: 1, Name: "Die Hard", Description: "A cop fights terrorists.", ReleaseYear: 1988, Rating: 8}
+
+	tests := []struct {
+		name                 string
+		method               string
+		url                  string
+		acceptLanguage       string
+		inputBody            string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "Set",
+			method:    "PUT",
+			url:       "/films/1/translations/PT-br",
+			inputBody: `{"name":"Duro de Matar"}`,
+			mockBehavior: func(f *mock_store.MockIFilmRepository, r *mock_store.MockITranslationRepository) {
+				r.EXPECT().Set(1, models.FilmTranslation{Locale: "pt-BR", Name: "Duro de Matar"}).Return(nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"result":true}`,
+		},
+		{
+			name:      "Set Invalid",
+			method:    "PUT",
+			url:       "/films/1/translations/xx",
+			inputBody: `{"name":"Die Hard"}`,
+			mockBehavior: func(f *mock_store.MockIFilmRepository, r *mock_store.MockITranslationRepository) {
+				r.EXPECT().Set(1, models.FilmTranslation{Locale: "xx", Name: "Die Hard"}).Return(store.ErrValidation)
+			},
+			expectedStatusCode:   422,
+			expectedResponseBody: `{"error":"validation error"}`,
+		},
+		{
+			name:      "Set Not Found",
+			method:    "PUT",
+			url:       "/films/9/translations/de",
+			inputBody: `{"name":"Stirb langsam"}`,
+			mockBehavior: func(f *mock_store.MockIFilmRepository, r *mock_store.MockITranslationRepository) {
+				r.EXPECT().Set(9, models.FilmTranslation{Locale: "de", Name: "Stirb langsam"}).Return(store.ErrResourceNotFound)
+			},
+			expectedStatusCode:   404,
+			expectedResponseBody: `{"error":"resource not found"}`,
+		},
+		{
+			name:           "Find Translated",
+			method:         "GET",
+			url:            "/films/1",
+			acceptLanguage: "de-AT, en;q=0.5",
+			mockBehavior: func(f *mock_store.MockIFilmRepository, r *mock_store.MockITranslationRepository) {
+				f.EXPECT().Find(1).Return(film, nil)
+				r.EXPECT().ForFilms([]int{1}, []string{"de-AT", "de", "en"}).Return(map[int]models.FilmTranslation{
+					1: {Locale: "de", Name: "Stirb langsam"},
+				}, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"id":1,"name":"Stirb langsam","description":"A cop fights terrorists.","release_year":1988,"rating":8}`,
+		},
+		{
+			name:           "Find Untranslated",
+			method:         "GET",
+			url:            "/films/1",
+			acceptLanguage: "fr",
+			mockBehavior: func(f *mock_store.MockIFilmRepository, r *mock_store.MockITranslationRepository) {
+				f.EXPECT().Find(1).Return(film, nil)
+				r.EXPECT().ForFilms([]int{1}, []string{"fr"}).Return(map[int]models.FilmTranslation{}, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"id":1,"name":"Die Hard","description":"A cop fights terrorists.","release_year":1988,"rating":8}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			filmRepo := mock_store.NewMockIFilmRepository(c)
+			translationRepo := mock_store.NewMockITranslationRepository(c)
+			test.mockBehavior(filmRepo, translationRepo)
+			st := mock_store.New(filmRepo, mock_store.NewMockIActorRepository(c)).WithTranslationRepo(translationRepo)
+			server := NewServer(st)
+
+			// Create Request
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(test.method, test.url, bytes.NewBufferString(test.inputBody))
+			if test.acceptLanguage != "" {
+				req.Header.Set("Accept-Language", test.acceptLanguage)
+			}
+
+			// Make Request
+			server.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, strings.TrimRight(w.Body.String(), "\n"))
+			if test.method == "GET" {
+				assert.Equal(t, "Accept-Language", w.Header().Get("Vary"))
+			}
+		})
+	}
+}
+
+func TestAcceptedLocales(t *testing.T) {
+	tests := []struct {
+		header string
+		want   []string
+	}{
+		{header: "", want: nil},
+		{header: "*", want: nil},
+		{header: "pt-br", want: []string{"pt-BR", "pt"}},
+		{header: "fr;q=0.8, de-AT", want: []string{"de-AT", "de", "fr"}},
+		{header: "en-US, en;q=0.9, de;q=0", want: []string{"en-US", "en"}},
+		{header: "it;q=oops, es", want: []string{"es"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			assert.Equal(t, tt.want, acceptedLocales(tt.header))
+		})
+	}
+}
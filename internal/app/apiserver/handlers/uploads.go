@@ -0,0 +1,128 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+
+	"filmoteka/internal/app/media"
+	"filmoteka/internal/app/models"
+)
+
+// uploadField is the multipart form field carrying the image.
+const uploadField = "image"
+
+var errNoMedia = errors.New("media storage not configured")
+
+// WithMedia stores uploaded images in st, refusing uploads larger than
+// maxBytes or than maxPixels once decoded.
+func WithMedia(st media.Storage, maxBytes int64, maxPixels int) Option {
+	return func(s *server) {
+		s.media = st
+		s.uploadLimit = maxBytes
+		s.uploadPixels = maxPixels
+	}
+}
+
+func (s *server) handleFilmPoster() http.HandlerFunc {
+	return s.upload("films/%d/poster", func(r *http.Request, id int, img models.Image) (*models.Image, error) {
+		return s.storeFor(r).MediaRepo().SetPoster(id, img)
+	})
+}
+
+func (s *server) handleActorPhoto() http.HandlerFunc {
+	return s.upload("actors/%d/photo", func(r *http.Request, id int, img models.Image) (*models.Image, error) {
+		return s.storeFor(r).MediaRepo().SetPhoto(id, img)
+	})
+}
+
+// upload returns a handler storing the image posted for the row with the
+// {id} of the route under the key prefix format, then attaching it with
+// set. The files of the replaced image are removed once the new one is
+// attached, those of a rejected one right away.
+func (s *server) upload(format string, set func(*http.Request, int, models.Image) (*models.Image, error)) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(mux.Vars(r)["id"])
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+		if s.media == nil {
+			w.WriteHeader(http.StatusNotImplemented)
+			json.NewEncoder(w).Encode(map[string]string{"error": errNoMedia.Error()})
+			return
+		}
+
+		data, err := s.readUpload(w, r)
+		if err != nil {
+			w.WriteHeader(uploadStatus(err))
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+
+		img, err := media.Save(s.media, fmt.Sprintf(format, id), data, s.uploadPixels)
+		if err != nil {
+			w.WriteHeader(uploadStatus(err))
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+
+		prev, err := set(r, id, img)
+		if err != nil {
+			s.removeImage(img.Key)
+			w.WriteHeader(errorStatus(err))
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+		// the same file uploaded again lands on the same key
+		if prev != nil && prev.Key != img.Key {
+			s.removeImage(prev.Key)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(img)
+	})
+}
+
+// readUpload reads the image field of a multipart request body of at
+// most uploadLimit bytes.
+func (s *server) readUpload(w http.ResponseWriter, r *http.Request) ([]byte, error) {
+	if s.uploadLimit > 0 {
+		r.Body = http.MaxBytesReader(w, r.Body, s.uploadLimit)
+	}
+
+	file, _, err := r.FormFile(uploadField)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return io.ReadAll(file)
+}
+
+func (s *server) removeImage(key string) {
+	if err := s.media.Delete(key); err != nil {
+		s.logger.Error("removing image files", "key", key, "error", err)
+	}
+}
+
+// uploadStatus maps an upload error to a response status.
+func uploadStatus(err error) int {
+	var tooLarge *http.MaxBytesError
+	switch {
+	case errors.As(err, &tooLarge), errors.Is(err, media.ErrTooManyPixels):
+		return http.StatusRequestEntityTooLarge
+	case errors.Is(err, media.ErrUnsupportedType):
+		return http.StatusUnsupportedMediaType
+	case errors.Is(err, http.ErrNotMultipart), errors.Is(err, http.ErrMissingFile):
+		return http.StatusBadRequest
+	}
+
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,175 @@
+package handlers
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"image"
+	"image/png"
+	"mime/multipart"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"filmoteka/internal/app/auth"
+	"filmoteka/internal/app/media"
+	"filmoteka/internal/app/models"
+	"filmoteka/internal/app/store"
+	"filmoteka/internal/app/store/mock_store"
+)
+
+// multipartBody returns a request body posting data as the image field.
+func multipartBody(t *testing.T, data []byte) (*bytes.Buffer, string) {
+	body := &bytes.Buffer{}
+	mw := multipart.NewWriter(body)
+	fw, err := mw.CreateFormFile("image", "upload")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fw.Write(data)
+	mw.Close()
+
+	return body, mw.FormDataContentType()
+}
+
+func TestHandler_Uploads(t *testing.T) {
+	type mockBehavior func(r *mock_store.MockIMediaRepository)
+
+	var poster bytes.Buffer
+	if err := png.Encode(&poster, image.NewRGBA(image.Rect(0, 0, 40, 60))); err != nil {
+		t.Fatal(err)
+	}
+	sum := sha256.Sum256(poster.Bytes())
+	hash := fmt.Sprintf("%x", sum[:8])
+
+	var huge bytes.Buffer
+	if err := png.Encode(&huge, image.NewRGBA(image.Rect(0, 0, 100, 100))); err != nil {
+		t.Fatal(err)
+	}
+
+	key := []byte("test-key")
+	userToken, _ := auth.Sign(key, auth.Claims{UserID: 2, Role: auth.RoleUser})
+
+	tests := []struct {
+		name                 string
+		token                string
+		url                  string
+		inputBody            []byte
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+		// expectedFiles are the image directories left in storage
+		expectedFiles []string
+	}{
+		{
+			name:      "Poster",
+			token:     userToken,
+			url:       "/films/1/poster",
+			inputBody: poster.Bytes(),
+			mockBehavior: func(r *mock_store.MockIMediaRepository) {
+				r.EXPECT().SetPoster(1, gomock.Any()).Return(&models.Image{Key: "films/1/poster/old"}, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"url":"/media/films/1/poster/` + hash + `/original.png","thumbnails":{"large":"/media/films/1/poster/` + hash + `/w500.jpg","medium":"/media/films/1/poster/` + hash + `/w185.jpg","small":"/media/films/1/poster/` + hash + `/w92.jpg"}}`,
+			expectedFiles:        []string{"films/1/poster/" + hash},
+		},
+		{
+			name:      "Photo Not Found",
+			token:     userToken,
+			url:       "/actors/9/photo",
+			inputBody: poster.Bytes(),
+			mockBehavior: func(r *mock_store.MockIMediaRepository) {
+				r.EXPECT().SetPhoto(9, gomock.Any()).Return(nil, store.ErrResourceNotFound)
+			},
+			expectedStatusCode:   404,
+			expectedResponseBody: `{"error":"resource not found"}`,
+			expectedFiles:        []string{"films/1/poster/old"},
+		},
+		{
+			name:                 "Not An Image",
+			token:                userToken,
+			url:                  "/films/1/poster",
+			inputBody:            []byte("just some text"),
+			mockBehavior:         func(r *mock_store.MockIMediaRepository) {},
+			expectedStatusCode:   415,
+			expectedResponseBody: `{"error":"unsupported image type, use jpeg or png"}`,
+			expectedFiles:        []string{"films/1/poster/old"},
+		},
+		{
+			name:                 "Too Large",
+			token:                userToken,
+			url:                  "/films/1/poster",
+			inputBody:            make([]byte, 2<<10),
+			mockBehavior:         func(r *mock_store.MockIMediaRepository) {},
+			expectedStatusCode:   413,
+			expectedResponseBody: `{"error":"http: request body too large"}`,
+			expectedFiles:        []string{"films/1/poster/old"},
+		},
+		{
+			name:                 "Too Many Pixels",
+			token:                userToken,
+			url:                  "/films/1/poster",
+			inputBody:            huge.Bytes(),
+			mockBehavior:         func(r *mock_store.MockIMediaRepository) {},
+			expectedStatusCode:   413,
+			expectedResponseBody: `{"error":"image has too many pixels"}`,
+			expectedFiles:        []string{"films/1/poster/old"},
+		},
+		{
+			name:                 "Anonymous",
+			url:                  "/films/1/poster",
+			inputBody:            poster.Bytes(),
+			mockBehavior:         func(r *mock_store.MockIMediaRepository) {},
+			expectedStatusCode:   401,
+			expectedResponseBody: `{"error":"authentication required"}`,
+			expectedFiles:        []string{"films/1/poster/old"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Init Dependencies
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			mediaRepo := mock_store.NewMockIMediaRepository(c)
+			test.mockBehavior(mediaRepo)
+			st := mock_store.New(mock_store.NewMockIFilmRepository(c), mock_store.NewMockIActorRepository(c)).WithMediaRepo(mediaRepo)
+			dir := t.TempDir()
+			files := media.NewLocal(dir, "/media/")
+			// the old poster is removed once replaced
+			files.Put("films/1/poster/old/original.png", []byte("old"))
+			server := NewServer(st, WithAuthKey(key), WithMedia(files, 1<<10+512, 5000))
+
+			// Create Request
+			body, contentType := multipartBody(t, test.inputBody)
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", test.url, body)
+			req.Header.Set("Content-Type", contentType)
+			if test.token != "" {
+				req.Header.Set("Authorization", "Bearer "+test.token)
+			}
+
+			// Make Request
+			server.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, strings.TrimRight(w.Body.String(), "\n"))
+
+			var left []string
+			for _, kind := range []string{"films/1/poster", "actors/9/photo"} {
+				entries, _ := os.ReadDir(filepath.Join(dir, filepath.FromSlash(kind)))
+				for _, e := range entries {
+					left = append(left, kind+"/"+e.Name())
+				}
+			}
+			assert.Equal(t, test.expectedFiles, left)
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package apiserver
+
+import (
+	"fmt"
+	"io"
+
+	"filmoteka/internal/app/importer"
+	"filmoteka/internal/app/store/sqlstore"
+)
+
+// Import loads films or actors from r straight into the database.
+func Import(config *Config, entity string, r io.Reader, opts importer.Options) (*importer.Report, error) {
+	db, err := newDB(config)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	store := sqlstore.New(db, sqlstore.WithRetry(config.retryPolicy()))
+	switch entity {
+	case "films":
+		return importer.Films(store.FilmRepo(), r, opts)
+	case "actors":
+		return importer.Actors(store.ActorRepo(), r, opts)
+	}
+
+	return nil, fmt.Errorf("unknown entity %q, use films or actors", entity)
+}
@@ -0,0 +1,60 @@
+package apiserver
+
+import (
+	"fmt"
+	"time"
+
+	"filmoteka/internal/app/media"
+	"filmoteka/internal/app/store/sqlstore"
+)
+
+// PurgeReport counts the rows removed by Purge.
+type PurgeReport struct {
+	Films  int64 `json:"films"`
+	Actors int64 `json:"actors"`
+}
+
+// Purge permanently removes films and actors soft deleted before the
+// given time, along with their uploaded images.
+func Purge(config *Config, before time.Time) (*PurgeReport, error) {
+	db, err := newDB(config)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	store := sqlstore.New(db, sqlstore.WithRetry(config.retryPolicy()))
+	files := media.NewLocal(config.MediaDir, config.MediaURL)
+	report := &PurgeReport{}
+
+	films, err := store.FilmRepo().Purge(before)
+	if err != nil {
+		return nil, err
+	}
+	report.Films = int64(len(films))
+	actors, err := store.ActorRepo().Purge(before)
+	report.Actors = int64(len(actors))
+
+	// the rows are gone once Purge returns, their files go after them
+	if ferr := removeImages(files, "films", films); err == nil {
+		err = ferr
+	}
+	if ferr := removeImages(files, "actors", actors); err == nil {
+		err = ferr
+	}
+
+	return report, err
+}
+
+// removeImages deletes the uploads kept under entity/{id}/ for each id,
+// returning the first failure after trying them all.
+func removeImages(st media.Storage, entity string, ids []int) error {
+	var first error
+	for _, id := range ids {
+		if err := st.Delete(fmt.Sprintf("%s/%d", entity, id)); err != nil && first == nil {
+			first = err
+		}
+	}
+
+	return first
+}
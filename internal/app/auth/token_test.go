@@ -0,0 +1,45 @@
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignVerify(t *testing.T) {
+	key := []byte("secret")
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	claims := Claims{UserID: 7, Role: RoleAdmin, ExpiresAt: now.Add(time.Hour).Unix()}
+
+	token, err := Sign(key, claims)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		key     []byte
+		token   string
+		now     time.Time
+		wantErr error
+	}{
+		{name: "valid", key: key, token: token, now: now},
+		{name: "wrong key", key: []byte("other"), token: token, now: now, wantErr: ErrInvalidToken},
+		{name: "tampered", key: key, token: "x" + token, now: now, wantErr: ErrInvalidToken},
+		{name: "malformed", key: key, token: "abc", now: now, wantErr: ErrInvalidToken},
+		{name: "expired", key: key, token: token, now: now.Add(2 * time.Hour), wantErr: ErrExpiredToken},
+		{name: "no key", token: token, now: now, wantErr: ErrNoKey},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Verify(tt.key, tt.token, tt.now)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, claims, got)
+				assert.True(t, got.IsAdmin())
+			}
+		})
+	}
+}
@@ -0,0 +1,234 @@
+package exporter
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"filmoteka/internal/app/models"
+)
+
+// Format of an export stream.
+type Format string
+
+const (
+	FormatCSV    Format = "csv"
+	FormatNDJSON Format = "ndjson"
+	FormatJSON   Format = "json"
+)
+
+// flushEvery is the number of rows written between flushes.
+const flushEvery = 100
+
+var ErrUnknownFormat = errors.New("unknown export format, use csv, ndjson or json")
+
+// ContentType returns the media type served for f.
+func (f Format) ContentType() string {
+	switch f {
+	case FormatCSV:
+		return "text/csv; charset=utf-8"
+	case FormatNDJSON:
+		return "application/x-ndjson"
+	}
+
+	return "application/json"
+}
+
+// ParseFormat accepts a format name or a media type.
+func ParseFormat(s string) (Format, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if i := strings.IndexByte(s, ';'); i >= 0 {
+		s = strings.TrimSpace(s[:i])
+	}
+
+	switch s {
+	case "csv", "text/csv":
+		return FormatCSV, nil
+	case "ndjson", "jsonl", "application/x-ndjson", "application/jsonl", "application/jsonlines":
+		return FormatNDJSON, nil
+	case "json", "application/json", "application/*", "*/*":
+		return FormatJSON, nil
+	}
+
+	return "", ErrUnknownFormat
+}
+
+// Negotiate picks the format from an Accept header, honouring q-values.
+// An empty header selects JSON.
+func Negotiate(accept string) (Format, error) {
+	if strings.TrimSpace(accept) == "" {
+		return FormatJSON, nil
+	}
+
+	var (
+		best  Format
+		bestQ = -1.0
+	)
+	for _, part := range strings.Split(accept, ",") {
+		media, params, _ := strings.Cut(part, ";")
+		q := 1.0
+		for _, p := range strings.Split(params, ";") {
+			k, v, ok := strings.Cut(strings.TrimSpace(p), "=")
+			if ok && strings.TrimSpace(k) == "q" {
+				if parsed, err := strconv.ParseFloat(v, 64); err == nil {
+					q = parsed
+				}
+			}
+		}
+		f, err := ParseFormat(media)
+		if err != nil || q <= 0 {
+			continue
+		}
+		if q > bestQ {
+			best, bestQ = f, q
+		}
+	}
+	if bestQ < 0 {
+		return "", ErrUnknownFormat
+	}
+
+	return best, nil
+}
+
+// listSeparator joins list fields inside one CSV cell; the importer
+// splits on the same character.
+const listSeparator = "|"
+
+var (
+	filmHeader = []string{
+		"id", "name", "description", "release_year", "rating",
+		"runtime", "original_title", "original_language", "countries", "age_rating",
+		"genres", "credits",
+	}
+	actorHeader = []string{
+		"id", "name", "gender", "birth_date",
+		"death_date", "birthplace", "biography", "aliases",
+	}
+)
+
+func filmRecord(f models.Film) []string {
+	runtime := ""
+	if f.Runtime > 0 {
+		runtime = strconv.FormatUint(uint64(f.Runtime), 10)
+	}
+	// a credit is written as person_id:job
+	credits := make([]string, len(f.Credits))
+	for i, c := range f.Credits {
+		credits[i] = strconv.Itoa(c.PersonID) + ":" + c.Job
+	}
+
+	return []string{
+		strconv.Itoa(f.Id),
+		f.Name,
+		f.Description,
+		strconv.FormatUint(uint64(f.ReleaseYear), 10),
+		strconv.FormatFloat(float64(f.Rating), 'f', -1, 32),
+		runtime,
+		f.OriginalTitle,
+		f.OriginalLanguage,
+		strings.Join(f.Countries, listSeparator),
+		f.AgeRating,
+		strings.Join(f.Genres, listSeparator),
+		strings.Join(credits, listSeparator),
+	}
+}
+
+func actorRecord(a models.Actor) []string {
+	return []string{
+		strconv.Itoa(a.Id),
+		a.Name,
+		a.Gender,
+		a.BirthDate,
+		a.DeathDate,
+		a.Birthplace,
+		a.Biography,
+		strings.Join(a.Aliases, listSeparator),
+	}
+}
+
+// Films writes every film produced by each to w.
+func Films(w io.Writer, format Format, each func(func(models.Film) error) error) error {
+	return export(w, format, filmHeader, filmRecord, each)
+}
+
+// Actors writes every actor produced by each to w.
+func Actors(w io.Writer, format Format, each func(func(models.Actor) error) error) error {
+	return export(w, format, actorHeader, actorRecord, each)
+}
+
+func export[T any](
+	w io.Writer,
+	format Format,
+	header []string,
+	record func(T) []string,
+	each func(func(T) error) error,
+) error {
+	flusher, _ := w.(http.Flusher)
+	n := 0
+	flush := func() {
+		n++
+		if flusher != nil && n%flushEvery == 0 {
+			flusher.Flush()
+		}
+	}
+
+	switch format {
+	case FormatCSV:
+		cw := csv.NewWriter(w)
+		if err := cw.Write(header); err != nil {
+			return err
+		}
+		err := each(func(v T) error {
+			if err := cw.Write(record(v)); err != nil {
+				return err
+			}
+			if (n+1)%flushEvery == 0 {
+				cw.Flush()
+			}
+			flush()
+			return cw.Error()
+		})
+		cw.Flush()
+		if err != nil {
+			return err
+		}
+		return cw.Error()
+
+	case FormatNDJSON:
+		enc := json.NewEncoder(w)
+		return each(func(v T) error {
+			defer flush()
+			return enc.Encode(v)
+		})
+
+	case FormatJSON:
+		if _, err := io.WriteString(w, "["); err != nil {
+			return err
+		}
+		err := each(func(v T) error {
+			defer flush()
+			if n > 0 {
+				if _, err := io.WriteString(w, ","); err != nil {
+					return err
+				}
+			}
+			b, err := json.Marshal(v)
+			if err != nil {
+				return err
+			}
+			_, err = w.Write(b)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+		_, err = io.WriteString(w, "]\n")
+		return err
+	}
+
+	return ErrUnknownFormat
+}
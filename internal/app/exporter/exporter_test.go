@@ -0,0 +1,102 @@
+package exporter
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"filmoteka/internal/app/models"
+)
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		accept  string
+		want    Format
+		wantErr bool
+	}{
+		{accept: "", want: FormatJSON},
+		{accept: "*/*", want: FormatJSON},
+		{accept: "text/csv", want: FormatCSV},
+		{accept: "application/json;q=0.5, application/x-ndjson", want: FormatNDJSON},
+		{accept: "text/csv;q=0.9, application/json;q=0.4", want: FormatCSV},
+		{accept: "image/png", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.accept, func(t *testing.T) {
+			got, err := Negotiate(tt.accept)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestFilms(t *testing.T) {
+	films := []models.Film{
+		{Id: 1, Name: "Film, One", Description: "Desc1", ReleaseYear: 2001, Rating: 7.5},
+		{Id: 2, Name: "Film Two", Description: "Desc2", ReleaseYear: 2002, Rating: 8},
+	}
+	each := func(fn func(models.Film) error) error {
+		for _, f := range films {
+			if err := fn(f); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	tests := []struct {
+		format Format
+		want   string
+	}{
+		{
+			format: FormatCSV,
+			want: "id,name,description,release_year,rating,runtime,original_title,original_language,countries,age_rating,genres,credits\n" +
+				"1,\"Film, One\",Desc1,2001,7.5,,,,,,,\n" +
+				"2,Film Two,Desc2,2002,8,,,,,,,\n",
+		},
+		{
+			format: FormatNDJSON,
+			want: `{"id":1,"name":"Film, One","description":"Desc1","release_year":2001,"rating":7.5}` + "\n" +
+				`{"id":2,"name":"Film Two","description":"Desc2","release_year":2002,"rating":8}` + "\n",
+		},
+		{
+			format: FormatJSON,
+			want: `[{"id":1,"name":"Film, One","description":"Desc1","release_year":2001,"rating":7.5},` +
+				`{"id":2,"name":"Film Two","description":"Desc2","release_year":2002,"rating":8}]` + "\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.format), func(t *testing.T) {
+			var buf bytes.Buffer
+			assert.NoError(t, Films(&buf, tt.format, each))
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+}
+
+func TestActors_CSV(t *testing.T) {
+	each := func(fn func(models.Actor) error) error {
+		return fn(models.Actor{
+			Id:         1,
+			Name:       "Actor One",
+			Gender:     models.GenderFemale,
+			BirthDate:  "1950-01-02",
+			DeathDate:  "2020-03-04",
+			Birthplace: "Paris, France",
+			Biography:  "Bio",
+			Aliases:    []string{"A. One", "Uno"},
+		})
+	}
+
+	var buf bytes.Buffer
+	assert.NoError(t, Actors(&buf, FormatCSV, each))
+	assert.Equal(t, "id,name,gender,birth_date,death_date,birthplace,biography,aliases\n"+
+		"1,Actor One,female,1950-01-02,2020-03-04,\"Paris, France\",Bio,A. One|Uno\n", buf.String())
+}
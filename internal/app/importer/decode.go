@@ -0,0 +1,202 @@
+package importer
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"filmoteka/internal/app/models"
+)
+
+// Format of an import stream.
+type Format string
+
+const (
+	FormatCSV    Format = "csv"
+	FormatNDJSON Format = "ndjson"
+)
+
+// listSeparator splits list fields inside one CSV cell, as written by
+// the exporter.
+const listSeparator = "|"
+
+// Columns a CSV header must name; the optional ones are read when present
+// so files from before they existed still import.
+var (
+	filmColumns  = []string{"name", "description", "release_year", "rating"}
+	filmOptional = []string{
+		"runtime", "original_title", "original_language", "countries", "age_rating",
+		"genres", "credits",
+	}
+
+	actorColumns  = []string{"name", "gender", "birth_date"}
+	actorOptional = []string{"death_date", "birthplace", "biography", "aliases"}
+)
+
+// splitList reads a list cell; an empty cell is an empty list.
+func splitList(s string) []string {
+	if s == "" {
+		return nil
+	}
+	parts := strings.Split(s, listSeparator)
+	for i := range parts {
+		parts[i] = strings.TrimSpace(parts[i])
+	}
+
+	return parts
+}
+
+// parseCredits reads a list cell of person_id:job credits.
+func parseCredits(s string) ([]models.FilmCredit, error) {
+	parts := splitList(s)
+	if parts == nil {
+		return nil, nil
+	}
+
+	credits := make([]models.FilmCredit, len(parts))
+	for i, part := range parts {
+		id, job, ok := strings.Cut(part, ":")
+		if !ok {
+			return nil, fmt.Errorf("credit %q: want person_id:job", part)
+		}
+		personID, err := strconv.Atoi(strings.TrimSpace(id))
+		if err != nil {
+			return nil, fmt.Errorf("credit %q: %w", part, err)
+		}
+		credits[i] = models.FilmCredit{PersonID: personID, Job: strings.TrimSpace(job)}
+	}
+
+	return credits, nil
+}
+
+// ParseFormat accepts a format name or a media type.
+func ParseFormat(s string) (Format, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if i := strings.IndexByte(s, ';'); i >= 0 {
+		s = strings.TrimSpace(s[:i])
+	}
+
+	switch s {
+	case "csv", "text/csv":
+		return FormatCSV, nil
+	case "ndjson", "jsonl", "application/x-ndjson", "application/jsonl", "application/jsonlines":
+		return FormatNDJSON, nil
+	}
+
+	return "", ErrUnknownFormat
+}
+
+// item is a decoded row; err is set when the row could not be parsed.
+type item[T any] struct {
+	row   int
+	value T
+	err   error
+}
+
+func decode[T any](
+	r io.Reader,
+	format Format,
+	maxRows int,
+	columns, optional []string,
+	fromCSV func(map[string]string) (T, error),
+) ([]item[T], error) {
+	switch format {
+	case FormatCSV:
+		return decodeCSV(r, maxRows, columns, optional, fromCSV)
+	case FormatNDJSON:
+		return decodeNDJSON[T](r, maxRows)
+	}
+
+	return nil, ErrUnknownFormat
+}
+
+// decodeCSV reads a CSV stream whose first line names the columns.
+func decodeCSV[T any](r io.Reader, maxRows int, columns, optional []string, fromRecord func(map[string]string) (T, error)) ([]item[T], error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("csv header: %w", err)
+	}
+	index := make(map[string]int, len(header))
+	for i, name := range header {
+		index[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, c := range columns {
+		if _, ok := index[c]; !ok {
+			return nil, fmt.Errorf("csv header: missing column %q", c)
+		}
+	}
+	for _, c := range optional {
+		if _, ok := index[c]; ok {
+			columns = append(columns[:len(columns):len(columns)], c)
+		}
+	}
+
+	var items []item[T]
+	for row := 1; ; row++ {
+		rec, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if maxRows > 0 && row > maxRows {
+			return nil, ErrTooManyRows
+		}
+		it := item[T]{row: row}
+		if err != nil {
+			var perr *csv.ParseError
+			if !errors.As(err, &perr) {
+				return nil, err
+			}
+			it.err = err
+			items = append(items, it)
+			continue
+		}
+
+		fields := make(map[string]string, len(columns))
+		for _, c := range columns {
+			if i := index[c]; i < len(rec) {
+				fields[c] = strings.TrimSpace(rec[i])
+			}
+		}
+		it.value, it.err = fromRecord(fields)
+		items = append(items, it)
+	}
+
+	return items, nil
+}
+
+// decodeNDJSON reads one JSON object per line, skipping blank lines.
+func decodeNDJSON[T any](r io.Reader, maxRows int) ([]item[T], error) {
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	var items []item[T]
+	row := 0
+	for sc.Scan() {
+		line := bytes.TrimSpace(sc.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		row++
+		if maxRows > 0 && row > maxRows {
+			return nil, ErrTooManyRows
+		}
+		it := item[T]{row: row}
+		it.err = json.Unmarshal(line, &it.value)
+		items = append(items, it)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
@@ -0,0 +1,240 @@
+package importer
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+
+	"filmoteka/internal/app/models"
+	"filmoteka/internal/app/store"
+)
+
+// Row statuses reported for every imported row.
+const (
+	StatusCreated = "created"
+	StatusSkipped = "skipped"
+	StatusFailed  = "failed"
+)
+
+const defaultBatchSize = 500
+
+var (
+	ErrUnknownFormat = errors.New("unknown import format, use csv or ndjson")
+	ErrTooManyRows   = errors.New("too many rows")
+	errRolledBack    = errors.New("rolled back, another row failed")
+)
+
+// Options controls how an import is parsed and written.
+type Options struct {
+	Format Format
+	// Atomic imports all rows in a single transaction: any failure leaves
+	// the catalogue untouched. Otherwise rows are written in batches of
+	// BatchSize and failing rows are reported and left out.
+	Atomic    bool
+	BatchSize int
+	// MaxRows refuses streams of more data rows with ErrTooManyRows
+	// before anything is written; 0 is unlimited.
+	MaxRows int
+}
+
+// RowResult is the outcome of a single input row. Row is 1-based and
+// counts data rows only (a CSV header is not counted).
+type RowResult struct {
+	Row    int    `json:"row"`
+	Status string `json:"status"`
+	Id     int    `json:"id,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// Report summarises an import.
+type Report struct {
+	Created    int         `json:"created"`
+	Skipped    int         `json:"skipped"`
+	Failed     int         `json:"failed"`
+	RolledBack bool        `json:"rolled_back"`
+	Rows       []RowResult `json:"rows"`
+}
+
+// Films imports films read from r into repo.
+func Films(repo store.IFilmRepository, r io.Reader, opts Options) (*Report, error) {
+	items, err := decode(r, opts.Format, opts.MaxRows, filmColumns, filmOptional, func(rec map[string]string) (models.Film, error) {
+		f := models.Film{
+			Name:             rec["name"],
+			Description:      rec["description"],
+			OriginalTitle:    rec["original_title"],
+			OriginalLanguage: rec["original_language"],
+			Countries:        splitList(rec["countries"]),
+			AgeRating:        rec["age_rating"],
+			Genres:           splitList(rec["genres"]),
+		}
+		year, err := strconv.ParseUint(rec["release_year"], 10, 16)
+		if err != nil {
+			return f, fmt.Errorf("release_year: %w", err)
+		}
+		f.ReleaseYear = uint16(year)
+		rating, err := strconv.ParseFloat(rec["rating"], 32)
+		if err != nil {
+			return f, fmt.Errorf("rating: %w", err)
+		}
+		f.Rating = float32(rating)
+		if s := rec["runtime"]; s != "" {
+			runtime, err := strconv.ParseUint(s, 10, 16)
+			if err != nil {
+				return f, fmt.Errorf("runtime: %w", err)
+			}
+			f.Runtime = uint16(runtime)
+		}
+		credits, err := parseCredits(rec["credits"])
+		if err != nil {
+			return f, fmt.Errorf("credits: %w", err)
+		}
+		f.Credits = credits
+
+		return f, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return run(items, (*models.Film).Validate, repo.CreateBatch, opts), nil
+}
+
+// Actors imports actors read from r into repo.
+func Actors(repo store.IActorRepository, r io.Reader, opts Options) (*Report, error) {
+	items, err := decode(r, opts.Format, opts.MaxRows, actorColumns, actorOptional, func(rec map[string]string) (models.Actor, error) {
+		return models.Actor{
+			Name:       rec["name"],
+			Gender:     rec["gender"],
+			BirthDate:  rec["birth_date"],
+			DeathDate:  rec["death_date"],
+			Birthplace: rec["birthplace"],
+			Biography:  rec["biography"],
+			Aliases:    splitList(rec["aliases"]),
+		}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	// accept the legacy M/F codes in both formats
+	for i := range items {
+		items[i].value.Gender = models.NormalizeGender(items[i].value.Gender)
+	}
+
+	return run(items, (*models.Actor).Validate, repo.CreateBatch, opts), nil
+}
+
+func run[T any](
+	items []item[T],
+	validate func(*T) error,
+	create func([]T) ([]int, error),
+	opts Options,
+) *Report {
+	report := &Report{Rows: make([]RowResult, len(items))}
+
+	invalid := 0
+	pending := make([]int, 0, len(items))
+	for i := range items {
+		report.Rows[i].Row = items[i].row
+		err := items[i].err
+		if err == nil {
+			err = validate(&items[i].value)
+		}
+		if err != nil {
+			report.fail(i, err)
+			invalid++
+			continue
+		}
+		pending = append(pending, i)
+	}
+
+	if opts.Atomic {
+		if invalid > 0 {
+			report.rollback(pending)
+		} else {
+			insert(report, items, pending, create, true)
+		}
+	} else {
+		size := opts.BatchSize
+		if size <= 0 {
+			size = defaultBatchSize
+		}
+		for start := 0; start < len(pending); start += size {
+			end := min(start+size, len(pending))
+			insert(report, items, pending[start:end], create, false)
+		}
+	}
+
+	report.tally()
+
+	return report
+}
+
+// insert writes the rows at idx. In best-effort mode a row aborting the
+// batch is marked failed and the rest of the batch is retried without it.
+func insert[T any](rep *Report, items []item[T], idx []int, create func([]T) ([]int, error), atomic bool) {
+	for len(idx) > 0 {
+		values := make([]T, len(idx))
+		for j, i := range idx {
+			values[j] = items[i].value
+		}
+
+		ids, err := create(values)
+		var be *store.BatchError
+		switch {
+		case errors.As(err, &be) && be.Row < len(idx):
+			rep.fail(idx[be.Row], be.Err)
+			rest := make([]int, 0, len(idx)-1)
+			rest = append(rest, idx[:be.Row]...)
+			rest = append(rest, idx[be.Row+1:]...)
+			if atomic {
+				rep.rollback(rest)
+				return
+			}
+			idx = rest
+		case err != nil:
+			for _, i := range idx {
+				rep.fail(i, err)
+			}
+			rep.RolledBack = rep.RolledBack || atomic
+			return
+		default:
+			for j, i := range idx {
+				if ids[j] == 0 {
+					rep.Rows[i].Status = StatusSkipped
+					rep.Rows[i].Error = "duplicate"
+					continue
+				}
+				rep.Rows[i].Status = StatusCreated
+				rep.Rows[i].Id = ids[j]
+			}
+			return
+		}
+	}
+}
+
+func (rep *Report) fail(i int, err error) {
+	rep.Rows[i].Status = StatusFailed
+	rep.Rows[i].Error = err.Error()
+}
+
+// rollback marks valid rows of an aborted atomic import as failed.
+func (rep *Report) rollback(idx []int) {
+	rep.RolledBack = true
+	for _, i := range idx {
+		rep.fail(i, errRolledBack)
+	}
+}
+
+func (rep *Report) tally() {
+	for _, row := range rep.Rows {
+		switch row.Status {
+		case StatusCreated:
+			rep.Created++
+		case StatusSkipped:
+			rep.Skipped++
+		case StatusFailed:
+			rep.Failed++
+		}
+	}
+}
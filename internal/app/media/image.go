@@ -0,0 +1,154 @@
+package media
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/jpeg"
+	_ "image/png"
+	"net/http"
+
+	"filmoteka/internal/app/models"
+)
+
+// DefaultMaxPixels bounds the decoded size of an upload when Save is not
+// given a limit, so a small file cannot expand into a huge bitmap. Each
+// pixel costs about 8 bytes while decoding and scaling.
+const DefaultMaxPixels = 16_000_000
+
+var (
+	ErrUnsupportedType = errors.New("unsupported image type, use jpeg or png")
+	ErrTooManyPixels   = errors.New("image has too many pixels")
+)
+
+// Size is a thumbnail size: images wider than Width are scaled down to
+// it, narrower ones are kept as they are.
+type Size struct {
+	Name  string
+	Width int
+}
+
+// Thumbnails are the sizes generated for every upload, widest first.
+var Thumbnails = []Size{
+	{Name: "large", Width: 500},
+	{Name: "medium", Width: 185},
+	{Name: "small", Width: 92},
+}
+
+// extensions of the accepted image types, sniffed from the content.
+var extensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+}
+
+// Save checks that data is a JPEG or PNG image and stores it along with
+// its thumbnails under a key below prefix derived from the content, so
+// a new upload never overwrites files still cached by clients. Images
+// over maxPixels are refused before being decoded, 0 means
+// DefaultMaxPixels.
+func Save(st Storage, prefix string, data []byte, maxPixels int) (models.Image, error) {
+	if maxPixels <= 0 {
+		maxPixels = DefaultMaxPixels
+	}
+
+	ext, ok := extensions[http.DetectContentType(data)]
+	if !ok {
+		return models.Image{}, ErrUnsupportedType
+	}
+
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return models.Image{}, ErrUnsupportedType
+	}
+	if cfg.Width*cfg.Height > maxPixels {
+		return models.Image{}, ErrTooManyPixels
+	}
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return models.Image{}, ErrUnsupportedType
+	}
+
+	sum := sha256.Sum256(data)
+	img := models.Image{
+		Key:        fmt.Sprintf("%s/%x", prefix, sum[:8]),
+		Thumbnails: make(map[string]string, len(Thumbnails)),
+	}
+
+	original := img.Key + "/original" + ext
+	if err := st.Put(original, data); err != nil {
+		return models.Image{}, err
+	}
+	img.URL = st.URL(original)
+
+	// each size is scaled from the previous, wider one
+	thumb := toRGBA(src)
+	for _, size := range Thumbnails {
+		if thumb.Bounds().Dx() > size.Width {
+			thumb = scale(thumb, size.Width)
+		}
+
+		var buf bytes.Buffer
+		if err := jpeg.Encode(&buf, thumb, &jpeg.Options{Quality: 85}); err != nil {
+			st.Delete(img.Key)
+			return models.Image{}, err
+		}
+		key := fmt.Sprintf("%s/w%d.jpg", img.Key, size.Width)
+		if err := st.Put(key, buf.Bytes()); err != nil {
+			st.Delete(img.Key)
+			return models.Image{}, err
+		}
+		img.Thumbnails[size.Name] = st.URL(key)
+	}
+
+	return img, nil
+}
+
+// toRGBA copies src onto an opaque white canvas, flattening transparency
+// for the JPEG thumbnails.
+func toRGBA(src image.Image) *image.RGBA {
+	b := src.Bounds()
+	dst := image.NewRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(dst, dst.Bounds(), image.White, image.Point{}, draw.Src)
+	draw.Draw(dst, dst.Bounds(), src, b.Min, draw.Over)
+
+	return dst
+}
+
+// scale shrinks src to width, keeping the aspect ratio, by averaging the
+// source pixels each target pixel covers.
+func scale(src *image.RGBA, width int) *image.RGBA {
+	sw, sh := src.Bounds().Dx(), src.Bounds().Dy()
+	height := max(1, sh*width/sw)
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+
+	for y := 0; y < height; y++ {
+		y0, y1 := y*sh/height, max((y+1)*sh/height, y*sh/height+1)
+		for x := 0; x < width; x++ {
+			x0, x1 := x*sw/width, max((x+1)*sw/width, x*sw/width+1)
+
+			var r, g, b, a, n int
+			for sy := y0; sy < y1; sy++ {
+				row := src.Pix[sy*src.Stride:]
+				for sx := x0; sx < x1; sx++ {
+					p := row[sx*4 : sx*4+4]
+					r += int(p[0])
+					g += int(p[1])
+					b += int(p[2])
+					a += int(p[3])
+					n++
+				}
+			}
+
+			p := dst.Pix[y*dst.Stride+x*4:]
+			p[0] = uint8(r / n)
+			p[1] = uint8(g / n)
+			p[2] = uint8(b / n)
+			p[3] = uint8(a / n)
+		}
+	}
+
+	return dst
+}
@@ -0,0 +1,98 @@
+package media
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testPNG(t *testing.T, width, height int) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 200, A: 255})
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+
+	return buf.Bytes()
+}
+
+func TestSave(t *testing.T) {
+	dir := t.TempDir()
+	st := NewLocal(dir, "/media/")
+
+	img, err := Save(st, "films/1/poster", testPNG(t, 600, 900), 0)
+	assert.NoError(t, err)
+	assert.Regexp(t, `^films/1/poster/[0-9a-f]{16}$`, img.Key)
+	assert.Equal(t, "/media/"+img.Key+"/original.png", img.URL)
+	assert.Equal(t, map[string]string{
+		"large":  "/media/" + img.Key + "/w500.jpg",
+		"medium": "/media/" + img.Key + "/w185.jpg",
+		"small":  "/media/" + img.Key + "/w92.jpg",
+	}, img.Thumbnails)
+
+	for width, height := range map[int]int{500: 750, 185: 277, 92: 137} {
+		data, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(img.Key), fmt.Sprintf("w%d.jpg", width)))
+		assert.NoError(t, err)
+		cfg, err := jpeg.DecodeConfig(bytes.NewReader(data))
+		assert.NoError(t, err)
+		assert.Equal(t, width, cfg.Width)
+		assert.Equal(t, height, cfg.Height)
+	}
+}
+
+func TestSave_Small(t *testing.T) {
+	dir := t.TempDir()
+	st := NewLocal(dir, "/media/")
+
+	// narrower images are not scaled up
+	img, err := Save(st, "actors/2/photo", testPNG(t, 120, 160), 0)
+	assert.NoError(t, err)
+
+	data, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(img.Key), "w500.jpg"))
+	assert.NoError(t, err)
+	cfg, err := jpeg.DecodeConfig(bytes.NewReader(data))
+	assert.NoError(t, err)
+	assert.Equal(t, 120, cfg.Width)
+}
+
+func TestSave_Unsupported(t *testing.T) {
+	dir := t.TempDir()
+	st := NewLocal(dir, "/media/")
+
+	_, err := Save(st, "films/1/poster", []byte("GIF89a not really"), 0)
+	assert.ErrorIs(t, err, ErrUnsupportedType)
+
+	// a png signature with a broken body
+	_, err = Save(st, "films/1/poster", append([]byte("\x89PNG\r\n\x1a\n"), make([]byte, 32)...), 0)
+	assert.ErrorIs(t, err, ErrUnsupportedType)
+
+	entries, err := os.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestSave_TooManyPixels(t *testing.T) {
+	dir := t.TempDir()
+	st := NewLocal(dir, "/media/")
+
+	_, err := Save(st, "films/1/poster", testPNG(t, 100, 100), 5000)
+	assert.ErrorIs(t, err, ErrTooManyPixels)
+
+	entries, err := os.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+}
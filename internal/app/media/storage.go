@@ -0,0 +1,113 @@
+package media
+
+import (
+	"errors"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+var errBadKey = errors.New("invalid blob key")
+
+// Storage keeps uploaded files. Keys are slash separated paths.
+type Storage interface {
+	// Put stores data under key, replacing any previous blob.
+	Put(key string, data []byte) error
+	// Delete removes every blob stored under the key prefix.
+	Delete(prefix string) error
+	// URL returns the address the blob is served from.
+	URL(key string) string
+}
+
+// Local stores blobs as files under a directory and serves them itself
+// under a URL path prefix.
+type Local struct {
+	dir    string
+	prefix string
+	files  http.Handler
+}
+
+// NewLocal returns a storage writing to dir and serving files under the
+// URL path prefix, e.g. "/media/".
+func NewLocal(dir, prefix string) *Local {
+	if !strings.HasSuffix(prefix, "/") {
+		prefix += "/"
+	}
+
+	return &Local{
+		dir:    dir,
+		prefix: prefix,
+		files:  http.StripPrefix(prefix, http.FileServer(filesOnly{http.Dir(dir)})),
+	}
+}
+
+// filesOnly hides directories, so the file server answers 404 instead of
+// listing what is stored.
+type filesOnly struct {
+	http.FileSystem
+}
+
+func (fs filesOnly) Open(name string) (http.File, error) {
+	f, err := fs.FileSystem.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	if st, err := f.Stat(); err != nil || st.IsDir() {
+		f.Close()
+		return nil, os.ErrNotExist
+	}
+
+	return f, nil
+}
+
+// Prefix returns the URL path prefix the files are served under.
+func (l *Local) Prefix() string {
+	return l.prefix
+}
+
+func (l *Local) Put(key string, data []byte) error {
+	name, err := l.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		return err
+	}
+
+	// write aside and rename, so readers never see a partial file
+	tmp := name + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, name)
+}
+
+func (l *Local) Delete(prefix string) error {
+	name, err := l.path(prefix)
+	if err != nil {
+		return err
+	}
+
+	return os.RemoveAll(name)
+}
+
+func (l *Local) URL(key string) string {
+	return l.prefix + key
+}
+
+func (l *Local) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	l.files.ServeHTTP(w, r)
+}
+
+// path maps a key to a file below dir, rejecting keys escaping it.
+func (l *Local) path(key string) (string, error) {
+	clean := path.Clean("/" + key)
+	if clean == "/" || clean != "/"+key {
+		return "", errBadKey
+	}
+
+	return filepath.Join(l.dir, filepath.FromSlash(clean)), nil
+}
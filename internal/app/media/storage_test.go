@@ -0,0 +1,43 @@
+package media
+
+import (
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocal_PutDelete(t *testing.T) {
+	dir := t.TempDir()
+	l := NewLocal(dir, "/media")
+
+	assert.NoError(t, l.Put("films/1/poster/ab/original.png", []byte("data")))
+	assert.Equal(t, "/media/films/1/poster/ab/original.png", l.URL("films/1/poster/ab/original.png"))
+
+	w := httptest.NewRecorder()
+	l.ServeHTTP(w, httptest.NewRequest("GET", "/media/films/1/poster/ab/original.png", nil))
+	assert.Equal(t, 200, w.Code)
+	assert.Equal(t, "data", w.Body.String())
+
+	// directories are not listed
+	for _, url := range []string{"/media/", "/media/films/1/poster/ab/", "/media/films/1"} {
+		w = httptest.NewRecorder()
+		l.ServeHTTP(w, httptest.NewRequest("GET", url, nil))
+		assert.Equal(t, 404, w.Code, url)
+	}
+
+	assert.NoError(t, l.Delete("films/1/poster/ab"))
+	_, err := os.Stat(filepath.Join(dir, "films", "1", "poster", "ab"))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestLocal_BadKey(t *testing.T) {
+	l := NewLocal(t.TempDir(), "/media/")
+
+	for _, key := range []string{"", "/abs", "../escape", "a/../../b", "a//b"} {
+		assert.ErrorIs(t, l.Put(key, []byte("data")), errBadKey, key)
+		assert.ErrorIs(t, l.Delete(key), errBadKey, key)
+	}
+}
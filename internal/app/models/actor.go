@@ -2,16 +2,52 @@ package models
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/go-playground/validator/v10"
 )
 
+// Gender values. GenderUnspecified covers records where the gender is
+// unknown or deliberately not stated.
+const (
+	GenderMale        = "male"
+	GenderFemale      = "female"
+	GenderNonBinary   = "non-binary"
+	GenderOther       = "other"
+	GenderUnspecified = "unspecified"
+)
+
+// NormalizeGender maps client input to a gender value. The legacy codes
+// "M" and "F" are still accepted; other input is lower-cased and left
+// for Validate to check.
+func NormalizeGender(s string) string {
+	s = strings.ToLower(strings.TrimSpace(s))
+	switch s {
+	case "m":
+		return GenderMale
+	case "f":
+		return GenderFemale
+	}
+
+	return s
+}
+
 type Actor struct {
 	Id        int    `json:"id"`
 	Name      string `json:"name" validate:"required,min=3,max=100"`
-	Gender    string `json:"gender" validate:"required,len=1"`
+	Gender    string `json:"gender" validate:"required,oneof=male female non-binary other unspecified"`
 	BirthDate string `json:"birth_date" validate:"required"`
+	// DeathDate is empty for living actors.
+	DeathDate  string `json:"death_date,omitempty"`
+	Birthplace string `json:"birthplace,omitempty" validate:"max=150"`
+	Biography  string `json:"biography,omitempty" validate:"max=10000"`
+	// Aliases holds stage and alternative names, searched along with Name.
+	Aliases []string `json:"aliases,omitempty" validate:"dive,required,max=100"`
+	// Photo is set through uploads only.
+	Photo     *Image     `json:"photo,omitempty" validate:"-"`
+	UpdatedAt time.Time  `json:"-"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
 }
 
 func (a *Actor) Validate() error {
@@ -19,12 +55,18 @@ func (a *Actor) Validate() error {
 	if err := validate.Struct(a); err != nil {
 		return err
 	}
-	if _, err := time.Parse("2006-01-02", a.BirthDate); err != nil {
+	born, err := time.Parse("2006-01-02", a.BirthDate)
+	if err != nil {
 		return fmt.Errorf("valid date format 2006-01-02")
 	}
-	if a.Gender != "M" && a.Gender != "F" {
-		return fmt.Errorf("valid gender values: 'M' or 'F'")
+	if a.DeathDate != "" {
+		died, err := time.Parse("2006-01-02", a.DeathDate)
+		if err != nil {
+			return fmt.Errorf("valid date format 2006-01-02")
+		}
+		if !died.After(born) {
+			return fmt.Errorf("death date must be after birth date")
+		}
 	}
-
 	return nil
 }
@@ -41,6 +41,36 @@ func TestActor_Validate(t *testing.T) {
 			},
 			isValid: false,
 		},
+		{
+			name: "non-binary gender",
+			a: func() *models.Actor {
+				a := models.TestActor(t)
+				a.Gender = models.GenderNonBinary
+
+				return a
+			},
+			isValid: true,
+		},
+		{
+			name: "unspecified gender",
+			a: func() *models.Actor {
+				a := models.TestActor(t)
+				a.Gender = models.GenderUnspecified
+
+				return a
+			},
+			isValid: true,
+		},
+		{
+			name: "legacy gender code",
+			a: func() *models.Actor {
+				a := models.TestActor(t)
+				a.Gender = "M"
+
+				return a
+			},
+			isValid: false,
+		},
 		{
 			name: "empty birthdate",
 			a: func() *models.Actor {
@@ -72,6 +102,39 @@ func TestActor_Validate(t *testing.T) {
 			},
 			isValid: false,
 		},
+		{
+			name: "with profile",
+			a: func() *models.Actor {
+				a := models.TestActor(t)
+				a.DeathDate = "2060-03-01"
+				a.Birthplace = "Lyon, France"
+				a.Biography = "Stage and screen actor."
+				a.Aliases = []string{"A. One"}
+
+				return a
+			},
+			isValid: true,
+		},
+		{
+			name: "death before birth",
+			a: func() *models.Actor {
+				a := models.TestActor(t)
+				a.DeathDate = "1990-01-01"
+
+				return a
+			},
+			isValid: false,
+		},
+		{
+			name: "empty alias",
+			a: func() *models.Actor {
+				a := models.TestActor(t)
+				a.Aliases = []string{""}
+
+				return a
+			},
+			isValid: false,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -84,3 +147,17 @@ func TestActor_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestNormalizeGender(t *testing.T) {
+	testCases := map[string]string{
+		"M":           models.GenderMale,
+		"f":           models.GenderFemale,
+		" Non-Binary": models.GenderNonBinary,
+		"unspecified": models.GenderUnspecified,
+		"x":           "x",
+	}
+
+	for in, want := range testCases {
+		assert.Equal(t, want, models.NormalizeGender(in), in)
+	}
+}
@@ -0,0 +1,21 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// AuditEntry records a single change to a film or an actor. Before and
+// After hold the changed fields only, or the whole entity when it was
+// created, deleted or restored.
+type AuditEntry struct {
+	Id        int64           `json:"id"`
+	UserID    *int            `json:"user_id"`
+	RequestID string          `json:"request_id,omitempty"`
+	Entity    string          `json:"entity"`
+	EntityID  int             `json:"entity_id"`
+	Action    string          `json:"action"`
+	Before    json.RawMessage `json:"before"`
+	After     json.RawMessage `json:"after"`
+	CreatedAt time.Time       `json:"created_at"`
+}
@@ -0,0 +1,37 @@
+package models
+
+import "github.com/go-playground/validator/v10"
+
+// Award is a category of a ceremony in a year, e.g. Best Picture at the
+// 1994 Academy Awards.
+type Award struct {
+	Id       int    `json:"id"`
+	Ceremony string `json:"ceremony" validate:"required,max=100"`
+	Year     uint16 `json:"year" validate:"required,gte=1900,lte=2100"`
+	Category string `json:"category" validate:"required,max=100"`
+}
+
+func (a *Award) Validate() error {
+	return validator.New().Struct(a)
+}
+
+// Nomination of a film for an award, and of an actor for their part in
+// it when the award goes to a person. The award, film and actor names
+// are filled on reads only.
+type Nomination struct {
+	Id       int    `json:"id"`
+	AwardID  int    `json:"award_id" validate:"required"`
+	Ceremony string `json:"ceremony"`
+	Year     uint16 `json:"year"`
+	Category string `json:"category"`
+	FilmID   int    `json:"film_id" validate:"required"`
+	Film     string `json:"film"`
+	// ActorID is zero for nominations of the film itself.
+	ActorID int    `json:"actor_id,omitempty" validate:"gte=0"`
+	Actor   string `json:"actor,omitempty"`
+	Won     bool   `json:"won"`
+}
+
+func (n *Nomination) Validate() error {
+	return validator.New().Struct(n)
+}
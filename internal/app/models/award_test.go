@@ -0,0 +1,22 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"filmoteka/internal/app/models"
+)
+
+func TestAward_Validate(t *testing.T) {
+	assert.NoError(t, (&models.Award{Ceremony: "Academy Awards", Year: 1995, Category: "Best Picture"}).Validate())
+	assert.Error(t, (&models.Award{Ceremony: "Academy Awards", Category: "Best Picture"}).Validate())
+	assert.Error(t, (&models.Award{Year: 1995, Category: "Best Picture"}).Validate())
+}
+
+func TestNomination_Validate(t *testing.T) {
+	assert.NoError(t, (&models.Nomination{AwardID: 1, FilmID: 2}).Validate())
+	assert.NoError(t, (&models.Nomination{AwardID: 1, FilmID: 2, ActorID: 3, Won: true}).Validate())
+	assert.Error(t, (&models.Nomination{AwardID: 1}).Validate())
+	assert.Error(t, (&models.Nomination{AwardID: 1, FilmID: 2, ActorID: -1}).Validate())
+}
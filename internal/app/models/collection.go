@@ -0,0 +1,40 @@
+package models
+
+import (
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// Collection visibilities. Private collections are seen by their owner
+// and admins only.
+const (
+	VisibilityPublic  = "public"
+	VisibilityPrivate = "private"
+)
+
+// Collection is an ordered, curated list of films.
+type Collection struct {
+	Id          int       `json:"id"`
+	Title       string    `json:"title" validate:"required,max=200"`
+	Description string    `json:"description,omitempty" validate:"max=2000"`
+	OwnerID     int       `json:"owner_id"`
+	Visibility  string    `json:"visibility" validate:"required,oneof=public private"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+	// Films are the live member films in order, only set when reading a
+	// single collection.
+	Films []CollectionFilm `json:"films,omitempty" validate:"-"`
+}
+
+func (c *Collection) Validate() error {
+	return validator.New().Struct(c)
+}
+
+// CollectionFilm is a film at a 1-based position of a collection.
+type CollectionFilm struct {
+	Position    int    `json:"position"`
+	FilmID      int    `json:"film_id"`
+	Name        string `json:"name"`
+	ReleaseYear uint16 `json:"release_year"`
+}
@@ -0,0 +1,37 @@
+package models
+
+import "github.com/go-playground/validator/v10"
+
+// Crew jobs a person can be credited with on a film.
+const (
+	JobDirector        = "director"
+	JobWriter          = "writer"
+	JobComposer        = "composer"
+	JobCinematographer = "cinematographer"
+	JobProducer        = "producer"
+)
+
+// Credit links a person to a film with a crew job. People are actors: a
+// person working on and off screen keeps a single Actor record.
+type Credit struct {
+	FilmID      int    `json:"film_id" validate:"required"`
+	Film        string `json:"film"`
+	ReleaseYear uint16 `json:"release_year"`
+	PersonID    int    `json:"person_id" validate:"required"`
+	Person      string `json:"person"`
+	Job         string `json:"job" validate:"oneof=director writer composer cinematographer producer"`
+}
+
+// FilmCredit is a crew credit as carried on a film: the person and job.
+type FilmCredit struct {
+	PersonID int    `json:"person_id" validate:"required"`
+	Job      string `json:"job" validate:"oneof=director writer composer cinematographer producer"`
+}
+
+func (c *Credit) Validate() error {
+	validate := validator.New()
+	if err := validate.Struct(c); err != nil {
+		return err
+	}
+	return nil
+}
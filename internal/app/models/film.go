@@ -1,7 +1,18 @@
 package models
 
 import (
-	"github.com/go-playground/validator"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// Age ratings a film can carry, MPAA style.
+const (
+	AgeRatingG    = "G"
+	AgeRatingPG   = "PG"
+	AgeRatingPG13 = "PG-13"
+	AgeRatingR    = "R"
+	AgeRatingNC17 = "NC-17"
 )
 
 type Film struct {
@@ -10,10 +21,32 @@ type Film struct {
 	Description string  `json:"description" validate:"required,min=5,max=500"`
 	ReleaseYear uint16  `json:"release_year" validate:"required,gte=1900,lte=2030"`
 	Rating      float32 `json:"rating" validate:"required,gte=0,lte=10"`
+	// Runtime is the length in minutes, 0 when unknown.
+	Runtime       uint16 `json:"runtime,omitempty" validate:"omitempty,lte=1000"`
+	OriginalTitle string `json:"original_title,omitempty" validate:"omitempty,max=150"`
+	// OriginalLanguage is a lower case ISO 639-1 code.
+	OriginalLanguage string `json:"original_language,omitempty" validate:"omitempty,iso639_1"`
+	// Countries holds upper case ISO 3166-1 alpha-2 codes of the
+	// production countries.
+	Countries []string `json:"countries,omitempty" validate:"dive,iso3166_1_alpha2"`
+	AgeRating string   `json:"age_rating,omitempty" validate:"omitempty,oneof=G PG PG-13 R NC-17"`
+	// Genres holds genre names. A nil slice on update keeps the film's
+	// genres, an empty one clears them.
+	Genres []string `json:"genres,omitempty" validate:"dive,required,max=50"`
+	// Credits is read by Each and written by CreateBatch, so exports and
+	// imports carry the crew; elsewhere it is managed as credits.
+	Credits []FilmCredit `json:"credits,omitempty" validate:"dive"`
+	// UserRating is filled on reads only and never written back.
+	UserRating *RatingSummary `json:"user_rating,omitempty" validate:"-"`
+	// Poster is set through uploads only.
+	Poster    *Image     `json:"poster,omitempty" validate:"-"`
+	UpdatedAt time.Time  `json:"-"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
 }
 
 func (f *Film) Validate() error {
 	validate := validator.New()
+	validate.RegisterValidation("iso639_1", isLanguage)
 	if err := validate.Struct(f); err != nil {
 		return err
 	}
@@ -71,6 +71,50 @@ func TestFilm_Validate(t *testing.T) {
 			},
 			isValid: false,
 		},
+		{
+			name: "with metadata",
+			f: func() *models.Film {
+				f := models.TestFilm(t)
+				f.Runtime = 142
+				f.OriginalTitle = "Le Film"
+				f.OriginalLanguage = "fr"
+				f.Countries = []string{"FR", "BE"}
+				f.AgeRating = models.AgeRatingPG13
+
+				return f
+			},
+			isValid: true,
+		},
+		{
+			name: "unknown language",
+			f: func() *models.Film {
+				f := models.TestFilm(t)
+				f.OriginalLanguage = "xx"
+
+				return f
+			},
+			isValid: false,
+		},
+		{
+			name: "unknown country",
+			f: func() *models.Film {
+				f := models.TestFilm(t)
+				f.Countries = []string{"FR", "XX"}
+
+				return f
+			},
+			isValid: false,
+		},
+		{
+			name: "unknown age rating",
+			f: func() *models.Film {
+				f := models.TestFilm(t)
+				f.AgeRating = "PEGI-99"
+
+				return f
+			},
+			isValid: false,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -0,0 +1,22 @@
+package models
+
+import "github.com/go-playground/validator/v10"
+
+type Genre struct {
+	Id   int    `json:"id"`
+	Name string `json:"name" validate:"required,min=2,max=50"`
+}
+
+func (g *Genre) Validate() error {
+	validate := validator.New()
+	if err := validate.Struct(g); err != nil {
+		return err
+	}
+	return nil
+}
+
+// GenreCount is a genre facet: the number of listed films in the genre.
+type GenreCount struct {
+	Genre string `json:"genre"`
+	Count int    `json:"count"`
+}
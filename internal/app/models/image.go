@@ -0,0 +1,11 @@
+package models
+
+// Image is an uploaded picture: the original and its thumbnails, keyed
+// by size name.
+type Image struct {
+	// Key is the blob storage prefix the files of the image are stored
+	// under.
+	Key        string            `json:"-"`
+	URL        string            `json:"url"`
+	Thumbnails map[string]string `json:"thumbnails"`
+}
@@ -0,0 +1,41 @@
+package models
+
+import (
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// UserRating is the score a user gave a film.
+type UserRating struct {
+	FilmID    int       `json:"film_id"`
+	UserID    int       `json:"-"`
+	Rating    int       `json:"rating" validate:"required,gte=1,lte=10"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (r *UserRating) Validate() error {
+	return validator.New().Struct(r)
+}
+
+// RatingSummary aggregates the user ratings of a film. Score is the
+// rating shown for the film: the mean of the user ratings, pulled
+// towards the editor rating while there are few of them.
+type RatingSummary struct {
+	Count   int     `json:"count"`
+	Average float32 `json:"average"`
+	Score   float32 `json:"score"`
+}
+
+// Summarize builds the summary of count ratings adding up to sum. The
+// editor rating weighs as much as weight user ratings in the score; with
+// a zero weight the score is the plain mean once the film has ratings.
+func Summarize(editor float32, count, sum, weight int) RatingSummary {
+	s := RatingSummary{Count: count, Score: editor}
+	if count > 0 {
+		s.Average = float32(sum) / float32(count)
+		s.Score = (editor*float32(weight) + float32(sum)) / float32(weight+count)
+	}
+
+	return s
+}
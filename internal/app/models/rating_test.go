@@ -0,0 +1,18 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"filmoteka/internal/app/models"
+)
+
+func TestSummarize(t *testing.T) {
+	// no ratings yet: the editor rating stands
+	assert.Equal(t, models.RatingSummary{Score: 7}, models.Summarize(7, 0, 0, 10))
+	// plain mean without a prior weight
+	assert.Equal(t, models.RatingSummary{Count: 2, Average: 9, Score: 9}, models.Summarize(7, 2, 18, 0))
+	// (3*7 + 18) / (3 + 2)
+	assert.Equal(t, models.RatingSummary{Count: 2, Average: 9, Score: 7.8}, models.Summarize(7, 2, 18, 3))
+}
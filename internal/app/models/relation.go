@@ -0,0 +1,70 @@
+package models
+
+import "github.com/go-playground/validator/v10"
+
+// Film relation types, read as "the related film is the <type> of the
+// film". Prequels and originals are stored as the sequels and remakes
+// they are the inverse of.
+const (
+	RelationSequel    = "sequel"
+	RelationPrequel   = "prequel"
+	RelationRemake    = "remake"
+	RelationOriginal  = "original"
+	RelationFranchise = "franchise"
+)
+
+// FilmRelation relates a film to another one.
+type FilmRelation struct {
+	FilmID    int    `json:"film_id" validate:"required"`
+	RelatedID int    `json:"related_id" validate:"required,nefield=FilmID"`
+	Type      string `json:"type" validate:"oneof=sequel prequel remake original franchise"`
+}
+
+func (r *FilmRelation) Validate() error {
+	return validator.New().Struct(r)
+}
+
+// Canonical returns the relation in the form it is stored in: prequels
+// and originals flipped into sequels and remakes, and franchise links,
+// which go both ways, from the lower film id.
+func (r FilmRelation) Canonical() FilmRelation {
+	switch r.Type {
+	case RelationPrequel:
+		return FilmRelation{FilmID: r.RelatedID, RelatedID: r.FilmID, Type: RelationSequel}
+	case RelationOriginal:
+		return FilmRelation{FilmID: r.RelatedID, RelatedID: r.FilmID, Type: RelationRemake}
+	case RelationFranchise:
+		if r.RelatedID < r.FilmID {
+			return FilmRelation{FilmID: r.RelatedID, RelatedID: r.FilmID, Type: r.Type}
+		}
+	}
+
+	return r
+}
+
+// InverseRelation returns the type a relation has when read from the
+// related film.
+func InverseRelation(t string) string {
+	switch t {
+	case RelationSequel:
+		return RelationPrequel
+	case RelationPrequel:
+		return RelationSequel
+	case RelationRemake:
+		return RelationOriginal
+	case RelationOriginal:
+		return RelationRemake
+	}
+
+	return t
+}
+
+// RelatedFilm is a film listed among the relations of another one.
+type RelatedFilm struct {
+	Id          int    `json:"id"`
+	Name        string `json:"name"`
+	ReleaseYear uint16 `json:"release_year"`
+}
+
+// RelatedFilms groups the related films of a film by relation type.
+type RelatedFilms map[string][]RelatedFilm
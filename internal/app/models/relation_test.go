@@ -0,0 +1,50 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"filmoteka/internal/app/models"
+)
+
+func TestFilmRelation_Canonical(t *testing.T) {
+	tests := []struct {
+		name  string
+		input models.FilmRelation
+		want  models.FilmRelation
+	}{
+		{
+			name:  "Sequel",
+			input: models.FilmRelation{FilmID: 1, RelatedID: 2, Type: "sequel"},
+			want:  models.FilmRelation{FilmID: 1, RelatedID: 2, Type: "sequel"},
+		},
+		{
+			name:  "Prequel",
+			input: models.FilmRelation{FilmID: 2, RelatedID: 1, Type: "prequel"},
+			want:  models.FilmRelation{FilmID: 1, RelatedID: 2, Type: "sequel"},
+		},
+		{
+			name:  "Original",
+			input: models.FilmRelation{FilmID: 5, RelatedID: 3, Type: "original"},
+			want:  models.FilmRelation{FilmID: 3, RelatedID: 5, Type: "remake"},
+		},
+		{
+			name:  "Franchise",
+			input: models.FilmRelation{FilmID: 4, RelatedID: 2, Type: "franchise"},
+			want:  models.FilmRelation{FilmID: 2, RelatedID: 4, Type: "franchise"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.input.Canonical())
+		})
+	}
+}
+
+func TestFilmRelation_Validate(t *testing.T) {
+	assert.NoError(t, (&models.FilmRelation{FilmID: 1, RelatedID: 2, Type: "remake"}).Validate())
+	assert.Error(t, (&models.FilmRelation{FilmID: 1, RelatedID: 1, Type: "sequel"}).Validate())
+	assert.Error(t, (&models.FilmRelation{FilmID: 1, RelatedID: 2, Type: "spin-off"}).Validate())
+}
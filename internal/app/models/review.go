@@ -0,0 +1,40 @@
+package models
+
+import (
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// Review moderation states. New and edited reviews wait for a moderator
+// and only approved ones are listed publicly.
+const (
+	ReviewPending  = "pending"
+	ReviewApproved = "approved"
+	ReviewRejected = "rejected"
+)
+
+type Review struct {
+	Id        int       `json:"id"`
+	FilmID    int       `json:"film_id"`
+	UserID    int       `json:"user_id"`
+	Title     string    `json:"title,omitempty" validate:"max=150"`
+	Body      string    `json:"body" validate:"required,min=10,max=5000"`
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (r *Review) Validate() error {
+	return validator.New().Struct(r)
+}
+
+// ValidReviewStatus reports whether s is a moderation state.
+func ValidReviewStatus(s string) bool {
+	switch s {
+	case ReviewPending, ReviewApproved, ReviewRejected:
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+// FilmRevision is a snapshot of a film taken after each change. Revisions
+// of a film are numbered from 1.
+type FilmRevision struct {
+	FilmID    int       `json:"film_id"`
+	Number    int       `json:"revision"`
+	Film      Film      `json:"film"`
+	UserID    *int      `json:"user_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -20,7 +20,7 @@ func TestActor(t *testing.T) *Actor {
 
 	return &Actor{
 		Name:      "Actor One",
-		Gender:    "M",
+		Gender:    GenderMale,
 		BirthDate: "1995-02-07",
 	}
 }
@@ -0,0 +1,60 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// FilmTranslation holds a film's name and description in a locale: a
+// lower case ISO 639-1 language code, optionally followed by an upper
+// case ISO 3166-1 alpha-2 region, e.g. "pt" or "pt-BR".
+type FilmTranslation struct {
+	Locale string `json:"locale" validate:"locale"`
+	Name   string `json:"name" validate:"required,min=2,max=150"`
+	// Description is left untranslated when empty.
+	Description string    `json:"description,omitempty" validate:"omitempty,min=5,max=500"`
+	UpdatedAt   time.Time `json:"-"`
+}
+
+func (t *FilmTranslation) Validate() error {
+	validate := validator.New()
+	validate.RegisterValidation("locale", isLocale)
+	return validate.Struct(t)
+}
+
+// Localize replaces the film's name and description with the
+// translation. The film counts as modified when the translation was.
+func (f *Film) Localize(t FilmTranslation) {
+	f.Name = t.Name
+	if t.Description != "" {
+		f.Description = t.Description
+	}
+	if t.UpdatedAt.After(f.UpdatedAt) {
+		f.UpdatedAt = t.UpdatedAt
+	}
+}
+
+// NormalizeLocale brings a locale to the case translations are stored
+// in, e.g. "PT-br" to "pt-BR".
+func NormalizeLocale(locale string) string {
+	language, region, found := strings.Cut(locale, "-")
+	if !found {
+		return strings.ToLower(language)
+	}
+
+	return strings.ToLower(language) + "-" + strings.ToUpper(region)
+}
+
+func isLocale(fl validator.FieldLevel) bool {
+	language, region, found := strings.Cut(fl.Field().String(), "-")
+	if !languages[language] {
+		return false
+	}
+	if !found {
+		return true
+	}
+
+	return len(region) == 2 && strings.IndexFunc(region, func(r rune) bool { return r < 'A' || r > 'Z' }) < 0
+}
@@ -0,0 +1,73 @@
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"filmoteka/internal/app/models"
+)
+
+func TestFilmTranslation_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   models.FilmTranslation
+		wantErr bool
+	}{
+		{
+			name:  "Language",
+			input: models.FilmTranslation{Locale: "de", Name: "Die Hard"},
+		},
+		{
+			name:  "Language and region",
+			input: models.FilmTranslation{Locale: "pt-BR", Name: "Duro de Matar", Description: "Um policial enfrenta terroristas."},
+		},
+		{
+			name:    "Unknown language",
+			input:   models.FilmTranslation{Locale: "xx", Name: "Die Hard"},
+			wantErr: true,
+		},
+		{
+			name:    "Lower case region",
+			input:   models.FilmTranslation{Locale: "pt-br", Name: "Duro de Matar"},
+			wantErr: true,
+		},
+		{
+			name:    "Script subtag",
+			input:   models.FilmTranslation{Locale: "zh-Hant", Name: "終極警探"},
+			wantErr: true,
+		},
+		{
+			name:    "No name",
+			input:   models.FilmTranslation{Locale: "de"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.input.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestNormalizeLocale(t *testing.T) {
+	assert.Equal(t, "de", models.NormalizeLocale("DE"))
+	assert.Equal(t, "pt-BR", models.NormalizeLocale("PT-br"))
+}
+
+func TestFilm_Localize(t *testing.T) {
+	updated := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	f := models.Film{Name: "Die Hard", Description: "A cop fights terrorists.", UpdatedAt: updated}
+
+	f.Localize(models.FilmTranslation{Locale: "de", Name: "Stirb langsam", UpdatedAt: updated.Add(time.Hour)})
+	assert.Equal(t, "Stirb langsam", f.Name)
+	assert.Equal(t, "A cop fights terrorists.", f.Description)
+	assert.Equal(t, updated.Add(time.Hour), f.UpdatedAt)
+}
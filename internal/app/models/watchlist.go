@@ -0,0 +1,30 @@
+package models
+
+import (
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// WatchlistItem is a film on a user's watchlist.
+type WatchlistItem struct {
+	Film
+	AddedAt time.Time `json:"added_at"`
+}
+
+// Watch records a user watching a film on a day. A film may be watched
+// any number of times.
+type Watch struct {
+	Id          int    `json:"id"`
+	UserID      int    `json:"-"`
+	FilmID      int    `json:"film_id" validate:"required"`
+	Film        string `json:"film,omitempty"`
+	ReleaseYear uint16 `json:"release_year,omitempty"`
+	// WatchedOn is the day of the watch in the 2006-01-02 format.
+	WatchedOn string    `json:"watched_on" validate:"required,datetime=2006-01-02"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (w *Watch) Validate() error {
+	return validator.New().Struct(w)
+}
@@ -0,0 +1,20 @@
+package store
+
+// Audited entities and actions.
+const (
+	EntityFilm  = "film"
+	EntityActor = "actor"
+
+	ActionCreate  = "create"
+	ActionUpdate  = "update"
+	ActionDelete  = "delete"
+	ActionRestore = "restore"
+	ActionPurge   = "purge"
+)
+
+// Principal is recorded as the author of changes. A zero UserID marks
+// changes made outside a user request, e.g. from the command line.
+type Principal struct {
+	UserID    int
+	RequestID string
+}
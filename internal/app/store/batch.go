@@ -0,0 +1,18 @@
+package store
+
+import "fmt"
+
+// BatchError reports the row that aborted a batch write. Rows before it
+// were rolled back together with it.
+type BatchError struct {
+	Row int
+	Err error
+}
+
+func (e *BatchError) Error() string {
+	return fmt.Sprintf("row %d: %s", e.Row, e.Err)
+}
+
+func (e *BatchError) Unwrap() error {
+	return e.Err
+}
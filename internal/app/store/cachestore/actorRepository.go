@@ -0,0 +1,87 @@
+package cachestore
+
+import (
+	"filmoteka/internal/app/models"
+	"filmoteka/internal/app/store"
+	"time"
+)
+
+// ActorRepository caches Find results of the wrapped repository.
+type ActorRepository struct {
+	next  store.IActorRepository
+	cache *lru[models.Actor]
+	// fill serves misses from the primary database, a lagging replica
+	// would put back entries that a write just dropped
+	fill store.IActorRepository
+	// bypass skips cache lookups but still refreshes entries
+	bypass bool
+}
+
+func (r *ActorRepository) Create(a models.Actor) (int, error) {
+	id, err := r.next.Create(a)
+	if err == nil {
+		r.cache.remove(id)
+	}
+
+	return id, err
+}
+
+func (r *ActorRepository) CreateBatch(items []models.Actor) ([]int, error) {
+	ids, err := r.next.CreateBatch(items)
+	for _, id := range ids {
+		r.cache.remove(id)
+	}
+
+	return ids, err
+}
+
+func (r *ActorRepository) Find(id int) (models.Actor, error) {
+	if !r.bypass {
+		if a, ok := r.cache.get(id); ok {
+			return a, nil
+		}
+	}
+
+	return r.cache.load(id, func() (models.Actor, error) {
+		return r.fill.Find(id)
+	})
+}
+
+func (r *ActorRepository) FindAll() ([]models.Actor, error) {
+	return r.next.FindAll()
+}
+
+func (r *ActorRepository) Each(fn func(models.Actor) error) error {
+	return r.next.Each(fn)
+}
+
+func (r *ActorRepository) Delete(id int) error {
+	defer r.cache.remove(id)
+
+	return r.next.Delete(id)
+}
+
+func (r *ActorRepository) Update(a models.Actor) error {
+	defer r.cache.remove(a.Id)
+
+	return r.next.Update(a)
+}
+
+func (r *ActorRepository) FindBy(filter store.ActorFilter) ([]models.Actor, error) {
+	return r.next.FindBy(filter)
+}
+
+func (r *ActorRepository) Restore(id int) error {
+	defer r.cache.remove(id)
+
+	return r.next.Restore(id)
+}
+
+func (r *ActorRepository) Purge(before time.Time) ([]int, error) {
+	ids, err := r.next.Purge(before)
+	for _, id := range ids {
+		r.cache.remove(id)
+	}
+
+	return ids, err
+}
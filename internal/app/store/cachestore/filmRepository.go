@@ -0,0 +1,114 @@
+package cachestore
+
+import (
+	"filmoteka/internal/app/models"
+	"filmoteka/internal/app/store"
+	"time"
+)
+
+// FilmRepository caches Find results of the wrapped repository.
+type FilmRepository struct {
+	next  store.IFilmRepository
+	cache *lru[models.Film]
+	// fill serves misses from the primary database, a lagging replica
+	// would put back entries that a write just dropped
+	fill store.IFilmRepository
+	// bypass skips cache lookups but still refreshes entries
+	bypass bool
+}
+
+func (r *FilmRepository) Create(f models.Film) (int, error) {
+	id, err := r.next.Create(f)
+	if err == nil {
+		r.cache.remove(id)
+	}
+
+	return id, err
+}
+
+func (r *FilmRepository) CreateBatch(items []models.Film) ([]int, error) {
+	ids, err := r.next.CreateBatch(items)
+	for _, id := range ids {
+		r.cache.remove(id)
+	}
+
+	return ids, err
+}
+
+func (r *FilmRepository) Find(id int) (models.Film, error) {
+	if !r.bypass {
+		if f, ok := r.cache.get(id); ok {
+			return f, nil
+		}
+	}
+
+	return r.cache.load(id, func() (models.Film, error) {
+		return r.fill.Find(id)
+	})
+}
+
+func (r *FilmRepository) FindAll() ([]models.Film, error) {
+	return r.next.FindAll()
+}
+
+func (r *FilmRepository) Each(fn func(models.Film) error) error {
+	return r.next.Each(fn)
+}
+
+func (r *FilmRepository) Delete(id int) error {
+	defer r.cache.remove(id)
+
+	return r.next.Delete(id)
+}
+
+func (r *FilmRepository) Update(f models.Film) error {
+	defer r.cache.remove(f.Id)
+
+	return r.next.Update(f)
+}
+
+func (r *FilmRepository) Upsert(f models.Film) (int, bool, error) {
+	id, created, err := r.next.Upsert(f)
+	if err == nil {
+		r.cache.remove(id)
+	}
+
+	return id, created, err
+}
+
+func (r *FilmRepository) FindBy(filter store.FilmFilter) ([]models.Film, error) {
+	return r.next.FindBy(filter)
+}
+
+func (r *FilmRepository) Restore(id int) error {
+	defer r.cache.remove(id)
+
+	return r.next.Restore(id)
+}
+
+func (r *FilmRepository) Purge(before time.Time) ([]int, error) {
+	ids, err := r.next.Purge(before)
+	for _, id := range ids {
+		r.cache.remove(id)
+	}
+
+	return ids, err
+}
+
+func (r *FilmRepository) Revisions(id int) ([]models.FilmRevision, error) {
+	return r.next.Revisions(id)
+}
+
+func (r *FilmRepository) Revision(id, n int) (models.FilmRevision, error) {
+	return r.next.Revision(id, n)
+}
+
+func (r *FilmRepository) Revert(id, n int) (models.Film, error) {
+	defer r.cache.remove(id)
+
+	return r.next.Revert(id, n)
+}
+
+func (r *FilmRepository) GenreFacets(filter store.FilmFilter) ([]models.GenreCount, error) {
+	return r.next.GenreFacets(filter)
+}
@@ -0,0 +1,148 @@
+package cachestore
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"filmoteka/internal/app/models"
+	"filmoteka/internal/app/store"
+	"filmoteka/internal/app/store/mock_store"
+)
+
+// replicatedStore reads from replicas unless asked for its primary view.
+type replicatedStore struct {
+	*mock_store.MockStore
+	primary store.IStore
+}
+
+func (s *replicatedStore) Primary() store.IStore {
+	return s.primary
+}
+
+func TestFilm_FindCached(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	testFilm := models.Film{
+		Id:          1,
+		Name:        "Test Name",
+		Description: "Desc1",
+		ReleaseYear: 2002,
+		Rating:      7.5,
+	}
+
+	filmRepo := mock_store.NewMockIFilmRepository(c)
+	actorRepo := mock_store.NewMockIActorRepository(c)
+	s := New(mock_store.New(filmRepo, actorRepo), 10, time.Minute)
+
+	// the second Find is served from cache
+	filmRepo.EXPECT().Find(1).Return(testFilm, nil).Times(1)
+	for i := 0; i < 2; i++ {
+		got, err := s.FilmRepo().Find(1)
+		assert.NoError(t, err)
+		assert.Equal(t, testFilm, got)
+	}
+
+	// Update invalidates the entry
+	filmRepo.EXPECT().Update(testFilm).Return(nil)
+	assert.NoError(t, s.FilmRepo().Update(testFilm))
+	filmRepo.EXPECT().Find(1).Return(testFilm, nil).Times(1)
+	_, err := s.FilmRepo().Find(1)
+	assert.NoError(t, err)
+
+	// Delete invalidates the entry
+	filmRepo.EXPECT().Delete(1).Return(nil)
+	assert.NoError(t, s.FilmRepo().Delete(1))
+	filmRepo.EXPECT().Find(1).Return(models.Film{}, errNotFound)
+	_, err = s.FilmRepo().Find(1)
+	assert.ErrorIs(t, err, errNotFound)
+
+	stats := s.Stats()["films"]
+	assert.Equal(t, uint64(1), stats.Hits)
+	assert.Equal(t, uint64(3), stats.Misses)
+}
+
+func TestFilm_FindFillsFromPrimary(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	testFilm := models.Film{Id: 1, Name: "Test Name"}
+
+	replicaRepo := mock_store.NewMockIFilmRepository(c)
+	primaryRepo := mock_store.NewMockIFilmRepository(c)
+	s := New(&replicatedStore{
+		MockStore: mock_store.New(replicaRepo, nil),
+		primary:   mock_store.New(primaryRepo, nil),
+	}, 10, time.Minute)
+
+	// a miss never reads the replica, which may still hold the row a
+	// write just invalidated
+	primaryRepo.EXPECT().Find(1).Return(testFilm, nil).Times(1)
+	for i := 0; i < 2; i++ {
+		got, err := s.FilmRepo().Find(1)
+		assert.NoError(t, err)
+		assert.Equal(t, testFilm, got)
+	}
+
+	// uncached reads still go to the replicas
+	replicaRepo.EXPECT().FindAll().Return([]models.Film{testFilm}, nil)
+	_, err := s.FilmRepo().FindAll()
+	assert.NoError(t, err)
+}
+
+func TestGenre_UpdateDropsFilms(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	testFilm := models.Film{Id: 1, Name: "Test Name", Genres: []string{"Drama"}}
+
+	filmRepo := mock_store.NewMockIFilmRepository(c)
+	genreRepo := mock_store.NewMockIGenreRepository(c)
+	s := New(mock_store.New(filmRepo, nil).WithGenreRepo(genreRepo), 10, time.Minute)
+
+	filmRepo.EXPECT().Find(1).Return(testFilm, nil).Times(2)
+	_, err := s.FilmRepo().Find(1)
+	assert.NoError(t, err)
+
+	// renaming a genre changes the cached films carrying it
+	genreRepo.EXPECT().Update(models.Genre{Id: 1, Name: "Melodrama"}).Return(nil)
+	assert.NoError(t, s.GenreRepo().Update(models.Genre{Id: 1, Name: "Melodrama"}))
+	_, err = s.FilmRepo().Find(1)
+	assert.NoError(t, err)
+}
+
+func TestRating_RateDropsFilm(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	testFilm := models.Film{Id: 1, Name: "Test Name", UserRating: &models.RatingSummary{Score: 7}}
+
+	filmRepo := mock_store.NewMockIFilmRepository(c)
+	ratingRepo := mock_store.NewMockIRatingRepository(c)
+	s := New(mock_store.New(filmRepo, nil).WithRatingRepo(ratingRepo), 10, time.Minute)
+
+	filmRepo.EXPECT().Find(1).Return(testFilm, nil).Times(3)
+	_, err := s.FilmRepo().Find(1)
+	assert.NoError(t, err)
+
+	// a new rating changes the summary of the cached film
+	rating := models.UserRating{FilmID: 1, UserID: 2, Rating: 9}
+	ratingRepo.EXPECT().Rate(rating).Return(models.RatingSummary{Count: 1, Average: 9, Score: 9}, nil)
+	_, err = s.RatingRepo().Rate(rating)
+	assert.NoError(t, err)
+	_, err = s.FilmRepo().Find(1)
+	assert.NoError(t, err)
+
+	// so does removing one
+	ratingRepo.EXPECT().Remove(1, 2).Return(models.RatingSummary{}, nil)
+	_, err = s.RatingRepo().Remove(1, 2)
+	assert.NoError(t, err)
+	_, err = s.FilmRepo().Find(1)
+	assert.NoError(t, err)
+}
+
+var errNotFound = errors.New("resource not found")
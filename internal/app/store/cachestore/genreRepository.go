@@ -0,0 +1,37 @@
+package cachestore
+
+import (
+	"filmoteka/internal/app/models"
+	"filmoteka/internal/app/store"
+)
+
+// GenreRepository passes calls through to the wrapped repository and
+// drops cached films when a genre they may carry is renamed or deleted.
+type GenreRepository struct {
+	next  store.IGenreRepository
+	films *lru[models.Film]
+}
+
+func (r *GenreRepository) Create(g models.Genre) (int, error) {
+	return r.next.Create(g)
+}
+
+func (r *GenreRepository) Find(id int) (models.Genre, error) {
+	return r.next.Find(id)
+}
+
+func (r *GenreRepository) FindAll() ([]models.Genre, error) {
+	return r.next.FindAll()
+}
+
+func (r *GenreRepository) Update(g models.Genre) error {
+	defer r.films.clear()
+
+	return r.next.Update(g)
+}
+
+func (r *GenreRepository) Delete(id int) error {
+	defer r.films.clear()
+
+	return r.next.Delete(id)
+}
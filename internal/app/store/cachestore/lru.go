@@ -0,0 +1,174 @@
+package cachestore
+
+import (
+	"container/list"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Stats holds cache counters.
+type Stats struct {
+	Hits      uint64 `json:"hits"`
+	Misses    uint64 `json:"misses"`
+	Evictions uint64 `json:"evictions"`
+	Size      int    `json:"size"`
+}
+
+type entry[V any] struct {
+	key       int
+	value     V
+	expiresAt time.Time
+}
+
+// fill tracks the loads of a key in flight. gen moves on every removal
+// of the key, so a load can tell whether the value it read went stale.
+type fill struct {
+	gen     uint64
+	readers int
+}
+
+// lru is a fixed size least recently used cache with per entry TTL.
+type lru[V any] struct {
+	mu    sync.Mutex
+	size  int
+	ttl   time.Duration
+	ll    *list.List
+	items map[int]*list.Element
+	fills map[int]*fill
+	now   func() time.Time
+
+	hits      atomic.Uint64
+	misses    atomic.Uint64
+	evictions atomic.Uint64
+}
+
+func newLRU[V any](size int, ttl time.Duration) *lru[V] {
+	return &lru[V]{
+		size:  size,
+		ttl:   ttl,
+		ll:    list.New(),
+		items: make(map[int]*list.Element),
+		fills: make(map[int]*fill),
+		now:   time.Now,
+	}
+}
+
+func (c *lru[V]) get(key int) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.items[key]; ok {
+		e := el.Value.(*entry[V])
+		if c.ttl <= 0 || c.now().Before(e.expiresAt) {
+			c.ll.MoveToFront(el)
+			c.hits.Add(1)
+			return e.value, true
+		}
+		c.removeElement(el)
+	}
+
+	c.misses.Add(1)
+	var zero V
+	return zero, false
+}
+
+func (c *lru[V]) set(key int, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.setLocked(key, value)
+}
+
+// load reads the value of key with fetch and caches it, unless the key
+// was removed while fetch ran: the read may have raced a write, and
+// caching it would put back the value the write replaced.
+func (c *lru[V]) load(key int, fetch func() (V, error)) (V, error) {
+	c.mu.Lock()
+	f := c.fills[key]
+	if f == nil {
+		f = &fill{}
+		c.fills[key] = f
+	}
+	f.readers++
+	gen := f.gen
+	c.mu.Unlock()
+
+	var (
+		value V
+		err   error
+	)
+	defer func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+
+		if f.readers--; f.readers == 0 {
+			delete(c.fills, key)
+		}
+		if err == nil && f.gen == gen {
+			c.setLocked(key, value)
+		}
+	}()
+
+	value, err = fetch()
+	return value, err
+}
+
+func (c *lru[V]) setLocked(key int, value V) {
+	expiresAt := c.now().Add(c.ttl)
+	if el, ok := c.items[key]; ok {
+		e := el.Value.(*entry[V])
+		e.value = value
+		e.expiresAt = expiresAt
+		c.ll.MoveToFront(el)
+		return
+	}
+
+	c.items[key] = c.ll.PushFront(&entry[V]{key: key, value: value, expiresAt: expiresAt})
+	if c.size > 0 && c.ll.Len() > c.size {
+		c.removeElement(c.ll.Back())
+		c.evictions.Add(1)
+	}
+}
+
+func (c *lru[V]) remove(key int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.items[key]; ok {
+		c.removeElement(el)
+	}
+	if f, ok := c.fills[key]; ok {
+		f.gen++
+	}
+}
+
+// clear drops all entries.
+func (c *lru[V]) clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.ll.Init()
+	c.items = make(map[int]*list.Element)
+	for _, f := range c.fills {
+		f.gen++
+	}
+}
+
+func (c *lru[V]) removeElement(el *list.Element) {
+	c.ll.Remove(el)
+	delete(c.items, el.Value.(*entry[V]).key)
+}
+
+func (c *lru[V]) stats() Stats {
+	c.mu.Lock()
+	size := c.ll.Len()
+	c.mu.Unlock()
+
+	return Stats{
+		Hits:      c.hits.Load(),
+		Misses:    c.misses.Load(),
+		Evictions: c.evictions.Load(),
+		Size:      size,
+	}
+}
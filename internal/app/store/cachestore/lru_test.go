@@ -0,0 +1,66 @@
+package cachestore
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLRU_Eviction(t *testing.T) {
+	c := newLRU[string](2, 0)
+
+	c.set(1, "one")
+	c.set(2, "two")
+	_, _ = c.get(1) // 1 is now most recently used
+	c.set(3, "three")
+
+	_, ok := c.get(2)
+	assert.False(t, ok)
+	v, ok := c.get(1)
+	assert.True(t, ok)
+	assert.Equal(t, "one", v)
+
+	stats := c.stats()
+	assert.Equal(t, uint64(2), stats.Hits)
+	assert.Equal(t, uint64(1), stats.Misses)
+	assert.Equal(t, uint64(1), stats.Evictions)
+	assert.Equal(t, 2, stats.Size)
+}
+
+func TestLRU_TTL(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	c := newLRU[string](10, time.Minute)
+	c.now = func() time.Time { return now }
+
+	c.set(1, "one")
+	_, ok := c.get(1)
+	assert.True(t, ok)
+
+	now = now.Add(2 * time.Minute)
+	_, ok = c.get(1)
+	assert.False(t, ok)
+	assert.Equal(t, 0, c.stats().Size)
+}
+
+func TestLRU_LoadRacingRemove(t *testing.T) {
+	c := newLRU[string](10, 0)
+
+	// a write removing the key while the old value is read keeps it out
+	v, err := c.load(1, func() (string, error) {
+		c.remove(1)
+		return "old", nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "old", v)
+	_, ok := c.get(1)
+	assert.False(t, ok)
+
+	// a later load caches again
+	_, err = c.load(1, func() (string, error) { return "new", nil })
+	assert.NoError(t, err)
+	v, ok = c.get(1)
+	assert.True(t, ok)
+	assert.Equal(t, "new", v)
+	assert.Empty(t, c.fills)
+}
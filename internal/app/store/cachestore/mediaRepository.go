@@ -0,0 +1,26 @@
+package cachestore
+
+import (
+	"filmoteka/internal/app/models"
+	"filmoteka/internal/app/store"
+)
+
+// MediaRepository passes calls through to the wrapped repository and
+// drops the cached film or actor whose picture changes.
+type MediaRepository struct {
+	next   store.IMediaRepository
+	films  *lru[models.Film]
+	actors *lru[models.Actor]
+}
+
+func (r *MediaRepository) SetPoster(filmID int, img models.Image) (*models.Image, error) {
+	defer r.films.remove(filmID)
+
+	return r.next.SetPoster(filmID, img)
+}
+
+func (r *MediaRepository) SetPhoto(actorID int, img models.Image) (*models.Image, error) {
+	defer r.actors.remove(actorID)
+
+	return r.next.SetPhoto(actorID, img)
+}
@@ -0,0 +1,29 @@
+package cachestore
+
+import (
+	"filmoteka/internal/app/models"
+	"filmoteka/internal/app/store"
+)
+
+// RatingRepository passes calls through to the wrapped repository and
+// drops the cached film whose rating summary a change affects.
+type RatingRepository struct {
+	next  store.IRatingRepository
+	films *lru[models.Film]
+}
+
+func (r *RatingRepository) Rate(ur models.UserRating) (models.RatingSummary, error) {
+	defer r.films.remove(ur.FilmID)
+
+	return r.next.Rate(ur)
+}
+
+func (r *RatingRepository) Find(filmID, userID int) (models.UserRating, error) {
+	return r.next.Find(filmID, userID)
+}
+
+func (r *RatingRepository) Remove(filmID, userID int) (models.RatingSummary, error) {
+	defer r.films.remove(filmID)
+
+	return r.next.Remove(filmID, userID)
+}
@@ -0,0 +1,181 @@
+package cachestore
+
+import (
+	"time"
+
+	"filmoteka/internal/app/models"
+	"filmoteka/internal/app/store"
+)
+
+// Store decorates another store with in-process read-through caches.
+type Store struct {
+	next            store.IStore
+	filmRepository  *FilmRepository
+	actorRepository *ActorRepository
+}
+
+// New wraps next with LRU caches holding up to size entries per
+// repository, each entry living at most ttl (0 disables expiry). Misses
+// are read from the primary database when next has replicas.
+func New(next store.IStore, size int, ttl time.Duration) *Store {
+	primary := primaryOf(next)
+
+	return &Store{
+		next: next,
+		filmRepository: &FilmRepository{
+			next:  next.FilmRepo(),
+			cache: newLRU[models.Film](size, ttl),
+			fill:  primary.FilmRepo(),
+		},
+		actorRepository: &ActorRepository{
+			next:  next.ActorRepo(),
+			cache: newLRU[models.Actor](size, ttl),
+			fill:  primary.ActorRepo(),
+		},
+	}
+}
+
+// primaryOf returns the primary view of s, or s itself when it has no
+// replicas.
+func primaryOf(s store.IStore) store.IStore {
+	if ps, ok := s.(store.IPrimaryStore); ok {
+		return ps.Primary()
+	}
+
+	return s
+}
+
+func (s *Store) FilmRepo() store.IFilmRepository {
+	return s.filmRepository
+}
+
+func (s *Store) ActorRepo() store.IActorRepository {
+	return s.actorRepository
+}
+
+func (s *Store) GenreRepo() store.IGenreRepository {
+	return &GenreRepository{
+		next:  s.next.GenreRepo(),
+		films: s.filmRepository.cache,
+	}
+}
+
+// CreditRepo is not cached, cached films and actors do not carry credits.
+func (s *Store) CreditRepo() store.ICreditRepository {
+	return s.next.CreditRepo()
+}
+
+func (s *Store) RatingRepo() store.IRatingRepository {
+	return &RatingRepository{
+		next:  s.next.RatingRepo(),
+		films: s.filmRepository.cache,
+	}
+}
+
+// ReviewRepo is not cached, cached films do not carry reviews.
+func (s *Store) ReviewRepo() store.IReviewRepository {
+	return s.next.ReviewRepo()
+}
+
+// WatchlistRepo is not cached, watchlists are read by their owner only.
+func (s *Store) WatchlistRepo() store.IWatchlistRepository {
+	return s.next.WatchlistRepo()
+}
+
+// WatchLogRepo is not cached, watch logs are read by their owner only.
+func (s *Store) WatchLogRepo() store.IWatchLogRepository {
+	return s.next.WatchLogRepo()
+}
+
+func (s *Store) MediaRepo() store.IMediaRepository {
+	return &MediaRepository{
+		next:   s.next.MediaRepo(),
+		films:  s.filmRepository.cache,
+		actors: s.actorRepository.cache,
+	}
+}
+
+// RelationRepo is not cached, cached films do not carry relations.
+func (s *Store) RelationRepo() store.IRelationRepository {
+	return s.next.RelationRepo()
+}
+
+// CollectionRepo is not cached, cached films do not carry collections.
+func (s *Store) CollectionRepo() store.ICollectionRepository {
+	return s.next.CollectionRepo()
+}
+
+// TranslationRepo is not cached, cached films are kept untranslated.
+func (s *Store) TranslationRepo() store.ITranslationRepository {
+	return s.next.TranslationRepo()
+}
+
+// AwardRepo is not cached, cached films do not carry awards.
+func (s *Store) AwardRepo() store.IAwardRepository {
+	return s.next.AwardRepo()
+}
+
+// AuditRepo is not cached, entries are read rarely and by admins only.
+func (s *Store) AuditRepo() store.IAuditRepository {
+	return s.next.AuditRepo()
+}
+
+// Primary returns a view whose reads bypass the cache and go to the
+// primary database of the wrapped store, refreshing cached entries.
+func (s *Store) Primary() store.IStore {
+	ps, ok := s.next.(store.IPrimaryStore)
+	if !ok {
+		return s
+	}
+	next := ps.Primary()
+
+	return &Store{
+		next: next,
+		filmRepository: &FilmRepository{
+			next:   next.FilmRepo(),
+			cache:  s.filmRepository.cache,
+			fill:   next.FilmRepo(),
+			bypass: true,
+		},
+		actorRepository: &ActorRepository{
+			next:   next.ActorRepo(),
+			cache:  s.actorRepository.cache,
+			fill:   next.ActorRepo(),
+			bypass: true,
+		},
+	}
+}
+
+// As returns a view attributing changes to p in the wrapped store's
+// audit log, sharing the caches of s.
+func (s *Store) As(p store.Principal) store.IStore {
+	as, ok := s.next.(store.IAuditedStore)
+	if !ok {
+		return s
+	}
+	next := as.As(p)
+
+	return &Store{
+		next: next,
+		filmRepository: &FilmRepository{
+			next:   next.FilmRepo(),
+			cache:  s.filmRepository.cache,
+			fill:   s.filmRepository.fill,
+			bypass: s.filmRepository.bypass,
+		},
+		actorRepository: &ActorRepository{
+			next:   next.ActorRepo(),
+			cache:  s.actorRepository.cache,
+			fill:   s.actorRepository.fill,
+			bypass: s.actorRepository.bypass,
+		},
+	}
+}
+
+// Stats returns cache counters keyed by repository name.
+func (s *Store) Stats() map[string]Stats {
+	return map[string]Stats{
+		"films":  s.filmRepository.cache.stats(),
+		"actors": s.actorRepository.cache.stats(),
+	}
+}
@@ -0,0 +1,63 @@
+package store
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Domain errors returned by repositories. Backends wrap them in *DBError
+// when more detail is available, so match them with errors.Is.
+var (
+	ErrResourceNotFound  = errors.New("resource not found")
+	ErrUniqueConstraints = errors.New("unique constraints violation")
+	ErrValidation        = errors.New("validation error")
+	ErrForeignKey        = errors.New("foreign key violation")
+	ErrCheckConstraint   = errors.New("check constraint violation")
+	ErrNotNull           = errors.New("not null violation")
+	ErrSerialization     = errors.New("serialization failure")
+	ErrDeadlock          = errors.New("deadlock detected")
+	ErrConnectionLost    = errors.New("database connection lost")
+	ErrRateLimited       = errors.New("rate limit exceeded")
+	ErrUnknownGenre      = fmt.Errorf("%w: unknown genre", ErrValidation)
+	ErrSequelCycle       = fmt.Errorf("%w: sequels would form a cycle", ErrValidation)
+)
+
+// DBError is a classified database error.
+type DBError struct {
+	// Kind is one of the domain errors above.
+	Kind error
+	// Code is the SQLSTATE reported by the server, if any.
+	Code       string
+	Table      string
+	Constraint string
+	Column     string
+	Err        error
+}
+
+func (e *DBError) Error() string {
+	switch {
+	case e.Constraint != "":
+		return fmt.Sprintf("%s (%s)", e.Kind, e.Constraint)
+	case e.Column != "":
+		return fmt.Sprintf("%s (%s.%s)", e.Kind, e.Table, e.Column)
+	}
+
+	return e.Kind.Error()
+}
+
+func (e *DBError) Unwrap() []error {
+	return []error{e.Kind, e.Err}
+}
+
+// Transient reports whether the operation may succeed if retried.
+func (e *DBError) Transient() bool {
+	return IsTransient(e.Kind)
+}
+
+// IsTransient reports whether err is a failure worth retrying:
+// serialization failures, deadlocks and lost connections.
+func IsTransient(err error) bool {
+	return errors.Is(err, ErrSerialization) ||
+		errors.Is(err, ErrDeadlock) ||
+		errors.Is(err, ErrConnectionLost)
+}
@@ -0,0 +1,58 @@
+package store
+
+// FilmFilter narrows film listings. The zero value lists all live films.
+type FilmFilter struct {
+	// IncludeDeleted also lists soft deleted films.
+	IncludeDeleted bool
+	// Genre lists films of the genre, matched case insensitively.
+	Genre string
+	// Language lists films of the ISO 639-1 original language.
+	Language string
+	// Country lists films produced in the ISO 3166-1 alpha-2 country.
+	Country   string
+	AgeRating string
+	// MinRuntime and MaxRuntime bound the runtime in minutes when not
+	// zero. Films of unknown runtime are left out of bounded lists.
+	MinRuntime uint16
+	MaxRuntime uint16
+	// WonCeremony and WonCategory list films that won an award of the
+	// ceremony and category, matched case insensitively. Either may be
+	// left empty to match any.
+	WonCeremony string
+	WonCategory string
+}
+
+// ActorFilter narrows actor listings. The zero value lists all live actors.
+type ActorFilter struct {
+	// IncludeDeleted also lists soft deleted actors.
+	IncludeDeleted bool
+	// Name lists actors whose name or any alias contains it, ignoring
+	// case.
+	Name string
+}
+
+// AuditFilter selects audit entries of an entity type, optionally of a
+// single entity.
+type AuditFilter struct {
+	Entity   string
+	EntityID int
+}
+
+// ReviewFilter selects reviews of live films. A zero FilmID or Status
+// matches any; Limit and Offset page through the matches, a zero Limit
+// listing them all.
+type ReviewFilter struct {
+	FilmID int
+	Status string
+	Limit  int
+	Offset int
+}
+
+// CollectionFilter selects collections visible to a viewer: public ones,
+// the viewer's own and, with IncludePrivate, everyone's. A non-zero
+// OwnerID lists the collections of that owner only.
+type CollectionFilter struct {
+	OwnerID        int
+	ViewerID       int
+	IncludePrivate bool
+}
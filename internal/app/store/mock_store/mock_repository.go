@@ -6,7 +6,9 @@ package mock_store
 
 import (
 	models "filmoteka/internal/app/models"
+	store "filmoteka/internal/app/store"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 )
@@ -49,6 +51,21 @@ func (mr *MockIFilmRepositoryMockRecorder) Create(arg0 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIFilmRepository)(nil).Create), arg0)
 }
 
+// CreateBatch mocks base method.
+func (m *MockIFilmRepository) CreateBatch(arg0 []models.Film) ([]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBatch", arg0)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBatch indicates an expected call of CreateBatch.
+func (mr *MockIFilmRepositoryMockRecorder) CreateBatch(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBatch", reflect.TypeOf((*MockIFilmRepository)(nil).CreateBatch), arg0)
+}
+
 // Delete mocks base method.
 func (m *MockIFilmRepository) Delete(id int) error {
 	m.ctrl.T.Helper()
@@ -63,6 +80,20 @@ func (mr *MockIFilmRepositoryMockRecorder) Delete(id interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockIFilmRepository)(nil).Delete), id)
 }
 
+// Each mocks base method.
+func (m *MockIFilmRepository) Each(fn func(models.Film) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Each", fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Each indicates an expected call of Each.
+func (mr *MockIFilmRepositoryMockRecorder) Each(fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Each", reflect.TypeOf((*MockIFilmRepository)(nil).Each), fn)
+}
+
 // Find mocks base method.
 func (m *MockIFilmRepository) Find(arg0 int) (models.Film, error) {
 	m.ctrl.T.Helper()
@@ -107,6 +138,126 @@ func (mr *MockIFilmRepositoryMockRecorder) Update(arg0 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockIFilmRepository)(nil).Update), arg0)
 }
 
+// Upsert mocks base method.
+func (m *MockIFilmRepository) Upsert(arg0 models.Film) (int, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upsert", arg0)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Upsert indicates an expected call of Upsert.
+func (mr *MockIFilmRepositoryMockRecorder) Upsert(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upsert", reflect.TypeOf((*MockIFilmRepository)(nil).Upsert), arg0)
+}
+
+// FindBy mocks base method.
+func (m *MockIFilmRepository) FindBy(arg0 store.FilmFilter) ([]models.Film, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindBy", arg0)
+	ret0, _ := ret[0].([]models.Film)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindBy indicates an expected call of FindBy.
+func (mr *MockIFilmRepositoryMockRecorder) FindBy(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindBy", reflect.TypeOf((*MockIFilmRepository)(nil).FindBy), arg0)
+}
+
+// Purge mocks base method.
+func (m *MockIFilmRepository) Purge(arg0 time.Time) ([]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", arg0)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockIFilmRepositoryMockRecorder) Purge(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockIFilmRepository)(nil).Purge), arg0)
+}
+
+// Restore mocks base method.
+func (m *MockIFilmRepository) Restore(arg0 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockIFilmRepositoryMockRecorder) Restore(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockIFilmRepository)(nil).Restore), arg0)
+}
+
+// Revert mocks base method.
+func (m *MockIFilmRepository) Revert(arg0 int, arg1 int) (models.Film, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revert", arg0, arg1)
+	ret0, _ := ret[0].(models.Film)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Revert indicates an expected call of Revert.
+func (mr *MockIFilmRepositoryMockRecorder) Revert(arg0 interface{}, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revert", reflect.TypeOf((*MockIFilmRepository)(nil).Revert), arg0, arg1)
+}
+
+// Revision mocks base method.
+func (m *MockIFilmRepository) Revision(arg0 int, arg1 int) (models.FilmRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revision", arg0, arg1)
+	ret0, _ := ret[0].(models.FilmRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Revision indicates an expected call of Revision.
+func (mr *MockIFilmRepositoryMockRecorder) Revision(arg0 interface{}, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revision", reflect.TypeOf((*MockIFilmRepository)(nil).Revision), arg0, arg1)
+}
+
+// Revisions mocks base method.
+func (m *MockIFilmRepository) Revisions(arg0 int) ([]models.FilmRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revisions", arg0)
+	ret0, _ := ret[0].([]models.FilmRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Revisions indicates an expected call of Revisions.
+func (mr *MockIFilmRepositoryMockRecorder) Revisions(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revisions", reflect.TypeOf((*MockIFilmRepository)(nil).Revisions), arg0)
+}
+
+// GenreFacets mocks base method.
+func (m *MockIFilmRepository) GenreFacets(arg0 store.FilmFilter) ([]models.GenreCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenreFacets", arg0)
+	ret0, _ := ret[0].([]models.GenreCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GenreFacets indicates an expected call of GenreFacets.
+func (mr *MockIFilmRepositoryMockRecorder) GenreFacets(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenreFacets", reflect.TypeOf((*MockIFilmRepository)(nil).GenreFacets), arg0)
+}
+
 // MockIActorRepository is a mock of IActorRepository interface.
 type MockIActorRepository struct {
 	ctrl     *gomock.Controller
@@ -145,6 +296,21 @@ func (mr *MockIActorRepositoryMockRecorder) Create(arg0 interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIActorRepository)(nil).Create), arg0)
 }
 
+// CreateBatch mocks base method.
+func (m *MockIActorRepository) CreateBatch(arg0 []models.Actor) ([]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBatch", arg0)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBatch indicates an expected call of CreateBatch.
+func (mr *MockIActorRepositoryMockRecorder) CreateBatch(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBatch", reflect.TypeOf((*MockIActorRepository)(nil).CreateBatch), arg0)
+}
+
 // Delete mocks base method.
 func (m *MockIActorRepository) Delete(id int) error {
 	m.ctrl.T.Helper()
@@ -159,6 +325,20 @@ func (mr *MockIActorRepositoryMockRecorder) Delete(id interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockIActorRepository)(nil).Delete), id)
 }
 
+// Each mocks base method.
+func (m *MockIActorRepository) Each(fn func(models.Actor) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Each", fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Each indicates an expected call of Each.
+func (mr *MockIActorRepositoryMockRecorder) Each(fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Each", reflect.TypeOf((*MockIActorRepository)(nil).Each), fn)
+}
+
 // Find mocks base method.
 func (m *MockIActorRepository) Find(arg0 int) (models.Actor, error) {
 	m.ctrl.T.Helper()
@@ -202,3 +382,1012 @@ func (mr *MockIActorRepositoryMockRecorder) Update(arg0 interface{}) *gomock.Cal
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockIActorRepository)(nil).Update), arg0)
 }
+
+// FindBy mocks base method.
+func (m *MockIActorRepository) FindBy(arg0 store.ActorFilter) ([]models.Actor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindBy", arg0)
+	ret0, _ := ret[0].([]models.Actor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindBy indicates an expected call of FindBy.
+func (mr *MockIActorRepositoryMockRecorder) FindBy(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindBy", reflect.TypeOf((*MockIActorRepository)(nil).FindBy), arg0)
+}
+
+// Purge mocks base method.
+func (m *MockIActorRepository) Purge(arg0 time.Time) ([]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", arg0)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockIActorRepositoryMockRecorder) Purge(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockIActorRepository)(nil).Purge), arg0)
+}
+
+// Restore mocks base method.
+func (m *MockIActorRepository) Restore(arg0 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockIActorRepositoryMockRecorder) Restore(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockIActorRepository)(nil).Restore), arg0)
+}
+
+// MockIAuditRepository is a mock of IAuditRepository interface.
+type MockIAuditRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIAuditRepositoryMockRecorder
+}
+
+// MockIAuditRepositoryMockRecorder is the mock recorder for MockIAuditRepository.
+type MockIAuditRepositoryMockRecorder struct {
+	mock *MockIAuditRepository
+}
+
+// NewMockIAuditRepository creates a new mock instance.
+func NewMockIAuditRepository(ctrl *gomock.Controller) *MockIAuditRepository {
+	mock := &MockIAuditRepository{ctrl: ctrl}
+	mock.recorder = &MockIAuditRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIAuditRepository) EXPECT() *MockIAuditRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Find mocks base method.
+func (m *MockIAuditRepository) Find(arg0 store.AuditFilter) ([]models.AuditEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Find", arg0)
+	ret0, _ := ret[0].([]models.AuditEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Find indicates an expected call of Find.
+func (mr *MockIAuditRepositoryMockRecorder) Find(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockIAuditRepository)(nil).Find), arg0)
+}
+
+// MockIGenreRepository is a mock of IGenreRepository interface.
+type MockIGenreRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIGenreRepositoryMockRecorder
+}
+
+// MockIGenreRepositoryMockRecorder is the mock recorder for MockIGenreRepository.
+type MockIGenreRepositoryMockRecorder struct {
+	mock *MockIGenreRepository
+}
+
+// NewMockIGenreRepository creates a new mock instance.
+func NewMockIGenreRepository(ctrl *gomock.Controller) *MockIGenreRepository {
+	mock := &MockIGenreRepository{ctrl: ctrl}
+	mock.recorder = &MockIGenreRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIGenreRepository) EXPECT() *MockIGenreRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockIGenreRepository) Create(arg0 models.Genre) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockIGenreRepositoryMockRecorder) Create(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIGenreRepository)(nil).Create), arg0)
+}
+
+// Delete mocks base method.
+func (m *MockIGenreRepository) Delete(arg0 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockIGenreRepositoryMockRecorder) Delete(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockIGenreRepository)(nil).Delete), arg0)
+}
+
+// Find mocks base method.
+func (m *MockIGenreRepository) Find(arg0 int) (models.Genre, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Find", arg0)
+	ret0, _ := ret[0].(models.Genre)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Find indicates an expected call of Find.
+func (mr *MockIGenreRepositoryMockRecorder) Find(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockIGenreRepository)(nil).Find), arg0)
+}
+
+// FindAll mocks base method.
+func (m *MockIGenreRepository) FindAll() ([]models.Genre, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll")
+	ret0, _ := ret[0].([]models.Genre)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockIGenreRepositoryMockRecorder) FindAll() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockIGenreRepository)(nil).FindAll))
+}
+
+// Update mocks base method.
+func (m *MockIGenreRepository) Update(arg0 models.Genre) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockIGenreRepositoryMockRecorder) Update(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockIGenreRepository)(nil).Update), arg0)
+}
+
+// MockICreditRepository is a mock of ICreditRepository interface.
+type MockICreditRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockICreditRepositoryMockRecorder
+}
+
+// MockICreditRepositoryMockRecorder is the mock recorder for MockICreditRepository.
+type MockICreditRepositoryMockRecorder struct {
+	mock *MockICreditRepository
+}
+
+// NewMockICreditRepository creates a new mock instance.
+func NewMockICreditRepository(ctrl *gomock.Controller) *MockICreditRepository {
+	mock := &MockICreditRepository{ctrl: ctrl}
+	mock.recorder = &MockICreditRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockICreditRepository) EXPECT() *MockICreditRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockICreditRepository) Add(arg0 models.Credit) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockICreditRepositoryMockRecorder) Add(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockICreditRepository)(nil).Add), arg0)
+}
+
+// ByFilm mocks base method.
+func (m *MockICreditRepository) ByFilm(arg0 int) ([]models.Credit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ByFilm", arg0)
+	ret0, _ := ret[0].([]models.Credit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ByFilm indicates an expected call of ByFilm.
+func (mr *MockICreditRepositoryMockRecorder) ByFilm(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ByFilm", reflect.TypeOf((*MockICreditRepository)(nil).ByFilm), arg0)
+}
+
+// ByPerson mocks base method.
+func (m *MockICreditRepository) ByPerson(arg0 int) ([]models.Credit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ByPerson", arg0)
+	ret0, _ := ret[0].([]models.Credit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ByPerson indicates an expected call of ByPerson.
+func (mr *MockICreditRepositoryMockRecorder) ByPerson(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ByPerson", reflect.TypeOf((*MockICreditRepository)(nil).ByPerson), arg0)
+}
+
+// Remove mocks base method.
+func (m *MockICreditRepository) Remove(arg0 models.Credit) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Remove", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Remove indicates an expected call of Remove.
+func (mr *MockICreditRepositoryMockRecorder) Remove(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Remove", reflect.TypeOf((*MockICreditRepository)(nil).Remove), arg0)
+}
+
+// MockIRatingRepository is a mock of IRatingRepository interface.
+type MockIRatingRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIRatingRepositoryMockRecorder
+}
+
+// MockIRatingRepositoryMockRecorder is the mock recorder for MockIRatingRepository.
+type MockIRatingRepositoryMockRecorder struct {
+	mock *MockIRatingRepository
+}
+
+// NewMockIRatingRepository creates a new mock instance.
+func NewMockIRatingRepository(ctrl *gomock.Controller) *MockIRatingRepository {
+	mock := &MockIRatingRepository{ctrl: ctrl}
+	mock.recorder = &MockIRatingRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIRatingRepository) EXPECT() *MockIRatingRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Find mocks base method.
+func (m *MockIRatingRepository) Find(filmID int, userID int) (models.UserRating, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Find", filmID, userID)
+	ret0, _ := ret[0].(models.UserRating)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Find indicates an expected call of Find.
+func (mr *MockIRatingRepositoryMockRecorder) Find(filmID interface{}, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockIRatingRepository)(nil).Find), filmID, userID)
+}
+
+// Rate mocks base method.
+func (m *MockIRatingRepository) Rate(arg0 models.UserRating) (models.RatingSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rate", arg0)
+	ret0, _ := ret[0].(models.RatingSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Rate indicates an expected call of Rate.
+func (mr *MockIRatingRepositoryMockRecorder) Rate(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rate", reflect.TypeOf((*MockIRatingRepository)(nil).Rate), arg0)
+}
+
+// Remove mocks base method.
+func (m *MockIRatingRepository) Remove(filmID int, userID int) (models.RatingSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Remove", filmID, userID)
+	ret0, _ := ret[0].(models.RatingSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Remove indicates an expected call of Remove.
+func (mr *MockIRatingRepositoryMockRecorder) Remove(filmID interface{}, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Remove", reflect.TypeOf((*MockIRatingRepository)(nil).Remove), filmID, userID)
+}
+
+// MockIReviewRepository is a mock of IReviewRepository interface.
+type MockIReviewRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIReviewRepositoryMockRecorder
+}
+
+// MockIReviewRepositoryMockRecorder is the mock recorder for MockIReviewRepository.
+type MockIReviewRepositoryMockRecorder struct {
+	mock *MockIReviewRepository
+}
+
+// NewMockIReviewRepository creates a new mock instance.
+func NewMockIReviewRepository(ctrl *gomock.Controller) *MockIReviewRepository {
+	mock := &MockIReviewRepository{ctrl: ctrl}
+	mock.recorder = &MockIReviewRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIReviewRepository) EXPECT() *MockIReviewRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockIReviewRepository) Create(arg0 models.Review) (models.Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0)
+	ret0, _ := ret[0].(models.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockIReviewRepositoryMockRecorder) Create(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIReviewRepository)(nil).Create), arg0)
+}
+
+// Find mocks base method.
+func (m *MockIReviewRepository) Find(id int) (models.Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Find", id)
+	ret0, _ := ret[0].(models.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Find indicates an expected call of Find.
+func (mr *MockIReviewRepositoryMockRecorder) Find(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockIReviewRepository)(nil).Find), id)
+}
+
+// FindBy mocks base method.
+func (m *MockIReviewRepository) FindBy(arg0 store.ReviewFilter) ([]models.Review, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindBy", arg0)
+	ret0, _ := ret[0].([]models.Review)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FindBy indicates an expected call of FindBy.
+func (mr *MockIReviewRepositoryMockRecorder) FindBy(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindBy", reflect.TypeOf((*MockIReviewRepository)(nil).FindBy), arg0)
+}
+
+// Moderate mocks base method.
+func (m *MockIReviewRepository) Moderate(id int, status string) (models.Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Moderate", id, status)
+	ret0, _ := ret[0].(models.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Moderate indicates an expected call of Moderate.
+func (mr *MockIReviewRepositoryMockRecorder) Moderate(id interface{}, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Moderate", reflect.TypeOf((*MockIReviewRepository)(nil).Moderate), id, status)
+}
+
+// Update mocks base method.
+func (m *MockIReviewRepository) Update(arg0 models.Review) (models.Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0)
+	ret0, _ := ret[0].(models.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockIReviewRepositoryMockRecorder) Update(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockIReviewRepository)(nil).Update), arg0)
+}
+
+// MockIWatchlistRepository is a mock of IWatchlistRepository interface.
+type MockIWatchlistRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIWatchlistRepositoryMockRecorder
+}
+
+// MockIWatchlistRepositoryMockRecorder is the mock recorder for MockIWatchlistRepository.
+type MockIWatchlistRepositoryMockRecorder struct {
+	mock *MockIWatchlistRepository
+}
+
+// NewMockIWatchlistRepository creates a new mock instance.
+func NewMockIWatchlistRepository(ctrl *gomock.Controller) *MockIWatchlistRepository {
+	mock := &MockIWatchlistRepository{ctrl: ctrl}
+	mock.recorder = &MockIWatchlistRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIWatchlistRepository) EXPECT() *MockIWatchlistRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockIWatchlistRepository) Add(userID int, filmID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", userID, filmID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockIWatchlistRepositoryMockRecorder) Add(userID interface{}, filmID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockIWatchlistRepository)(nil).Add), userID, filmID)
+}
+
+// Films mocks base method.
+func (m *MockIWatchlistRepository) Films(userID int, filter store.FilmFilter) ([]models.WatchlistItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Films", userID, filter)
+	ret0, _ := ret[0].([]models.WatchlistItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Films indicates an expected call of Films.
+func (mr *MockIWatchlistRepositoryMockRecorder) Films(userID interface{}, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Films", reflect.TypeOf((*MockIWatchlistRepository)(nil).Films), userID, filter)
+}
+
+// Remove mocks base method.
+func (m *MockIWatchlistRepository) Remove(userID int, filmID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Remove", userID, filmID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Remove indicates an expected call of Remove.
+func (mr *MockIWatchlistRepositoryMockRecorder) Remove(userID interface{}, filmID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Remove", reflect.TypeOf((*MockIWatchlistRepository)(nil).Remove), userID, filmID)
+}
+
+// MockIWatchLogRepository is a mock of IWatchLogRepository interface.
+type MockIWatchLogRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIWatchLogRepositoryMockRecorder
+}
+
+// MockIWatchLogRepositoryMockRecorder is the mock recorder for MockIWatchLogRepository.
+type MockIWatchLogRepositoryMockRecorder struct {
+	mock *MockIWatchLogRepository
+}
+
+// NewMockIWatchLogRepository creates a new mock instance.
+func NewMockIWatchLogRepository(ctrl *gomock.Controller) *MockIWatchLogRepository {
+	mock := &MockIWatchLogRepository{ctrl: ctrl}
+	mock.recorder = &MockIWatchLogRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIWatchLogRepository) EXPECT() *MockIWatchLogRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockIWatchLogRepository) Add(arg0 models.Watch) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", arg0)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockIWatchLogRepositoryMockRecorder) Add(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockIWatchLogRepository)(nil).Add), arg0)
+}
+
+// ByUser mocks base method.
+func (m *MockIWatchLogRepository) ByUser(userID int) ([]models.Watch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ByUser", userID)
+	ret0, _ := ret[0].([]models.Watch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ByUser indicates an expected call of ByUser.
+func (mr *MockIWatchLogRepositoryMockRecorder) ByUser(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ByUser", reflect.TypeOf((*MockIWatchLogRepository)(nil).ByUser), userID)
+}
+
+// Remove mocks base method.
+func (m *MockIWatchLogRepository) Remove(userID int, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Remove", userID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Remove indicates an expected call of Remove.
+func (mr *MockIWatchLogRepositoryMockRecorder) Remove(userID interface{}, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Remove", reflect.TypeOf((*MockIWatchLogRepository)(nil).Remove), userID, id)
+}
+
+// MockICollectionRepository is a mock of ICollectionRepository interface.
+type MockICollectionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockICollectionRepositoryMockRecorder
+}
+
+// MockICollectionRepositoryMockRecorder is the mock recorder for MockICollectionRepository.
+type MockICollectionRepositoryMockRecorder struct {
+	mock *MockICollectionRepository
+}
+
+// NewMockICollectionRepository creates a new mock instance.
+func NewMockICollectionRepository(ctrl *gomock.Controller) *MockICollectionRepository {
+	mock := &MockICollectionRepository{ctrl: ctrl}
+	mock.recorder = &MockICollectionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockICollectionRepository) EXPECT() *MockICollectionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockICollectionRepository) Create(arg0 models.Collection) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockICollectionRepositoryMockRecorder) Create(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockICollectionRepository)(nil).Create), arg0)
+}
+
+// Delete mocks base method.
+func (m *MockICollectionRepository) Delete(id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockICollectionRepositoryMockRecorder) Delete(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockICollectionRepository)(nil).Delete), id)
+}
+
+// Find mocks base method.
+func (m *MockICollectionRepository) Find(id int) (models.Collection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Find", id)
+	ret0, _ := ret[0].(models.Collection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Find indicates an expected call of Find.
+func (mr *MockICollectionRepositoryMockRecorder) Find(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockICollectionRepository)(nil).Find), id)
+}
+
+// FindBy mocks base method.
+func (m *MockICollectionRepository) FindBy(arg0 store.CollectionFilter) ([]models.Collection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindBy", arg0)
+	ret0, _ := ret[0].([]models.Collection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindBy indicates an expected call of FindBy.
+func (mr *MockICollectionRepositoryMockRecorder) FindBy(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindBy", reflect.TypeOf((*MockICollectionRepository)(nil).FindBy), arg0)
+}
+
+// Place mocks base method.
+func (m *MockICollectionRepository) Place(collectionID int, filmID int, position int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Place", collectionID, filmID, position)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Place indicates an expected call of Place.
+func (mr *MockICollectionRepositoryMockRecorder) Place(collectionID interface{}, filmID interface{}, position interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Place", reflect.TypeOf((*MockICollectionRepository)(nil).Place), collectionID, filmID, position)
+}
+
+// RemoveFilm mocks base method.
+func (m *MockICollectionRepository) RemoveFilm(collectionID int, filmID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveFilm", collectionID, filmID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveFilm indicates an expected call of RemoveFilm.
+func (mr *MockICollectionRepositoryMockRecorder) RemoveFilm(collectionID interface{}, filmID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveFilm", reflect.TypeOf((*MockICollectionRepository)(nil).RemoveFilm), collectionID, filmID)
+}
+
+// Reorder mocks base method.
+func (m *MockICollectionRepository) Reorder(collectionID int, filmIDs []int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reorder", collectionID, filmIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reorder indicates an expected call of Reorder.
+func (mr *MockICollectionRepositoryMockRecorder) Reorder(collectionID interface{}, filmIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reorder", reflect.TypeOf((*MockICollectionRepository)(nil).Reorder), collectionID, filmIDs)
+}
+
+// Update mocks base method.
+func (m *MockICollectionRepository) Update(arg0 models.Collection) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockICollectionRepositoryMockRecorder) Update(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockICollectionRepository)(nil).Update), arg0)
+}
+
+// MockIRelationRepository is a mock of IRelationRepository interface.
+type MockIRelationRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIRelationRepositoryMockRecorder
+}
+
+// MockIRelationRepositoryMockRecorder is the mock recorder for MockIRelationRepository.
+type MockIRelationRepositoryMockRecorder struct {
+	mock *MockIRelationRepository
+}
+
+// NewMockIRelationRepository creates a new mock instance.
+func NewMockIRelationRepository(ctrl *gomock.Controller) *MockIRelationRepository {
+	mock := &MockIRelationRepository{ctrl: ctrl}
+	mock.recorder = &MockIRelationRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIRelationRepository) EXPECT() *MockIRelationRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockIRelationRepository) Add(arg0 models.FilmRelation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockIRelationRepositoryMockRecorder) Add(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockIRelationRepository)(nil).Add), arg0)
+}
+
+// ByFilm mocks base method.
+func (m *MockIRelationRepository) ByFilm(filmID int) (models.RelatedFilms, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ByFilm", filmID)
+	ret0, _ := ret[0].(models.RelatedFilms)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ByFilm indicates an expected call of ByFilm.
+func (mr *MockIRelationRepositoryMockRecorder) ByFilm(filmID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ByFilm", reflect.TypeOf((*MockIRelationRepository)(nil).ByFilm), filmID)
+}
+
+// Remove mocks base method.
+func (m *MockIRelationRepository) Remove(arg0 models.FilmRelation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Remove", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Remove indicates an expected call of Remove.
+func (mr *MockIRelationRepositoryMockRecorder) Remove(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Remove", reflect.TypeOf((*MockIRelationRepository)(nil).Remove), arg0)
+}
+
+// MockIMediaRepository is a mock of IMediaRepository interface.
+type MockIMediaRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIMediaRepositoryMockRecorder
+}
+
+// MockIMediaRepositoryMockRecorder is the mock recorder for MockIMediaRepository.
+type MockIMediaRepositoryMockRecorder struct {
+	mock *MockIMediaRepository
+}
+
+// NewMockIMediaRepository creates a new mock instance.
+func NewMockIMediaRepository(ctrl *gomock.Controller) *MockIMediaRepository {
+	mock := &MockIMediaRepository{ctrl: ctrl}
+	mock.recorder = &MockIMediaRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIMediaRepository) EXPECT() *MockIMediaRepositoryMockRecorder {
+	return m.recorder
+}
+
+// SetPhoto mocks base method.
+func (m *MockIMediaRepository) SetPhoto(actorID int, img models.Image) (*models.Image, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPhoto", actorID, img)
+	ret0, _ := ret[0].(*models.Image)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetPhoto indicates an expected call of SetPhoto.
+func (mr *MockIMediaRepositoryMockRecorder) SetPhoto(actorID interface{}, img interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPhoto", reflect.TypeOf((*MockIMediaRepository)(nil).SetPhoto), actorID, img)
+}
+
+// SetPoster mocks base method.
+func (m *MockIMediaRepository) SetPoster(filmID int, img models.Image) (*models.Image, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPoster", filmID, img)
+	ret0, _ := ret[0].(*models.Image)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetPoster indicates an expected call of SetPoster.
+func (mr *MockIMediaRepositoryMockRecorder) SetPoster(filmID interface{}, img interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPoster", reflect.TypeOf((*MockIMediaRepository)(nil).SetPoster), filmID, img)
+}
+
+// MockITranslationRepository is a mock of ITranslationRepository interface.
+type MockITranslationRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockITranslationRepositoryMockRecorder
+}
+
+// MockITranslationRepositoryMockRecorder is the mock recorder for MockITranslationRepository.
+type MockITranslationRepositoryMockRecorder struct {
+	mock *MockITranslationRepository
+}
+
+// NewMockITranslationRepository creates a new mock instance.
+func NewMockITranslationRepository(ctrl *gomock.Controller) *MockITranslationRepository {
+	mock := &MockITranslationRepository{ctrl: ctrl}
+	mock.recorder = &MockITranslationRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockITranslationRepository) EXPECT() *MockITranslationRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ForFilms mocks base method.
+func (m *MockITranslationRepository) ForFilms(filmIDs []int, locales []string) (map[int]models.FilmTranslation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForFilms", filmIDs, locales)
+	ret0, _ := ret[0].(map[int]models.FilmTranslation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ForFilms indicates an expected call of ForFilms.
+func (mr *MockITranslationRepositoryMockRecorder) ForFilms(filmIDs interface{}, locales interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForFilms", reflect.TypeOf((*MockITranslationRepository)(nil).ForFilms), filmIDs, locales)
+}
+
+// Set mocks base method.
+func (m *MockITranslationRepository) Set(filmID int, t models.FilmTranslation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Set", filmID, t)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Set indicates an expected call of Set.
+func (mr *MockITranslationRepositoryMockRecorder) Set(filmID interface{}, t interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockITranslationRepository)(nil).Set), filmID, t)
+}
+
+// MockIAwardRepository is a mock of IAwardRepository interface.
+type MockIAwardRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIAwardRepositoryMockRecorder
+}
+
+// MockIAwardRepositoryMockRecorder is the mock recorder for MockIAwardRepository.
+type MockIAwardRepositoryMockRecorder struct {
+	mock *MockIAwardRepository
+}
+
+// NewMockIAwardRepository creates a new mock instance.
+func NewMockIAwardRepository(ctrl *gomock.Controller) *MockIAwardRepository {
+	mock := &MockIAwardRepository{ctrl: ctrl}
+	mock.recorder = &MockIAwardRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIAwardRepository) EXPECT() *MockIAwardRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ByActor mocks base method.
+func (m *MockIAwardRepository) ByActor(actorID int) ([]models.Nomination, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ByActor", actorID)
+	ret0, _ := ret[0].([]models.Nomination)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ByActor indicates an expected call of ByActor.
+func (mr *MockIAwardRepositoryMockRecorder) ByActor(actorID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ByActor", reflect.TypeOf((*MockIAwardRepository)(nil).ByActor), actorID)
+}
+
+// ByFilm mocks base method.
+func (m *MockIAwardRepository) ByFilm(filmID int) ([]models.Nomination, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ByFilm", filmID)
+	ret0, _ := ret[0].([]models.Nomination)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ByFilm indicates an expected call of ByFilm.
+func (mr *MockIAwardRepositoryMockRecorder) ByFilm(filmID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ByFilm", reflect.TypeOf((*MockIAwardRepository)(nil).ByFilm), filmID)
+}
+
+// Create mocks base method.
+func (m *MockIAwardRepository) Create(arg0 models.Award) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockIAwardRepositoryMockRecorder) Create(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIAwardRepository)(nil).Create), arg0)
+}
+
+// Delete mocks base method.
+func (m *MockIAwardRepository) Delete(id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockIAwardRepositoryMockRecorder) Delete(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockIAwardRepository)(nil).Delete), id)
+}
+
+// FindAll mocks base method.
+func (m *MockIAwardRepository) FindAll() ([]models.Award, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll")
+	ret0, _ := ret[0].([]models.Award)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockIAwardRepositoryMockRecorder) FindAll() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockIAwardRepository)(nil).FindAll))
+}
+
+// Nominate mocks base method.
+func (m *MockIAwardRepository) Nominate(arg0 models.Nomination) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Nominate", arg0)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Nominate indicates an expected call of Nominate.
+func (mr *MockIAwardRepositoryMockRecorder) Nominate(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Nominate", reflect.TypeOf((*MockIAwardRepository)(nil).Nominate), arg0)
+}
+
+// RemoveNomination mocks base method.
+func (m *MockIAwardRepository) RemoveNomination(nominationID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveNomination", nominationID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveNomination indicates an expected call of RemoveNomination.
+func (mr *MockIAwardRepositoryMockRecorder) RemoveNomination(nominationID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveNomination", reflect.TypeOf((*MockIAwardRepository)(nil).RemoveNomination), nominationID)
+}
+
+// SetWon mocks base method.
+func (m *MockIAwardRepository) SetWon(nominationID int, won bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetWon", nominationID, won)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetWon indicates an expected call of SetWon.
+func (mr *MockIAwardRepositoryMockRecorder) SetWon(nominationID interface{}, won interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetWon", reflect.TypeOf((*MockIAwardRepository)(nil).SetWon), nominationID, won)
+}
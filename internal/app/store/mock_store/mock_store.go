@@ -5,8 +5,20 @@ import (
 )
 
 type MockStore struct {
-	filmRepository  *MockIFilmRepository
-	actorRepository *MockIActorRepository
+	filmRepository        *MockIFilmRepository
+	actorRepository       *MockIActorRepository
+	auditRepository       *MockIAuditRepository
+	genreRepository       *MockIGenreRepository
+	creditRepository      *MockICreditRepository
+	ratingRepository      *MockIRatingRepository
+	reviewRepository      *MockIReviewRepository
+	watchlistRepository   *MockIWatchlistRepository
+	watchLogRepository    *MockIWatchLogRepository
+	mediaRepository       *MockIMediaRepository
+	relationRepository    *MockIRelationRepository
+	collectionRepository  *MockICollectionRepository
+	translationRepository *MockITranslationRepository
+	awardRepository       *MockIAwardRepository
 }
 
 func New(
@@ -19,6 +31,78 @@ func New(
 	}
 }
 
+// WithAuditRepo sets the audit repository mock.
+func (s *MockStore) WithAuditRepo(r *MockIAuditRepository) *MockStore {
+	s.auditRepository = r
+	return s
+}
+
+// WithGenreRepo sets the genre repository mock.
+func (s *MockStore) WithGenreRepo(r *MockIGenreRepository) *MockStore {
+	s.genreRepository = r
+	return s
+}
+
+// WithCreditRepo sets the credit repository mock.
+func (s *MockStore) WithCreditRepo(r *MockICreditRepository) *MockStore {
+	s.creditRepository = r
+	return s
+}
+
+// WithRatingRepo sets the rating repository mock.
+func (s *MockStore) WithRatingRepo(r *MockIRatingRepository) *MockStore {
+	s.ratingRepository = r
+	return s
+}
+
+// WithReviewRepo sets the review repository mock.
+func (s *MockStore) WithReviewRepo(r *MockIReviewRepository) *MockStore {
+	s.reviewRepository = r
+	return s
+}
+
+// WithWatchlistRepo sets the watchlist repository mock.
+func (s *MockStore) WithWatchlistRepo(r *MockIWatchlistRepository) *MockStore {
+	s.watchlistRepository = r
+	return s
+}
+
+// WithWatchLogRepo sets the watch log repository mock.
+func (s *MockStore) WithWatchLogRepo(r *MockIWatchLogRepository) *MockStore {
+	s.watchLogRepository = r
+	return s
+}
+
+// WithMediaRepo sets the media repository mock.
+func (s *MockStore) WithMediaRepo(r *MockIMediaRepository) *MockStore {
+	s.mediaRepository = r
+	return s
+}
+
+// WithRelationRepo sets the relation repository mock.
+func (s *MockStore) WithRelationRepo(r *MockIRelationRepository) *MockStore {
+	s.relationRepository = r
+	return s
+}
+
+// WithCollectionRepo sets the collection repository mock.
+func (s *MockStore) WithCollectionRepo(r *MockICollectionRepository) *MockStore {
+	s.collectionRepository = r
+	return s
+}
+
+// WithTranslationRepo sets the translation repository mock.
+func (s *MockStore) WithTranslationRepo(r *MockITranslationRepository) *MockStore {
+	s.translationRepository = r
+	return s
+}
+
+// WithAwardRepo sets the award repository mock.
+func (s *MockStore) WithAwardRepo(r *MockIAwardRepository) *MockStore {
+	s.awardRepository = r
+	return s
+}
+
 func (s *MockStore) FilmRepo() store.IFilmRepository {
 	return s.filmRepository
 }
@@ -26,3 +110,51 @@ func (s *MockStore) FilmRepo() store.IFilmRepository {
 func (s *MockStore) ActorRepo() store.IActorRepository {
 	return s.actorRepository
 }
+
+func (s *MockStore) GenreRepo() store.IGenreRepository {
+	return s.genreRepository
+}
+
+func (s *MockStore) CreditRepo() store.ICreditRepository {
+	return s.creditRepository
+}
+
+func (s *MockStore) RatingRepo() store.IRatingRepository {
+	return s.ratingRepository
+}
+
+func (s *MockStore) ReviewRepo() store.IReviewRepository {
+	return s.reviewRepository
+}
+
+func (s *MockStore) WatchlistRepo() store.IWatchlistRepository {
+	return s.watchlistRepository
+}
+
+func (s *MockStore) WatchLogRepo() store.IWatchLogRepository {
+	return s.watchLogRepository
+}
+
+func (s *MockStore) MediaRepo() store.IMediaRepository {
+	return s.mediaRepository
+}
+
+func (s *MockStore) RelationRepo() store.IRelationRepository {
+	return s.relationRepository
+}
+
+func (s *MockStore) CollectionRepo() store.ICollectionRepository {
+	return s.collectionRepository
+}
+
+func (s *MockStore) TranslationRepo() store.ITranslationRepository {
+	return s.translationRepository
+}
+
+func (s *MockStore) AwardRepo() store.IAwardRepository {
+	return s.awardRepository
+}
+
+func (s *MockStore) AuditRepo() store.IAuditRepository {
+	return s.auditRepository
+}
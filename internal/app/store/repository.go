@@ -1,6 +1,8 @@
 package store
 
 import (
+	"time"
+
 	"filmoteka/internal/app/models"
 )
 
@@ -8,16 +10,183 @@ import (
 
 type IFilmRepository interface {
 	Create(models.Film) (int, error)
+	// CreateBatch inserts all films in one transaction. The returned ids
+	// follow the input order; 0 marks a film skipped as a duplicate.
+	CreateBatch([]models.Film) ([]int, error)
 	Find(int) (models.Film, error)
 	FindAll() ([]models.Film, error)
+	FindBy(FilmFilter) ([]models.Film, error)
+	// GenreFacets counts the films matching the filter per genre.
+	GenreFacets(FilmFilter) ([]models.GenreCount, error)
+	// Each streams all films to fn without loading them into memory,
+	// stopping at the first error fn returns.
+	Each(fn func(models.Film) error) error
+	// Delete soft deletes, hiding the row until Restore or Purge.
 	Delete(id int) error
+	Restore(id int) error
+	// Purge removes films soft deleted before the given time for good
+	// and returns their ids.
+	Purge(before time.Time) ([]int, error)
 	Update(models.Film) error
+	// Upsert inserts the film or updates the one with the same
+	// (name, release_year) key, reporting whether a row was created.
+	Upsert(models.Film) (int, bool, error)
+	// Revisions lists the snapshots of a film, oldest first.
+	Revisions(id int) ([]models.FilmRevision, error)
+	Revision(id, n int) (models.FilmRevision, error)
+	// Revert restores the film to revision n, recording it as a new
+	// revision, and returns the reverted film.
+	Revert(id, n int) (models.Film, error)
 }
 
 type IActorRepository interface {
 	Create(models.Actor) (int, error)
+	// CreateBatch inserts all actors in one transaction. The returned ids
+	// follow the input order.
+	CreateBatch([]models.Actor) ([]int, error)
 	Find(int) (models.Actor, error)
 	FindAll() ([]models.Actor, error)
+	FindBy(ActorFilter) ([]models.Actor, error)
+	// Each streams all actors to fn without loading them into memory,
+	// stopping at the first error fn returns.
+	Each(fn func(models.Actor) error) error
+	// Delete soft deletes, hiding the row until Restore or Purge.
 	Delete(id int) error
+	Restore(id int) error
+	// Purge removes actors soft deleted before the given time for good
+	// and returns their ids.
+	Purge(before time.Time) ([]int, error)
 	Update(models.Actor) error
 }
+
+type IGenreRepository interface {
+	Create(models.Genre) (int, error)
+	Find(int) (models.Genre, error)
+	FindAll() ([]models.Genre, error)
+	Update(models.Genre) error
+	// Delete removes the genre from all films.
+	Delete(id int) error
+}
+
+type ICreditRepository interface {
+	// Add credits a person with a job on a film. Deleted films and people
+	// count as missing.
+	Add(models.Credit) error
+	Remove(models.Credit) error
+	// ByFilm lists the crew of a film ordered by job and name.
+	ByFilm(filmID int) ([]models.Credit, error)
+	// ByPerson lists the crew credits of a person, newest film first.
+	ByPerson(personID int) ([]models.Credit, error)
+}
+
+type IRatingRepository interface {
+	// Rate sets a user's rating of a live film, replacing the previous
+	// one, and returns the updated summary of the film's ratings.
+	Rate(models.UserRating) (models.RatingSummary, error)
+	Find(filmID, userID int) (models.UserRating, error)
+	Remove(filmID, userID int) (models.RatingSummary, error)
+}
+
+type IReviewRepository interface {
+	// Create adds a pending review of a live film, failing with
+	// ErrRateLimited when the author has written too many lately.
+	Create(models.Review) (models.Review, error)
+	Find(id int) (models.Review, error)
+	// FindBy lists reviews newest first, one page at a time, along with
+	// the number of reviews matching the filter.
+	FindBy(ReviewFilter) ([]models.Review, int, error)
+	// Update changes the title and body of a review of its author and
+	// sends it back to moderation.
+	Update(models.Review) (models.Review, error)
+	// Moderate sets the moderation state of a review.
+	Moderate(id int, status string) (models.Review, error)
+}
+
+type IWatchlistRepository interface {
+	// Add puts a live film on the user's watchlist; adding it again is a
+	// no-op.
+	Add(userID, filmID int) error
+	Remove(userID, filmID int) error
+	// Films lists the live films on the user's watchlist matching the
+	// filter, latest additions first.
+	Films(userID int, filter FilmFilter) ([]models.WatchlistItem, error)
+}
+
+type IWatchLogRepository interface {
+	// Add logs a watch of a live film and returns its id.
+	Add(models.Watch) (int, error)
+	Remove(userID, id int) error
+	// ByUser lists the watches of a user, latest first.
+	ByUser(userID int) ([]models.Watch, error)
+}
+
+type IMediaRepository interface {
+	// SetPoster sets the poster of a live film and returns the one it
+	// replaces, nil when there was none.
+	SetPoster(filmID int, img models.Image) (*models.Image, error)
+	// SetPhoto sets the photo of a live actor and returns the one it
+	// replaces, nil when there was none.
+	SetPhoto(actorID int, img models.Image) (*models.Image, error)
+}
+
+type IRelationRepository interface {
+	// Add relates two live films, failing with ErrSequelCycle when a film
+	// would end up a sequel of itself.
+	Add(models.FilmRelation) error
+	Remove(models.FilmRelation) error
+	// ByFilm lists the live films related to a film, grouped by the type
+	// of their relation to it and ordered by release year.
+	ByFilm(filmID int) (models.RelatedFilms, error)
+}
+
+type ICollectionRepository interface {
+	Create(models.Collection) (int, error)
+	// Find returns a collection with its live films in order.
+	Find(id int) (models.Collection, error)
+	// FindBy lists collections without their films, latest updated first.
+	FindBy(CollectionFilter) ([]models.Collection, error)
+	// Update changes the title, description and visibility.
+	Update(models.Collection) error
+	Delete(id int) error
+	// Place adds a live film to the collection at position, or moves it
+	// there when it is already a member, shifting the films in between.
+	// Positions start at 1; 0 or one past the end places the film last.
+	// It returns the position the film ends up at.
+	Place(collectionID, filmID, position int) (int, error)
+	// RemoveFilm takes a film out of the collection, closing the gap.
+	RemoveFilm(collectionID, filmID int) error
+	// Reorder puts the given member films first, in the given order, and
+	// keeps the other members after them in their current order.
+	Reorder(collectionID int, filmIDs []int) error
+}
+
+type IAwardRepository interface {
+	Create(models.Award) (int, error)
+	// FindAll lists the awards, latest year first.
+	FindAll() ([]models.Award, error)
+	// Delete removes an award along with its nominations.
+	Delete(id int) error
+	// Nominate records a nomination of a live film, and of a live actor
+	// when ActorID is set, for an award.
+	Nominate(models.Nomination) (int, error)
+	SetWon(nominationID int, won bool) error
+	RemoveNomination(nominationID int) error
+	// ByFilm lists the nominations of a live film, latest year first.
+	ByFilm(filmID int) ([]models.Nomination, error)
+	// ByActor lists the nominations of a live actor, latest year first.
+	ByActor(actorID int) ([]models.Nomination, error)
+}
+
+type ITranslationRepository interface {
+	// Set adds or replaces the translation of a live film into a locale.
+	Set(filmID int, t models.FilmTranslation) error
+	// ForFilms returns the translations of the films into the first of
+	// locales each film has one in, keyed by film id. Films without any
+	// are left out.
+	ForFilms(filmIDs []int, locales []string) (map[int]models.FilmTranslation, error)
+}
+
+type IAuditRepository interface {
+	// Find lists audit entries oldest first.
+	Find(AuditFilter) ([]models.AuditEntry, error)
+}
@@ -3,46 +3,135 @@ package sqlstore
 import (
 	"database/sql"
 	"filmoteka/internal/app/models"
+	"filmoteka/internal/app/store"
+	"time"
+
+	"github.com/lib/pq"
 )
 
+// actorProfile selects the optional profile columns scanned by actorDest
+// after the birth date.
+const actorProfile = "COALESCE(to_char(death_date, 'YYYY-MM-DD'), ''), COALESCE(birthplace, ''), COALESCE(biography, ''), aliases"
+
+// actorPhoto selects the photo, read with imageDest after updated_at.
+const actorPhoto = "photo"
+
+const actorInsert = "INSERT INTO actors (name, gender, birth_date, death_date, birthplace, biography, aliases) VALUES ($1, $2, $3, NULLIF($4, '')::date, NULLIF($5, ''), NULLIF($6, ''), $7) RETURNING id;"
+
 type ActorRepository struct {
 	store *Store
 }
 
+// actorDest returns the scan destinations of id, name, gender, birth
+// date, actorProfile and updated_at.
+func actorDest(a *models.Actor) []any {
+	return []any{
+		&a.Id,
+		&a.Name,
+		&a.Gender,
+		&a.BirthDate,
+		&a.DeathDate,
+		&a.Birthplace,
+		&a.Biography,
+		pq.Array(&a.Aliases),
+		&a.UpdatedAt,
+	}
+}
+
+// actorArgs returns the column values written by actorInsert and Update.
+func actorArgs(a models.Actor) []any {
+	aliases := a.Aliases
+	if aliases == nil {
+		aliases = []string{}
+	}
+
+	return []any{
+		a.Name,
+		a.Gender,
+		a.BirthDate,
+		a.DeathDate,
+		a.Birthplace,
+		a.Biography,
+		pq.Array(aliases),
+	}
+}
+
 func (r *ActorRepository) Create(a models.Actor) (int, error) {
 	if err := a.Validate(); err != nil {
 		return 0, err
 	}
 
 	var id int
-	if err := r.store.db.QueryRow(
-		"INSERT INTO actors (name, gender, birth_date) VALUES ($1, $2, $3) RETURNING id;",
-		a.Name,
-		a.Gender,
-		a.BirthDate,
-	).Scan(&id); err != nil {
-		// sqlState := getSQLState(err)
-		if err == sql.ErrNoRows {
-			return 0, ErrResourceNotFound
-		} else {
-			return 0, err
-		}
+	if err := r.store.retry(false, func() error {
+		return r.store.inTx(func(tx *sql.Tx) error {
+			if err := tx.QueryRow(actorInsert, actorArgs(a)...).Scan(&id); err != nil {
+				return err
+			}
+			a.Id = id
+
+			return r.store.audit(tx, store.EntityActor, id, store.ActionCreate, nil, a)
+		})
+	}); err != nil {
+		return 0, err
 	}
 
 	return id, nil
 }
 
+func (r *ActorRepository) CreateBatch(actors []models.Actor) ([]int, error) {
+	var ids []int
+	err := r.store.retry(false, func() (err error) {
+		ids, err = r.createBatch(actors)
+		return err
+	})
+
+	return ids, err
+}
+
+func (r *ActorRepository) createBatch(actors []models.Actor) ([]int, error) {
+	tx, err := r.store.db.Begin()
+	if err != nil {
+		return nil, translateError(err)
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.Prepare(actorInsert)
+	if err != nil {
+		return nil, translateError(err)
+	}
+	defer stmt.Close()
+
+	ids := make([]int, len(actors))
+	for i, a := range actors {
+		if err := a.Validate(); err != nil {
+			return nil, &store.BatchError{Row: i, Err: err}
+		}
+
+		if err := stmt.QueryRow(actorArgs(a)...).Scan(&ids[i]); err != nil {
+			return nil, &store.BatchError{Row: i, Err: translateError(err)}
+		}
+
+		a.Id = ids[i]
+		if err := r.store.audit(tx, store.EntityActor, a.Id, store.ActionCreate, nil, a); err != nil {
+			return nil, &store.BatchError{Row: i, Err: translateError(err)}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, translateError(err)
+	}
+
+	return ids, nil
+}
+
 func (r *ActorRepository) Find(id int) (models.Actor, error) {
 	a := models.Actor{}
-	if err := r.store.db.QueryRow(
-		"SELECT id, name, gender, birth_date FROM actors WHERE id = $1;",
-		id,
-	).Scan(
-		&a.Id,
-		&a.Name,
-		&a.Gender,
-		&a.BirthDate,
-	); err != nil {
+	if err := r.store.retry(true, func() error {
+		return r.store.reader().QueryRow(
+			"SELECT id, name, gender, birth_date, "+actorProfile+", updated_at, "+actorPhoto+" FROM actors WHERE id = $1 AND deleted_at IS NULL;",
+			id,
+		).Scan(append(actorDest(&a), imageDest(&a.Photo))...)
+	}); err != nil {
 		switch err {
 		case sql.ErrNoRows:
 			return models.Actor{}, ErrResourceNotFound
@@ -55,24 +144,29 @@ func (r *ActorRepository) Find(id int) (models.Actor, error) {
 }
 
 func (r *ActorRepository) FindAll() ([]models.Actor, error) {
+	var actors []models.Actor
+	err := r.store.retry(true, func() (err error) {
+		actors, err = r.findAll()
+		return err
+	})
+
+	return actors, err
+}
+
+func (r *ActorRepository) findAll() ([]models.Actor, error) {
 	a := &models.Actor{}
 	actors := make([]models.Actor, 0)
-	rows, err := r.store.db.Query(
-		"SELECT id, name, gender, birth_date FROM actors;")
+	rows, err := r.store.reader().Query(
+		"SELECT id, name, gender, birth_date, " + actorProfile + ", updated_at, " + actorPhoto + " FROM actors WHERE deleted_at IS NULL;")
 	if err != nil {
-		return nil, err
+		return nil, translateError(err)
 	}
 	defer rows.Close()
 
 	for rows.Next() {
-		err := rows.Scan(
-			&a.Id,
-			&a.Name,
-			&a.Gender,
-			&a.BirthDate,
-		)
+		err := rows.Scan(append(actorDest(a), imageDest(&a.Photo))...)
 		if err != nil {
-			return nil, err
+			return nil, translateError(err)
 		}
 		actors = append(actors, *a)
 	}
@@ -80,21 +174,129 @@ func (r *ActorRepository) FindAll() ([]models.Actor, error) {
 	return actors, nil
 }
 
-func (r *ActorRepository) Delete(id int) error {
-	result, err := r.store.db.Exec("DELETE FROM actors WHERE id=$1;", id)
-	if err != nil {
+func (r *ActorRepository) FindBy(filter store.ActorFilter) ([]models.Actor, error) {
+	query, args := actorFilterQuery(filter)
+
+	var actors []models.Actor
+	err := r.store.retry(true, func() error {
+		rows, err := r.store.reader().Query(query, args...)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		actors = make([]models.Actor, 0)
+		for rows.Next() {
+			a := models.Actor{}
+			if err := rows.Scan(append(actorDest(&a), imageDest(&a.Photo), &a.DeletedAt)...); err != nil {
+				return err
+			}
+			actors = append(actors, a)
+		}
+
+		return rows.Err()
+	})
+
+	return actors, err
+}
+
+func (r *ActorRepository) Each(fn func(models.Actor) error) error {
+	// only opening the cursor is retried, rows already passed to fn
+	// cannot be taken back
+	var rows *sql.Rows
+	if err := r.store.retry(true, func() (err error) {
+		rows, err = r.store.reader().Query(
+			"SELECT id, name, gender, birth_date, " + actorProfile + ", updated_at FROM actors WHERE deleted_at IS NULL ORDER BY id;")
+		return err
+	}); err != nil {
 		return err
 	}
+	defer rows.Close()
 
-	deletedRows, err := result.RowsAffected()
-	// fmt.Println("deleted: ", deletedRows)
-	if err != nil {
-		return err
+	for rows.Next() {
+		a := models.Actor{}
+		err := rows.Scan(actorDest(&a)...)
+		if err != nil {
+			return translateError(err)
+		}
+		if err := fn(a); err != nil {
+			return err
+		}
 	}
-	if deletedRows == 0 {
-		return ErrResourceNotFound
+
+	return translateError(rows.Err())
+}
+
+func (r *ActorRepository) Delete(id int) error {
+	return r.store.retry(false, func() error {
+		return r.store.inTx(func(tx *sql.Tx) error {
+			before, err := r.lock(tx, id)
+			if err != nil {
+				return err
+			}
+			if _, err := tx.Exec("UPDATE actors SET deleted_at=now(), updated_at=now() WHERE id=$1;", id); err != nil {
+				return err
+			}
+
+			return r.store.audit(tx, store.EntityActor, id, store.ActionDelete, before, nil)
+		})
+	})
+}
+
+func (r *ActorRepository) Restore(id int) error {
+	return r.store.retry(false, func() error {
+		return r.store.inTx(func(tx *sql.Tx) error {
+			after := models.Actor{}
+			err := tx.QueryRow(
+				"UPDATE actors SET deleted_at=NULL, updated_at=now() WHERE id=$1 AND deleted_at IS NOT NULL RETURNING id, name, gender, to_char(birth_date, 'YYYY-MM-DD'), "+actorProfile+", updated_at;",
+				id,
+			).Scan(actorDest(&after)...)
+			switch {
+			case err == sql.ErrNoRows:
+				return ErrResourceNotFound
+			case err != nil:
+				return err
+			}
+
+			return r.store.audit(tx, store.EntityActor, id, store.ActionRestore, nil, after)
+		})
+	})
+}
+
+func (r *ActorRepository) Purge(before time.Time) ([]int, error) {
+	var ids []int
+	// a replay after a lost commit would find nothing left, losing the
+	// ids whose image files are to be removed
+	if err := r.store.retry(false, func() error {
+		// one statement, so rows and their audit entries go together
+		rows, err := r.store.db.Query(
+			"WITH purged AS (DELETE FROM actors WHERE deleted_at < $1 RETURNING id, name, gender, birth_date, deleted_at) INSERT INTO audit_log (user_id, request_id, entity, entity_id, action, before) SELECT $2, $3, $4, id, $5, jsonb_build_object('id', id, 'name', name, 'gender', gender, 'birth_date', birth_date, 'deleted_at', deleted_at) FROM purged RETURNING entity_id;",
+			before,
+			r.store.principalID(),
+			r.store.principal.RequestID,
+			store.EntityActor,
+			store.ActionPurge,
+		)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		ids = make([]int, 0)
+		for rows.Next() {
+			var id int
+			if err := rows.Scan(&id); err != nil {
+				return err
+			}
+			ids = append(ids, id)
+		}
+
+		return rows.Err()
+	}); err != nil {
+		return nil, err
 	}
-	return nil
+
+	return ids, nil
 }
 
 func (r *ActorRepository) Update(a models.Actor) error {
@@ -102,24 +304,36 @@ func (r *ActorRepository) Update(a models.Actor) error {
 		return err
 	}
 
-	result, err := r.store.db.Exec(
-		"UPDATE actors SET name=$1, gender=$2, birth_date=$3 WHERE id=$4;",
-		a.Name,
-		a.Gender,
-		a.BirthDate,
-		a.Id,
-	)
-	if err != nil {
-		return err
-	}
+	return r.store.retry(false, func() error {
+		return r.store.inTx(func(tx *sql.Tx) error {
+			before, err := r.lock(tx, a.Id)
+			if err != nil {
+				return err
+			}
+			if _, err := tx.Exec(
+				"UPDATE actors SET name=$1, gender=$2, birth_date=$3, death_date=NULLIF($4, '')::date, birthplace=NULLIF($5, ''), biography=NULLIF($6, ''), aliases=$7, updated_at=now() WHERE id=$8;",
+				append(actorArgs(a), a.Id)...,
+			); err != nil {
+				return err
+			}
 
-	updatedRows, err := result.RowsAffected()
-	if err != nil {
-		return err
-	}
-	if updatedRows == 0 {
-		return ErrResourceNotFound
+			return r.store.audit(tx, store.EntityActor, a.Id, store.ActionUpdate, before, a)
+		})
+	})
+}
+
+// lock reads the live actor and locks its row until tx ends. The birth
+// date is read in the input format so the audit diff only shows real
+// changes.
+func (r *ActorRepository) lock(tx *sql.Tx, id int) (models.Actor, error) {
+	a := models.Actor{}
+	err := tx.QueryRow(
+		"SELECT id, name, gender, to_char(birth_date, 'YYYY-MM-DD'), "+actorProfile+", updated_at FROM actors WHERE id = $1 AND deleted_at IS NULL FOR UPDATE;",
+		id,
+	).Scan(actorDest(&a)...)
+	if err == sql.ErrNoRows {
+		return a, ErrResourceNotFound
 	}
 
-	return nil
+	return a, err
 }
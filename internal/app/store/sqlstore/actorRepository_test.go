@@ -3,12 +3,18 @@ package sqlstore
 import (
 	"database/sql"
 	"filmoteka/internal/app/models"
+	"filmoteka/internal/app/store"
 	"testing"
+	"time"
 
 	sqlmock "github.com/DATA-DOG/go-sqlmock"
 	"github.com/stretchr/testify/assert"
 )
 
+const actorLock = "SELECT id, name, gender, to_char(birth_date, 'YYYY-MM-DD'), " + actorProfile + ", updated_at FROM actors WHERE id = $1 AND deleted_at IS NULL FOR UPDATE;"
+
+var actorColumns = []string{"id", "name", "gender", "birth_date", "death_date", "birthplace", "biography", "aliases", "updated_at"}
+
 func TestActor_Create(t *testing.T) {
 	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
 	if err != nil {
@@ -20,7 +26,7 @@ func TestActor_Create(t *testing.T) {
 
 	testActor := models.Actor{
 		Name:      "Name 1",
-		Gender:    "M",
+		Gender:    "male",
 		BirthDate: "1995-01-12",
 	}
 
@@ -44,20 +50,25 @@ func TestActor_Create(t *testing.T) {
 			want: 1,
 			mock: func(args args, id int) {
 				rows := sqlmock.NewRows([]string{"id"}).AddRow(id)
+				mock.ExpectBegin()
 				mock.ExpectQuery(
-					"INSERT INTO actors (name, gender, birth_date) VALUES ($1, $2, $3) RETURNING id;",
+					actorInsert,
 				).WithArgs(
 					args.actor.Name,
 					args.actor.Gender,
 					args.actor.BirthDate,
+					"", "", "", "{}",
 				).WillReturnRows(rows)
+				expectAudit(mock, store.EntityActor, id, store.ActionCreate, "",
+					`{"id":1,"name":"Name 1","gender":"male","birth_date":"1995-01-12"}`)
+				mock.ExpectCommit()
 			},
 		},
 		{
 			name: "Failed empty field",
 			input: args{
 				actor: models.Actor{
-					Gender:    "M",
+					Gender:    "male",
 					BirthDate: "1995-01-12",
 				},
 			},
@@ -96,6 +107,7 @@ func TestActor_GetAll(t *testing.T) {
 	defer db.Close()
 
 	r := New(db)
+	updatedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
 
 	tests := []struct {
 		name    string
@@ -107,30 +119,30 @@ func TestActor_GetAll(t *testing.T) {
 			name: "Regular Select",
 			mock: func() {
 				rows := sqlmock.NewRows([]string{
-					"id", "name", "gender", "birth_date",
+					"id", "name", "gender", "birth_date", "death_date", "birthplace", "biography", "aliases", "updated_at", "photo",
 				}).
-					AddRow(1, "Actor1", "M", "1980-01-12").
-					AddRow(2, "Actor2", "M", "1990-02-20").
-					AddRow(3, "Actor3", "F", "1990-02-20")
+					AddRow(1, "Actor1", "male", "1980-01-12", "", "", "", "{}", updatedAt, nil).
+					AddRow(2, "Actor2", "male", "1990-02-20", "", "", "", "{}", updatedAt, nil).
+					AddRow(3, "Actor3", "female", "1990-02-20", "", "", "", "{}", updatedAt, nil)
 
 				mock.ExpectQuery(
-					"SELECT id, name, gender, birth_date FROM actors;",
+					"SELECT id, name, gender, birth_date, " + actorProfile + ", updated_at, " + actorPhoto + " FROM actors WHERE deleted_at IS NULL;",
 				).WithArgs().WillReturnRows(rows)
 			},
 			want: []models.Actor{
-				{Id: 1, Name: "Actor1", Gender: "M", BirthDate: "1980-01-12"},
-				{Id: 2, Name: "Actor2", Gender: "M", BirthDate: "1990-02-20"},
-				{Id: 3, Name: "Actor3", Gender: "F", BirthDate: "1990-02-20"},
+				{Id: 1, Name: "Actor1", Gender: "male", BirthDate: "1980-01-12", UpdatedAt: updatedAt, Aliases: []string{}},
+				{Id: 2, Name: "Actor2", Gender: "male", BirthDate: "1990-02-20", UpdatedAt: updatedAt, Aliases: []string{}},
+				{Id: 3, Name: "Actor3", Gender: "female", BirthDate: "1990-02-20", UpdatedAt: updatedAt, Aliases: []string{}},
 			},
 		},
 		{
 			name: "No Records",
 			mock: func() {
 				rows := sqlmock.NewRows(
-					[]string{"id", "name", "gender", "birth_date"})
+					[]string{"id", "name", "gender", "birth_date", "death_date", "birthplace", "biography", "aliases", "updated_at", "photo"})
 
 				mock.ExpectQuery(
-					"SELECT id, name, gender, birth_date FROM actors;",
+					"SELECT id, name, gender, birth_date, " + actorProfile + ", updated_at, " + actorPhoto + " FROM actors WHERE deleted_at IS NULL;",
 				).WithArgs().WillReturnRows(rows)
 			},
 			want: []models.Actor{},
@@ -163,6 +175,7 @@ func TestActorFind(t *testing.T) {
 	defer db.Close()
 
 	r := New(db)
+	updatedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
 
 	type args struct {
 		id int
@@ -178,17 +191,17 @@ func TestActorFind(t *testing.T) {
 			name: "Ok",
 			mock: func(args args) {
 				rows := sqlmock.NewRows([]string{
-					"id", "name", "gender", "birth_date",
-				}).AddRow(1, "Name 1", "M", "1980-01-01")
+					"id", "name", "gender", "birth_date", "death_date", "birthplace", "biography", "aliases", "updated_at", "photo",
+				}).AddRow(1, "Name 1", "male", "1980-01-01", "", "", "", "{}", updatedAt, nil)
 				mock.ExpectQuery( // regexp.QuoteMeta( -- also works
-					"SELECT id, name, gender, birth_date FROM actors WHERE id = $1;",
+					"SELECT id, name, gender, birth_date, " + actorProfile + ", updated_at, " + actorPhoto + " FROM actors WHERE id = $1 AND deleted_at IS NULL;",
 				).WithArgs(args.id).WillReturnRows(rows)
 			},
 			input: args{
 				id: 1,
 			},
 			want: models.Actor{
-				Id: 1, Name: "Name 1", Gender: "M", BirthDate: "1980-01-01",
+				Id: 1, Name: "Name 1", Gender: "male", BirthDate: "1980-01-01", UpdatedAt: updatedAt, Aliases: []string{},
 			},
 		},
 		{
@@ -197,7 +210,7 @@ func TestActorFind(t *testing.T) {
 			mock: func(args args) {
 				// regexp.QuoteMeta -- also works
 				mock.ExpectQuery( // regexp.QuoteMeta(
-					"SELECT id, name, gender, birth_date FROM actors WHERE id = $1;",
+					"SELECT id, name, gender, birth_date, " + actorProfile + ", updated_at, " + actorPhoto + " FROM actors WHERE id = $1 AND deleted_at IS NULL;",
 				).WithArgs(args.id).WillReturnError(ErrResourceNotFound)
 			},
 			// want:    &models.Film{},
@@ -234,6 +247,8 @@ func TestActorDelete(t *testing.T) {
 
 	r := New(db)
 
+	updatedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
 	type args struct {
 		id int
 	}
@@ -249,8 +264,14 @@ func TestActorDelete(t *testing.T) {
 				id: 1,
 			},
 			mock: func(args args) {
-				mock.ExpectExec("DELETE FROM actors WHERE id=$1;").
+				mock.ExpectBegin()
+				mock.ExpectQuery(actorLock).WithArgs(args.id).
+					WillReturnRows(sqlmock.NewRows(actorColumns).AddRow(args.id, "Name 1", "male", "1995-01-12", "", "", "", "{}", updatedAt))
+				mock.ExpectExec("UPDATE actors SET deleted_at=now(), updated_at=now() WHERE id=$1;").
 					WithArgs(args.id).WillReturnResult(sqlmock.NewResult(0, 1))
+				expectAudit(mock, store.EntityActor, args.id, store.ActionDelete,
+					`{"id":1,"name":"Name 1","gender":"male","birth_date":"1995-01-12"}`, "")
+				mock.ExpectCommit()
 			},
 		},
 		{
@@ -259,8 +280,9 @@ func TestActorDelete(t *testing.T) {
 				id: 404,
 			},
 			mock: func(args args) {
-				mock.ExpectExec("DELETE FROM actors WHERE id=$1;").
-					WithArgs(args.id).WillReturnError(sql.ErrNoRows)
+				mock.ExpectBegin()
+				mock.ExpectQuery(actorLock).WithArgs(args.id).WillReturnError(sql.ErrNoRows)
+				mock.ExpectRollback()
 			},
 			wantErr: true,
 		},
@@ -290,10 +312,12 @@ func TestActorUpdate(t *testing.T) {
 
 	r := New(db)
 
+	updatedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
 	updatedActor := models.Actor{
 		Id:        1,
 		Name:      "Name 1",
-		Gender:    "M",
+		Gender:    "male",
 		BirthDate: "1995-01-12",
 	}
 
@@ -315,14 +339,20 @@ func TestActorUpdate(t *testing.T) {
 				actor: updatedActor,
 			},
 			mock: func(args args, a *models.Actor) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(actorLock).WithArgs(args.id).
+					WillReturnRows(sqlmock.NewRows(actorColumns).AddRow(args.id, "Name 1", "female", "1995-01-12", "", "", "", "{}", updatedAt))
 				mock.ExpectExec(
-					"UPDATE actors SET name=$1, gender=$2, birth_date=$3 WHERE id=$4;",
+					"UPDATE actors SET name=$1, gender=$2, birth_date=$3, death_date=NULLIF($4, '')::date, birthplace=NULLIF($5, ''), biography=NULLIF($6, ''), aliases=$7, updated_at=now() WHERE id=$8;",
 				).WithArgs(
 					args.actor.Name,
 					args.actor.Gender,
 					args.actor.BirthDate,
+					"", "", "", "{}",
 					args.id,
 				).WillReturnResult(sqlmock.NewResult(0, 1))
+				expectAudit(mock, store.EntityActor, args.id, store.ActionUpdate, `{"gender":"female"}`, `{"gender":"male"}`)
+				mock.ExpectCommit()
 			},
 			want: &updatedActor,
 		},
@@ -345,3 +375,50 @@ func TestActorUpdate(t *testing.T) {
 		})
 	}
 }
+
+func TestActor_Restore(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := New(db)
+
+	updatedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("UPDATE actors SET deleted_at=NULL, updated_at=now() WHERE id=$1 AND deleted_at IS NOT NULL RETURNING id, name, gender, to_char(birth_date, 'YYYY-MM-DD'), " + actorProfile + ", updated_at;").
+		WithArgs(1).WillReturnRows(sqlmock.NewRows(actorColumns).AddRow(1, "Name 1", "male", "1995-01-12", "", "", "", "{}", updatedAt))
+	expectAudit(mock, store.EntityActor, 1, store.ActionRestore, "",
+		`{"id":1,"name":"Name 1","gender":"male","birth_date":"1995-01-12"}`)
+	mock.ExpectCommit()
+
+	assert.NoError(t, r.ActorRepo().Restore(1))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestActor_FindByName(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := New(db)
+	updatedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	columns := append(append([]string{}, actorColumns...), "photo", "deleted_at")
+	mock.ExpectQuery("SELECT id, name, gender, birth_date, " + actorProfile + ", updated_at, " + actorPhoto + ", deleted_at FROM actors WHERE deleted_at IS NULL AND (name ILIKE $1 OR EXISTS (SELECT 1 FROM unnest(aliases) alias WHERE alias ILIKE $1)) ORDER BY id;").
+		WithArgs(`%the\_rock%`).
+		WillReturnRows(sqlmock.NewRows(columns).
+			AddRow(1, "Dwayne Johnson", "male", "1972-05-02", "", "Hayward", "", "{The_Rock}", updatedAt, nil, nil))
+
+	got, err := r.ActorRepo().FindBy(store.ActorFilter{Name: "the_rock"})
+	assert.NoError(t, err)
+	assert.Equal(t, []models.Actor{{
+		Id: 1, Name: "Dwayne Johnson", Gender: "male", BirthDate: "1972-05-02", Birthplace: "Hayward",
+		Aliases: []string{"The_Rock"}, UpdatedAt: updatedAt,
+	}}, got)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,167 @@
+package sqlstore
+
+import (
+	"database/sql"
+	"encoding/json"
+	"reflect"
+
+	"filmoteka/internal/app/models"
+	"filmoteka/internal/app/store"
+)
+
+type AuditRepository struct {
+	store *Store
+}
+
+func (r *AuditRepository) Find(filter store.AuditFilter) ([]models.AuditEntry, error) {
+	query, args := auditFilterQuery(filter)
+
+	var entries []models.AuditEntry
+	err := r.store.retry(true, func() error {
+		rows, err := r.store.reader().Query(query, args...)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		entries = make([]models.AuditEntry, 0)
+		for rows.Next() {
+			var (
+				e             models.AuditEntry
+				userID        sql.NullInt64
+				before, after []byte
+			)
+			if err := rows.Scan(
+				&e.Id,
+				&userID,
+				&e.RequestID,
+				&e.Entity,
+				&e.EntityID,
+				&e.Action,
+				&before,
+				&after,
+				&e.CreatedAt,
+			); err != nil {
+				return err
+			}
+			if userID.Valid {
+				id := int(userID.Int64)
+				e.UserID = &id
+			}
+			e.Before = rawJSON(before)
+			e.After = rawJSON(after)
+			entries = append(entries, e)
+		}
+
+		return rows.Err()
+	})
+
+	return entries, err
+}
+
+// inTx runs fn in a transaction on the primary, committing when fn
+// returns nil.
+func (s *Store) inTx(fn func(*sql.Tx) error) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// audit records a change of the entity made in tx. before is nil for
+// created rows and after is nil for deleted ones; otherwise only the
+// fields that differ are kept.
+func (s *Store) audit(tx *sql.Tx, entity string, id int, action string, before, after any) error {
+	b, a, err := diff(before, after)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(
+		"INSERT INTO audit_log (user_id, request_id, entity, entity_id, action, before, after) VALUES ($1, $2, $3, $4, $5, $6, $7);",
+		s.principalID(),
+		s.principal.RequestID,
+		entity,
+		id,
+		action,
+		jsonArg(b),
+		jsonArg(a),
+	)
+
+	return err
+}
+
+// principalID is the user id recorded in the audit log, NULL when the
+// change is not made on behalf of a user.
+func (s *Store) principalID() sql.NullInt64 {
+	return sql.NullInt64{Int64: int64(s.principal.UserID), Valid: s.principal.UserID != 0}
+}
+
+// diff returns the JSON of before and after restricted to the fields
+// whose values differ. A nil side is returned as SQL NULL and the other
+// side is kept whole.
+func diff(before, after any) (b, a []byte, err error) {
+	if before == nil || after == nil {
+		if before != nil {
+			b, err = json.Marshal(before)
+		}
+		if after != nil {
+			a, err = json.Marshal(after)
+		}
+		return b, a, err
+	}
+
+	bm, err := fields(before)
+	if err != nil {
+		return nil, nil, err
+	}
+	am, err := fields(after)
+	if err != nil {
+		return nil, nil, err
+	}
+	for k, v := range bm {
+		if av, ok := am[k]; ok && reflect.DeepEqual(v, av) {
+			delete(bm, k)
+			delete(am, k)
+		}
+	}
+
+	if b, err = json.Marshal(bm); err != nil {
+		return nil, nil, err
+	}
+	a, err = json.Marshal(am)
+
+	return b, a, err
+}
+
+func fields(v any) (map[string]any, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	m := map[string]any{}
+	err = json.Unmarshal(raw, &m)
+
+	return m, err
+}
+
+// jsonArg passes JSON as text, pq would send []byte as bytea.
+func jsonArg(b []byte) sql.NullString {
+	return sql.NullString{String: string(b), Valid: b != nil}
+}
+
+func rawJSON(b []byte) json.RawMessage {
+	if b == nil {
+		return nil
+	}
+
+	return json.RawMessage(b)
+}
@@ -0,0 +1,146 @@
+package sqlstore
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	"filmoteka/internal/app/models"
+	"filmoteka/internal/app/store"
+)
+
+const auditInsert = "INSERT INTO audit_log (user_id, request_id, entity, entity_id, action, before, after) VALUES ($1, $2, $3, $4, $5, $6, $7);"
+
+// jsonMatch matches a JSON column argument regardless of key order, an
+// empty string matching SQL NULL.
+type jsonMatch string
+
+func (j jsonMatch) Match(v driver.Value) bool {
+	if j == "" {
+		return v == nil
+	}
+	s, ok := v.(string)
+	if !ok {
+		return false
+	}
+
+	var want, got any
+	if json.Unmarshal([]byte(j), &want) != nil || json.Unmarshal([]byte(s), &got) != nil {
+		return false
+	}
+
+	return reflect.DeepEqual(want, got)
+}
+
+func expectAudit(mock sqlmock.Sqlmock, entity string, id int, action string, before, after jsonMatch) {
+	mock.ExpectExec(auditInsert).
+		WithArgs(nil, "", entity, id, action, before, after).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+}
+
+func TestDiff(t *testing.T) {
+	before := models.Film{Id: 1, Name: "Film 1", Description: "Descr 1", ReleaseYear: 2001, Rating: 5}
+	after := before
+	after.Rating = 7
+
+	tests := []struct {
+		name       string
+		before     any
+		after      any
+		wantBefore string
+		wantAfter  string
+	}{
+		{
+			name:      "Created",
+			after:     before,
+			wantAfter: `{"id":1,"name":"Film 1","description":"Descr 1","release_year":2001,"rating":5}`,
+		},
+		{
+			name:       "Deleted",
+			before:     before,
+			wantBefore: `{"id":1,"name":"Film 1","description":"Descr 1","release_year":2001,"rating":5}`,
+		},
+		{
+			name:       "Changed fields only",
+			before:     before,
+			after:      after,
+			wantBefore: `{"rating":5}`,
+			wantAfter:  `{"rating":7}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, a, err := diff(tt.before, tt.after)
+			assert.NoError(t, err)
+			assert.True(t, jsonMatch(tt.wantBefore).Match(nullable(b)), string(b))
+			assert.True(t, jsonMatch(tt.wantAfter).Match(nullable(a)), string(a))
+		})
+	}
+}
+
+func nullable(b []byte) driver.Value {
+	if b == nil {
+		return nil
+	}
+
+	return string(b)
+}
+
+func TestStore_As(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	s := New(db).As(store.Principal{UserID: 7, RequestID: "req-1"})
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(actorInsert).
+		WithArgs("Name 1", "female", "1980-01-01", "", "", "", "{}").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+	mock.ExpectExec(auditInsert).
+		WithArgs(7, "req-1", store.EntityActor, 3, store.ActionCreate, jsonMatch(""),
+			jsonMatch(`{"id":3,"name":"Name 1","gender":"female","birth_date":"1980-01-01"}`)).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	id, err := s.ActorRepo().Create(models.Actor{Name: "Name 1", Gender: "female", BirthDate: "1980-01-01"})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, id)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAudit_Find(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := New(db)
+
+	createdAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	columns := []string{"id", "user_id", "request_id", "entity", "entity_id", "action", "before", "after", "created_at"}
+	mock.ExpectQuery("SELECT id, user_id, request_id, entity, entity_id, action, before, after, created_at FROM audit_log WHERE entity = $1 AND entity_id = $2 ORDER BY id;").
+		WithArgs(store.EntityFilm, 1).
+		WillReturnRows(sqlmock.NewRows(columns).
+			AddRow(1, nil, "", "film", 1, "create", nil, []byte(`{"id":1}`), createdAt).
+			AddRow(2, 7, "req-1", "film", 1, "update", []byte(`{"rating":5}`), []byte(`{"rating":7}`), createdAt))
+
+	got, err := r.AuditRepo().Find(store.AuditFilter{Entity: store.EntityFilm, EntityID: 1})
+	assert.NoError(t, err)
+
+	user := 7
+	assert.Equal(t, []models.AuditEntry{
+		{Id: 1, RequestID: "", Entity: "film", EntityID: 1, Action: "create", After: json.RawMessage(`{"id":1}`), CreatedAt: createdAt},
+		{Id: 2, UserID: &user, RequestID: "req-1", Entity: "film", EntityID: 1, Action: "update", Before: json.RawMessage(`{"rating":5}`), After: json.RawMessage(`{"rating":7}`), CreatedAt: createdAt},
+	}, got)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
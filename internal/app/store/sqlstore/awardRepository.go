@@ -0,0 +1,173 @@
+package sqlstore
+
+import (
+	"database/sql"
+
+	"filmoteka/internal/app/models"
+)
+
+const nominationSelect = "SELECT n.id, n.award_id, aw.ceremony, aw.year, aw.category, n.film_id, f.name, COALESCE(n.actor_id, 0), COALESCE(a.name, ''), n.won " +
+	"FROM nominations n JOIN awards aw ON aw.id = n.award_id JOIN films f ON f.id = n.film_id LEFT JOIN actors a ON a.id = n.actor_id"
+
+type AwardRepository struct {
+	store *Store
+}
+
+func (r *AwardRepository) Create(a models.Award) (int, error) {
+	if err := a.Validate(); err != nil {
+		return 0, ErrValidation
+	}
+
+	var id int
+	if err := r.store.retry(false, func() error {
+		return r.store.db.QueryRow(
+			"INSERT INTO awards (ceremony, year, category) VALUES ($1, $2, $3) RETURNING id;",
+			a.Ceremony,
+			a.Year,
+			a.Category,
+		).Scan(&id)
+	}); err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (r *AwardRepository) FindAll() ([]models.Award, error) {
+	var awards []models.Award
+	err := r.store.retry(true, func() error {
+		rows, err := r.store.reader().Query("SELECT id, ceremony, year, category FROM awards ORDER BY year DESC, ceremony, category;")
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		awards = make([]models.Award, 0)
+		for rows.Next() {
+			a := models.Award{}
+			if err := rows.Scan(&a.Id, &a.Ceremony, &a.Year, &a.Category); err != nil {
+				return err
+			}
+			awards = append(awards, a)
+		}
+
+		return rows.Err()
+	})
+
+	return awards, err
+}
+
+func (r *AwardRepository) Delete(id int) error {
+	return r.exec("DELETE FROM awards WHERE id=$1;", id)
+}
+
+func (r *AwardRepository) Nominate(n models.Nomination) (int, error) {
+	if err := n.Validate(); err != nil {
+		return 0, ErrValidation
+	}
+
+	var id int
+	err := r.store.retry(false, func() error {
+		return r.store.db.QueryRow(
+			"INSERT INTO nominations (award_id, film_id, actor_id, won) SELECT $1, $2, NULLIF($3, 0), $4 WHERE EXISTS (SELECT 1 FROM awards WHERE id=$1) "+
+				"AND EXISTS (SELECT 1 FROM films WHERE id=$2 AND deleted_at IS NULL) AND ($3 = 0 OR EXISTS (SELECT 1 FROM actors WHERE id=$3 AND deleted_at IS NULL)) RETURNING id;",
+			n.AwardID,
+			n.FilmID,
+			n.ActorID,
+			n.Won,
+		).Scan(&id)
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, ErrResourceNotFound
+		}
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (r *AwardRepository) SetWon(nominationID int, won bool) error {
+	return r.exec("UPDATE nominations SET won=$2 WHERE id=$1;", nominationID, won)
+}
+
+func (r *AwardRepository) RemoveNomination(nominationID int) error {
+	return r.exec("DELETE FROM nominations WHERE id=$1;", nominationID)
+}
+
+func (r *AwardRepository) ByFilm(filmID int) ([]models.Nomination, error) {
+	return r.list(
+		"films",
+		filmID,
+		nominationSelect+" WHERE n.film_id=$1 AND a.deleted_at IS NULL ORDER BY aw.year DESC, aw.ceremony, aw.category, a.name NULLS FIRST;",
+	)
+}
+
+func (r *AwardRepository) ByActor(actorID int) ([]models.Nomination, error) {
+	return r.list(
+		"actors",
+		actorID,
+		nominationSelect+" WHERE n.actor_id=$1 AND f.deleted_at IS NULL ORDER BY aw.year DESC, aw.ceremony, aw.category;",
+	)
+}
+
+// exec runs a statement changing a single row, failing with
+// ErrResourceNotFound when there is none. A lost connection is not
+// replayed: a repeated delete would report the row it removed as missing.
+func (r *AwardRepository) exec(query string, args ...any) error {
+	var result sql.Result
+	if err := r.store.retry(false, func() (err error) {
+		result, err = r.store.db.Exec(query, args...)
+		return err
+	}); err != nil {
+		return err
+	}
+
+	affectedRows, err := result.RowsAffected()
+	if err != nil {
+		return translateError(err)
+	}
+	if affectedRows == 0 {
+		return ErrResourceNotFound
+	}
+
+	return nil
+}
+
+// list runs query for the row id of table, failing with
+// ErrResourceNotFound when the row is missing or deleted.
+func (r *AwardRepository) list(table string, id int, query string) ([]models.Nomination, error) {
+	var nominations []models.Nomination
+	err := r.store.retry(true, func() error {
+		db := r.store.reader()
+		var found bool
+		if err := db.QueryRow(
+			"SELECT EXISTS (SELECT 1 FROM "+table+" WHERE id=$1 AND deleted_at IS NULL);",
+			id,
+		).Scan(&found); err != nil {
+			return err
+		}
+		if !found {
+			return ErrResourceNotFound
+		}
+
+		rows, err := db.Query(query, id)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		nominations = make([]models.Nomination, 0)
+		for rows.Next() {
+			n := models.Nomination{}
+			if err := rows.Scan(&n.Id, &n.AwardID, &n.Ceremony, &n.Year, &n.Category, &n.FilmID, &n.Film, &n.ActorID, &n.Actor, &n.Won); err != nil {
+				return err
+			}
+			nominations = append(nominations, n)
+		}
+
+		return rows.Err()
+	})
+
+	return nominations, err
+}
@@ -0,0 +1,127 @@
+package sqlstore
+
+import (
+	"testing"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	"filmoteka/internal/app/models"
+	"filmoteka/internal/app/store"
+)
+
+func TestAward_Nominate(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := New(db)
+	query := "INSERT INTO nominations (award_id, film_id, actor_id, won) SELECT $1, $2, NULLIF($3, 0), $4 WHERE EXISTS (SELECT 1 FROM awards WHERE id=$1) " +
+		"AND EXISTS (SELECT 1 FROM films WHERE id=$2 AND deleted_at IS NULL) AND ($3 = 0 OR EXISTS (SELECT 1 FROM actors WHERE id=$3 AND deleted_at IS NULL)) RETURNING id;"
+
+	tests := []struct {
+		name    string
+		input   models.Nomination
+		mock    func()
+		want    int
+		wantErr error
+	}{
+		{
+			name:  "Film",
+			input: models.Nomination{AwardID: 1, FilmID: 2, Won: true},
+			mock: func() {
+				mock.ExpectQuery(query).WithArgs(1, 2, 0, true).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+			},
+			want: 7,
+		},
+		{
+			name:  "Actor",
+			input: models.Nomination{AwardID: 3, FilmID: 2, ActorID: 5},
+			mock: func() {
+				mock.ExpectQuery(query).WithArgs(3, 2, 5, false).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(8))
+			},
+			want: 8,
+		},
+		{
+			name:  "Deleted actor",
+			input: models.Nomination{AwardID: 3, FilmID: 2, ActorID: 9},
+			mock: func() {
+				mock.ExpectQuery(query).WithArgs(3, 2, 9, false).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+			},
+			wantErr: store.ErrResourceNotFound,
+		},
+		{
+			name:    "No film",
+			input:   models.Nomination{AwardID: 1},
+			mock:    func() {},
+			wantErr: store.ErrValidation,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+
+			got, err := r.AwardRepo().Nominate(tt.input)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestAward_SetWon(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := New(db)
+	query := "UPDATE nominations SET won=$2 WHERE id=$1;"
+
+	mock.ExpectExec(query).WithArgs(7, true).WillReturnResult(sqlmock.NewResult(0, 1))
+	assert.NoError(t, r.AwardRepo().SetWon(7, true))
+
+	mock.ExpectExec(query).WithArgs(9, true).WillReturnResult(sqlmock.NewResult(0, 0))
+	assert.ErrorIs(t, r.AwardRepo().SetWon(9, true), store.ErrResourceNotFound)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAward_ByFilm(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := New(db)
+	columns := []string{"id", "award_id", "ceremony", "year", "category", "film_id", "name", "actor_id", "actor", "won"}
+
+	mock.ExpectQuery("SELECT EXISTS (SELECT 1 FROM films WHERE id=$1 AND deleted_at IS NULL);").
+		WithArgs(2).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	mock.ExpectQuery(nominationSelect + " WHERE n.film_id=$1 AND a.deleted_at IS NULL ORDER BY aw.year DESC, aw.ceremony, aw.category, a.name NULLS FIRST;").
+		WithArgs(2).
+		WillReturnRows(sqlmock.NewRows(columns).
+			AddRow(7, 1, "Academy Awards", 1995, "Best Picture", 2, "Forrest Gump", 0, "", true).
+			AddRow(8, 3, "Academy Awards", 1995, "Best Actor", 2, "Forrest Gump", 5, "Tom Hanks", true))
+
+	got, err := r.AwardRepo().ByFilm(2)
+	assert.NoError(t, err)
+	assert.Equal(t, []models.Nomination{
+		{Id: 7, AwardID: 1, Ceremony: "Academy Awards", Year: 1995, Category: "Best Picture", FilmID: 2, Film: "Forrest Gump", Won: true},
+		{Id: 8, AwardID: 3, Ceremony: "Academy Awards", Year: 1995, Category: "Best Actor", FilmID: 2, Film: "Forrest Gump", ActorID: 5, Actor: "Tom Hanks", Won: true},
+	}, got)
+
+	mock.ExpectQuery("SELECT EXISTS (SELECT 1 FROM actors WHERE id=$1 AND deleted_at IS NULL);").
+		WithArgs(9).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+
+	_, err = r.AwardRepo().ByActor(9)
+	assert.ErrorIs(t, err, store.ErrResourceNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,338 @@
+package sqlstore
+
+import (
+	"database/sql"
+
+	"github.com/lib/pq"
+
+	"filmoteka/internal/app/models"
+	"filmoteka/internal/app/store"
+)
+
+// collectionFields selects the collection columns in the order
+// collectionDest scans them, from collections aliased c.
+const collectionFields = "c.id, c.title, COALESCE(c.description, ''), c.owner_id, c.visibility::text, c.created_at, c.updated_at"
+
+type CollectionRepository struct {
+	store *Store
+}
+
+func collectionDest(c *models.Collection) []any {
+	return []any{
+		&c.Id,
+		&c.Title,
+		&c.Description,
+		&c.OwnerID,
+		&c.Visibility,
+		&c.CreatedAt,
+		&c.UpdatedAt,
+	}
+}
+
+func (r *CollectionRepository) Create(c models.Collection) (int, error) {
+	if err := c.Validate(); err != nil {
+		return 0, ErrValidation
+	}
+
+	var id int
+	if err := r.store.retry(false, func() error {
+		return r.store.db.QueryRow(
+			"INSERT INTO collections (title, description, owner_id, visibility) VALUES ($1, NULLIF($2, ''), $3, $4::collection_visibility) RETURNING id;",
+			c.Title,
+			c.Description,
+			c.OwnerID,
+			c.Visibility,
+		).Scan(&id)
+	}); err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (r *CollectionRepository) Find(id int) (models.Collection, error) {
+	c := models.Collection{}
+	err := r.store.retry(true, func() error {
+		db := r.store.reader()
+		if err := db.QueryRow(
+			"SELECT "+collectionFields+" FROM collections c WHERE c.id=$1;",
+			id,
+		).Scan(collectionDest(&c)...); err != nil {
+			return err
+		}
+
+		rows, err := db.Query(
+			"SELECT cf.position, f.id, f.name, f.release_year FROM collection_films cf JOIN films f ON f.id = cf.film_id WHERE cf.collection_id=$1 AND f.deleted_at IS NULL ORDER BY cf.position;",
+			id,
+		)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		c.Films = make([]models.CollectionFilm, 0)
+		for rows.Next() {
+			cf := models.CollectionFilm{}
+			if err := rows.Scan(&cf.Position, &cf.FilmID, &cf.Name, &cf.ReleaseYear); err != nil {
+				return err
+			}
+			c.Films = append(c.Films, cf)
+		}
+
+		return rows.Err()
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return models.Collection{}, ErrResourceNotFound
+		}
+		return models.Collection{}, err
+	}
+
+	return c, nil
+}
+
+func (r *CollectionRepository) FindBy(filter store.CollectionFilter) ([]models.Collection, error) {
+	query, args := collectionFilterQuery(filter)
+
+	var collections []models.Collection
+	err := r.store.retry(true, func() error {
+		rows, err := r.store.reader().Query(query, args...)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		collections = make([]models.Collection, 0)
+		for rows.Next() {
+			c := models.Collection{}
+			if err := rows.Scan(collectionDest(&c)...); err != nil {
+				return err
+			}
+			collections = append(collections, c)
+		}
+
+		return rows.Err()
+	})
+
+	return collections, err
+}
+
+func (r *CollectionRepository) Update(c models.Collection) error {
+	if err := c.Validate(); err != nil {
+		return ErrValidation
+	}
+
+	var result sql.Result
+	if err := r.store.retry(true, func() (err error) {
+		result, err = r.store.db.Exec(
+			"UPDATE collections SET title=$1, description=NULLIF($2, ''), visibility=$3::collection_visibility, updated_at=now() WHERE id=$4;",
+			c.Title,
+			c.Description,
+			c.Visibility,
+			c.Id,
+		)
+		return err
+	}); err != nil {
+		return err
+	}
+
+	updatedRows, err := result.RowsAffected()
+	if err != nil {
+		return translateError(err)
+	}
+	if updatedRows == 0 {
+		return ErrResourceNotFound
+	}
+
+	return nil
+}
+
+func (r *CollectionRepository) Delete(id int) error {
+	var result sql.Result
+	if err := r.store.retry(false, func() (err error) {
+		result, err = r.store.db.Exec("DELETE FROM collections WHERE id=$1;", id)
+		return err
+	}); err != nil {
+		return err
+	}
+
+	deletedRows, err := result.RowsAffected()
+	if err != nil {
+		return translateError(err)
+	}
+	if deletedRows == 0 {
+		return ErrResourceNotFound
+	}
+
+	return nil
+}
+
+func (r *CollectionRepository) Place(collectionID, filmID, position int) (int, error) {
+	if position < 0 {
+		return 0, ErrValidation
+	}
+
+	var placed int
+	err := r.store.retry(true, func() error {
+		return r.store.inTx(func(tx *sql.Tx) error {
+			if err := lockCollection(tx, collectionID); err != nil {
+				return err
+			}
+			if err := lockFilm(tx, filmID); err != nil {
+				return err
+			}
+
+			var count, current int
+			if err := tx.QueryRow(
+				"SELECT count(*), COALESCE(max(position) FILTER (WHERE film_id=$2), 0) FROM collection_films WHERE collection_id=$1;",
+				collectionID,
+				filmID,
+			).Scan(&count, &current); err != nil {
+				return err
+			}
+
+			last := count
+			if current == 0 {
+				last++
+			}
+			placed = position
+			if placed == 0 || placed > last {
+				placed = last
+			}
+
+			var err error
+			switch {
+			case current == 0:
+				if _, err = tx.Exec(
+					"UPDATE collection_films SET position=position+1 WHERE collection_id=$1 AND position >= $2;",
+					collectionID,
+					placed,
+				); err != nil {
+					return err
+				}
+				_, err = tx.Exec(
+					"INSERT INTO collection_films (collection_id, film_id, position) VALUES ($1, $2, $3);",
+					collectionID,
+					filmID,
+					placed,
+				)
+			case placed < current:
+				_, err = tx.Exec(
+					"UPDATE collection_films SET position = CASE WHEN film_id=$2 THEN $3 ELSE position+1 END WHERE collection_id=$1 AND position BETWEEN $3 AND $4;",
+					collectionID,
+					filmID,
+					placed,
+					current,
+				)
+			case placed > current:
+				_, err = tx.Exec(
+					"UPDATE collection_films SET position = CASE WHEN film_id=$2 THEN $3 ELSE position-1 END WHERE collection_id=$1 AND position BETWEEN $4 AND $3;",
+					collectionID,
+					filmID,
+					placed,
+					current,
+				)
+			}
+			if err != nil {
+				return err
+			}
+
+			return touchCollection(tx, collectionID)
+		})
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return placed, nil
+}
+
+func (r *CollectionRepository) RemoveFilm(collectionID, filmID int) error {
+	return r.store.retry(false, func() error {
+		return r.store.inTx(func(tx *sql.Tx) error {
+			if err := lockCollection(tx, collectionID); err != nil {
+				return err
+			}
+
+			var position int
+			err := tx.QueryRow(
+				"DELETE FROM collection_films WHERE collection_id=$1 AND film_id=$2 RETURNING position;",
+				collectionID,
+				filmID,
+			).Scan(&position)
+			switch {
+			case err == sql.ErrNoRows:
+				return ErrResourceNotFound
+			case err != nil:
+				return err
+			}
+
+			if _, err := tx.Exec(
+				"UPDATE collection_films SET position=position-1 WHERE collection_id=$1 AND position > $2;",
+				collectionID,
+				position,
+			); err != nil {
+				return err
+			}
+
+			return touchCollection(tx, collectionID)
+		})
+	})
+}
+
+func (r *CollectionRepository) Reorder(collectionID int, filmIDs []int) error {
+	seen := make(map[int]bool, len(filmIDs))
+	for _, id := range filmIDs {
+		if seen[id] {
+			return ErrValidation
+		}
+		seen[id] = true
+	}
+
+	return r.store.retry(true, func() error {
+		return r.store.inTx(func(tx *sql.Tx) error {
+			if err := lockCollection(tx, collectionID); err != nil {
+				return err
+			}
+
+			var members int
+			if err := tx.QueryRow(
+				"SELECT count(*) FROM collection_films WHERE collection_id=$1 AND film_id = ANY($2);",
+				collectionID,
+				pq.Array(filmIDs),
+			).Scan(&members); err != nil {
+				return err
+			}
+			if members != len(filmIDs) {
+				return ErrValidation
+			}
+
+			if _, err := tx.Exec(
+				"UPDATE collection_films cf SET position = o.n FROM (SELECT film_id, row_number() OVER (ORDER BY array_position($2::int[], film_id) NULLS LAST, position) AS n FROM collection_films WHERE collection_id=$1) o WHERE cf.collection_id=$1 AND cf.film_id = o.film_id;",
+				collectionID,
+				pq.Array(filmIDs),
+			); err != nil {
+				return err
+			}
+
+			return touchCollection(tx, collectionID)
+		})
+	})
+}
+
+// lockCollection locks the collection row until tx ends, so concurrent
+// changes of its membership renumber positions one after another.
+func lockCollection(tx *sql.Tx, id int) error {
+	var one int
+	err := tx.QueryRow("SELECT 1 FROM collections WHERE id=$1 FOR UPDATE;", id).Scan(&one)
+	if err == sql.ErrNoRows {
+		return ErrResourceNotFound
+	}
+
+	return err
+}
+
+func touchCollection(tx *sql.Tx, id int) error {
+	_, err := tx.Exec("UPDATE collections SET updated_at=now() WHERE id=$1;", id)
+	return err
+}
@@ -0,0 +1,215 @@
+package sqlstore
+
+import (
+	"testing"
+	"time"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+
+	"filmoteka/internal/app/models"
+	"filmoteka/internal/app/store"
+)
+
+const (
+	collectionLock    = "SELECT 1 FROM collections WHERE id=$1 FOR UPDATE;"
+	collectionMembers = "SELECT count(*), COALESCE(max(position) FILTER (WHERE film_id=$2), 0) FROM collection_films WHERE collection_id=$1;"
+	collectionTouch   = "UPDATE collections SET updated_at=now() WHERE id=$1;"
+)
+
+var collectionColumns = []string{"id", "title", "description", "owner_id", "visibility", "created_at", "updated_at"}
+
+func TestCollection_Find(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := New(db)
+	at := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery("SELECT " + collectionFields + " FROM collections c WHERE c.id=$1;").
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows(collectionColumns).AddRow(1, "Best of 1990s", "", 2, "public", at, at))
+	mock.ExpectQuery("SELECT cf.position, f.id, f.name, f.release_year FROM collection_films cf JOIN films f ON f.id = cf.film_id WHERE cf.collection_id=$1 AND f.deleted_at IS NULL ORDER BY cf.position;").
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"position", "id", "name", "release_year"}).
+			AddRow(1, 7, "Film 7", 1994).
+			AddRow(3, 5, "Film 5", 1999))
+
+	got, err := r.CollectionRepo().Find(1)
+	assert.NoError(t, err)
+	assert.Equal(t, models.Collection{
+		Id: 1, Title: "Best of 1990s", OwnerID: 2, Visibility: "public", CreatedAt: at, UpdatedAt: at,
+		Films: []models.CollectionFilm{
+			{Position: 1, FilmID: 7, Name: "Film 7", ReleaseYear: 1994},
+			{Position: 3, FilmID: 5, Name: "Film 5", ReleaseYear: 1999},
+		},
+	}, got)
+
+	mock.ExpectQuery("SELECT " + collectionFields + " FROM collections c WHERE c.id=$1;").
+		WithArgs(9).
+		WillReturnRows(sqlmock.NewRows(collectionColumns))
+
+	_, err = r.CollectionRepo().Find(9)
+	assert.ErrorIs(t, err, store.ErrResourceNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCollection_FindBy(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := New(db)
+
+	mock.ExpectQuery("SELECT "+collectionFields+" FROM collections c WHERE (c.visibility = 'public' OR c.owner_id = $1) AND c.owner_id = $2 ORDER BY c.updated_at DESC, c.id DESC;").
+		WithArgs(2, 3).
+		WillReturnRows(sqlmock.NewRows(collectionColumns))
+	got, err := r.CollectionRepo().FindBy(store.CollectionFilter{OwnerID: 3, ViewerID: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, []models.Collection{}, got)
+
+	mock.ExpectQuery("SELECT " + collectionFields + " FROM collections c ORDER BY c.updated_at DESC, c.id DESC;").
+		WillReturnRows(sqlmock.NewRows(collectionColumns))
+	_, err = r.CollectionRepo().FindBy(store.CollectionFilter{IncludePrivate: true})
+	assert.NoError(t, err)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCollection_Place(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := New(db)
+	members := []string{"count", "max"}
+
+	tests := []struct {
+		name     string
+		film     int
+		position int
+		mock     func()
+		want     int
+		wantErr  error
+	}{
+		{
+			name:     "Append",
+			film:     7,
+			position: 0,
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(collectionLock).WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"?column?"}).AddRow(1))
+				mock.ExpectQuery(ratingLock).WithArgs(7).WillReturnRows(sqlmock.NewRows([]string{"?column?"}).AddRow(1))
+				mock.ExpectQuery(collectionMembers).WithArgs(1, 7).WillReturnRows(sqlmock.NewRows(members).AddRow(3, 0))
+				mock.ExpectExec("UPDATE collection_films SET position=position+1 WHERE collection_id=$1 AND position >= $2;").
+					WithArgs(1, 4).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec("INSERT INTO collection_films (collection_id, film_id, position) VALUES ($1, $2, $3);").
+					WithArgs(1, 7, 4).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(collectionTouch).WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			want: 4,
+		},
+		{
+			name:     "Move up",
+			film:     7,
+			position: 1,
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(collectionLock).WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"?column?"}).AddRow(1))
+				mock.ExpectQuery(ratingLock).WithArgs(7).WillReturnRows(sqlmock.NewRows([]string{"?column?"}).AddRow(1))
+				mock.ExpectQuery(collectionMembers).WithArgs(1, 7).WillReturnRows(sqlmock.NewRows(members).AddRow(4, 4))
+				mock.ExpectExec("UPDATE collection_films SET position = CASE WHEN film_id=$2 THEN $3 ELSE position+1 END WHERE collection_id=$1 AND position BETWEEN $3 AND $4;").
+					WithArgs(1, 7, 1, 4).WillReturnResult(sqlmock.NewResult(0, 4))
+				mock.ExpectExec(collectionTouch).WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			want: 1,
+		},
+		{
+			name:     "Move past the end",
+			film:     7,
+			position: 9,
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(collectionLock).WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"?column?"}).AddRow(1))
+				mock.ExpectQuery(ratingLock).WithArgs(7).WillReturnRows(sqlmock.NewRows([]string{"?column?"}).AddRow(1))
+				mock.ExpectQuery(collectionMembers).WithArgs(1, 7).WillReturnRows(sqlmock.NewRows(members).AddRow(4, 2))
+				mock.ExpectExec("UPDATE collection_films SET position = CASE WHEN film_id=$2 THEN $3 ELSE position-1 END WHERE collection_id=$1 AND position BETWEEN $4 AND $3;").
+					WithArgs(1, 7, 4, 2).WillReturnResult(sqlmock.NewResult(0, 3))
+				mock.ExpectExec(collectionTouch).WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			want: 4,
+		},
+		{
+			name:     "Deleted film",
+			film:     9,
+			position: 1,
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(collectionLock).WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"?column?"}).AddRow(1))
+				mock.ExpectQuery(ratingLock).WithArgs(9).WillReturnRows(sqlmock.NewRows([]string{"?column?"}))
+				mock.ExpectRollback()
+			},
+			wantErr: store.ErrResourceNotFound,
+		},
+		{
+			name:     "Negative position",
+			film:     7,
+			position: -1,
+			mock:     func() {},
+			wantErr:  store.ErrValidation,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+
+			got, err := r.CollectionRepo().Place(1, tt.film, tt.position)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestCollection_Reorder(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := New(db)
+	count := "SELECT count(*) FROM collection_films WHERE collection_id=$1 AND film_id = ANY($2);"
+
+	// the listed films go first, the others keep their order behind them
+	mock.ExpectBegin()
+	mock.ExpectQuery(collectionLock).WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"?column?"}).AddRow(1))
+	mock.ExpectQuery(count).WithArgs(1, pq.Array([]int{5, 7})).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+	mock.ExpectExec("UPDATE collection_films cf SET position = o.n FROM (SELECT film_id, row_number() OVER (ORDER BY array_position($2::int[], film_id) NULLS LAST, position) AS n FROM collection_films WHERE collection_id=$1) o WHERE cf.collection_id=$1 AND cf.film_id = o.film_id;").
+		WithArgs(1, pq.Array([]int{5, 7})).WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectExec(collectionTouch).WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	assert.NoError(t, r.CollectionRepo().Reorder(1, []int{5, 7}))
+
+	// films that are not members are rejected
+	mock.ExpectBegin()
+	mock.ExpectQuery(collectionLock).WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"?column?"}).AddRow(1))
+	mock.ExpectQuery(count).WithArgs(1, pq.Array([]int{5, 8})).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectRollback()
+	assert.ErrorIs(t, r.CollectionRepo().Reorder(1, []int{5, 8}), store.ErrValidation)
+
+	assert.ErrorIs(t, r.CollectionRepo().Reorder(1, []int{5, 5}), store.ErrValidation)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
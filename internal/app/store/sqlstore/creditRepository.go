@@ -0,0 +1,178 @@
+package sqlstore
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"filmoteka/internal/app/models"
+)
+
+// filmCredits selects the crew of a film by live people as JSON, read
+// with creditsDest.
+const filmCredits = "(SELECT COALESCE(json_agg(json_build_object('person_id', c.person_id, 'job', c.job) ORDER BY c.job, c.person_id), '[]') FROM credits c JOIN actors a ON a.id = c.person_id WHERE c.film_id = films.id AND a.deleted_at IS NULL)"
+
+// creditsColumn scans the filmCredits column.
+type creditsColumn struct {
+	credits *[]models.FilmCredit
+}
+
+func creditsDest(credits *[]models.FilmCredit) creditsColumn {
+	return creditsColumn{credits: credits}
+}
+
+func (c creditsColumn) Scan(src any) error {
+	switch v := src.(type) {
+	case []byte:
+		return json.Unmarshal(v, c.credits)
+	case string:
+		return json.Unmarshal([]byte(v), c.credits)
+	}
+
+	return fmt.Errorf("credits: unexpected %T", src)
+}
+
+// addCredits links the crew of a film it has just inserted. Each person
+// must be live.
+func (r *FilmRepository) addCredits(tx *sql.Tx, f models.Film) error {
+	seen := map[models.FilmCredit]bool{}
+	for _, c := range f.Credits {
+		if seen[c] {
+			continue
+		}
+		seen[c] = true
+
+		result, err := tx.Exec(
+			"INSERT INTO credits (film_id, person_id, job) SELECT $1, $2, $3::crew_job WHERE EXISTS (SELECT 1 FROM actors WHERE id=$2 AND deleted_at IS NULL);",
+			f.Id,
+			c.PersonID,
+			c.Job,
+		)
+		if err != nil {
+			return err
+		}
+		insertedRows, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if insertedRows == 0 {
+			return ErrResourceNotFound
+		}
+	}
+
+	return nil
+}
+
+const creditSelect = "SELECT c.film_id, f.name, f.release_year, c.person_id, a.name, c.job FROM credits c JOIN films f ON f.id = c.film_id JOIN actors a ON a.id = c.person_id"
+
+type CreditRepository struct {
+	store *Store
+}
+
+func (r *CreditRepository) Add(c models.Credit) error {
+	if err := c.Validate(); err != nil {
+		return ErrValidation
+	}
+
+	var result sql.Result
+	if err := r.store.retry(false, func() (err error) {
+		result, err = r.store.db.Exec(
+			"INSERT INTO credits (film_id, person_id, job) SELECT $1, $2, $3::crew_job WHERE EXISTS (SELECT 1 FROM films WHERE id=$1 AND deleted_at IS NULL) AND EXISTS (SELECT 1 FROM actors WHERE id=$2 AND deleted_at IS NULL);",
+			c.FilmID,
+			c.PersonID,
+			c.Job,
+		)
+		return err
+	}); err != nil {
+		return err
+	}
+
+	insertedRows, err := result.RowsAffected()
+	if err != nil {
+		return translateError(err)
+	}
+	if insertedRows == 0 {
+		return ErrResourceNotFound
+	}
+
+	return nil
+}
+
+func (r *CreditRepository) Remove(c models.Credit) error {
+	var result sql.Result
+	if err := r.store.retry(false, func() (err error) {
+		result, err = r.store.db.Exec(
+			"DELETE FROM credits WHERE film_id=$1 AND person_id=$2 AND job=$3;",
+			c.FilmID,
+			c.PersonID,
+			c.Job,
+		)
+		return err
+	}); err != nil {
+		return err
+	}
+
+	deletedRows, err := result.RowsAffected()
+	if err != nil {
+		return translateError(err)
+	}
+	if deletedRows == 0 {
+		return ErrResourceNotFound
+	}
+
+	return nil
+}
+
+func (r *CreditRepository) ByFilm(filmID int) ([]models.Credit, error) {
+	return r.list(
+		"films",
+		filmID,
+		creditSelect+" WHERE c.film_id=$1 AND a.deleted_at IS NULL ORDER BY c.job, a.name;",
+	)
+}
+
+func (r *CreditRepository) ByPerson(personID int) ([]models.Credit, error) {
+	return r.list(
+		"actors",
+		personID,
+		creditSelect+" WHERE c.person_id=$1 AND f.deleted_at IS NULL ORDER BY f.release_year DESC, f.name, c.job;",
+	)
+}
+
+// list runs query for the row id of table, failing with
+// ErrResourceNotFound when the row is missing or deleted.
+func (r *CreditRepository) list(table string, id int, query string) ([]models.Credit, error) {
+	var credits []models.Credit
+	err := r.store.retry(true, func() error {
+		db := r.store.reader()
+		var found bool
+		if err := db.QueryRow(
+			"SELECT EXISTS (SELECT 1 FROM "+table+" WHERE id=$1 AND deleted_at IS NULL);",
+			id,
+		).Scan(&found); err != nil {
+			return err
+		}
+		if !found {
+			return ErrResourceNotFound
+		}
+
+		rows, err := db.Query(query, id)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		credits = make([]models.Credit, 0)
+		for rows.Next() {
+			c := models.Credit{}
+			if err := rows.Scan(&c.FilmID, &c.Film, &c.ReleaseYear, &c.PersonID, &c.Person, &c.Job); err != nil {
+				return err
+			}
+			credits = append(credits, c)
+		}
+
+		return rows.Err()
+	})
+
+	return credits, err
+}
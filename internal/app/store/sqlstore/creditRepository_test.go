@@ -0,0 +1,138 @@
+package sqlstore
+
+import (
+	"testing"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+
+	"filmoteka/internal/app/models"
+	"filmoteka/internal/app/store"
+)
+
+func TestCredit_Add(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := New(db)
+	insert := "INSERT INTO credits (film_id, person_id, job) SELECT $1, $2, $3::crew_job WHERE EXISTS (SELECT 1 FROM films WHERE id=$1 AND deleted_at IS NULL) AND EXISTS (SELECT 1 FROM actors WHERE id=$2 AND deleted_at IS NULL);"
+
+	tests := []struct {
+		name    string
+		input   models.Credit
+		mock    func()
+		wantErr error
+	}{
+		{
+			name:  "Ok",
+			input: models.Credit{FilmID: 1, PersonID: 2, Job: models.JobDirector},
+			mock: func() {
+				mock.ExpectExec(insert).WithArgs(1, 2, "director").WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name:  "Missing film or person",
+			input: models.Credit{FilmID: 1, PersonID: 3, Job: models.JobWriter},
+			mock: func() {
+				mock.ExpectExec(insert).WithArgs(1, 3, "writer").WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			wantErr: store.ErrResourceNotFound,
+		},
+		{
+			name:  "Already credited",
+			input: models.Credit{FilmID: 1, PersonID: 2, Job: models.JobDirector},
+			mock: func() {
+				mock.ExpectExec(insert).WithArgs(1, 2, "director").WillReturnError(&pq.Error{Code: "23505"})
+			},
+			wantErr: store.ErrUniqueConstraints,
+		},
+		{
+			name:    "Unknown job",
+			input:   models.Credit{FilmID: 1, PersonID: 2, Job: "gaffer"},
+			mock:    func() {},
+			wantErr: store.ErrValidation,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+
+			err := r.CreditRepo().Add(tt.input)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestCredit_ByFilm(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := New(db)
+	exists := "SELECT EXISTS (SELECT 1 FROM films WHERE id=$1 AND deleted_at IS NULL);"
+	query := creditSelect + " WHERE c.film_id=$1 AND a.deleted_at IS NULL ORDER BY c.job, a.name;"
+	columns := []string{"film_id", "name", "release_year", "person_id", "name", "job"}
+
+	tests := []struct {
+		name    string
+		mock    func()
+		want    []models.Credit
+		wantErr error
+	}{
+		{
+			name: "Ok",
+			mock: func() {
+				mock.ExpectQuery(exists).WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+				mock.ExpectQuery(query).WithArgs(1).WillReturnRows(sqlmock.NewRows(columns).
+					AddRow(1, "Film 1", 2001, 2, "Person 2", "director").
+					AddRow(1, "Film 1", 2001, 2, "Person 2", "writer"))
+			},
+			want: []models.Credit{
+				{FilmID: 1, Film: "Film 1", ReleaseYear: 2001, PersonID: 2, Person: "Person 2", Job: models.JobDirector},
+				{FilmID: 1, Film: "Film 1", ReleaseYear: 2001, PersonID: 2, Person: "Person 2", Job: models.JobWriter},
+			},
+		},
+		{
+			name: "No crew",
+			mock: func() {
+				mock.ExpectQuery(exists).WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+				mock.ExpectQuery(query).WithArgs(1).WillReturnRows(sqlmock.NewRows(columns))
+			},
+			want: []models.Credit{},
+		},
+		{
+			name: "Film not found",
+			mock: func() {
+				mock.ExpectQuery(exists).WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+			},
+			wantErr: store.ErrResourceNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+
+			got, err := r.CreditRepo().ByFilm(1)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
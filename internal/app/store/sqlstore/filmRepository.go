@@ -3,13 +3,78 @@ package sqlstore
 import (
 	"database/sql"
 	"filmoteka/internal/app/models"
-	"strings"
+	"filmoteka/internal/app/store"
+	"time"
+
+	"github.com/lib/pq"
 )
 
+// filmFields selects the film columns in the order filmDest scans them.
+const filmFields = "id, name, description, release_year, rating, COALESCE(runtime, 0), COALESCE(original_title, ''), COALESCE(original_language, ''), countries, COALESCE(age_rating::text, ''), updated_at"
+
+const filmInsert = "INSERT INTO films (name, description, release_year, rating, runtime, original_title, original_language, countries, age_rating) VALUES ($1, $2, $3, $4, NULLIF($5, 0), NULLIF($6, ''), NULLIF($7, ''), $8, NULLIF($9, '')::age_rating)"
+
+const filmUpdate = "UPDATE films SET name=$1, description=$2, release_year=$3, rating=$4, runtime=NULLIF($5, 0), original_title=NULLIF($6, ''), original_language=NULLIF($7, ''), countries=$8, age_rating=NULLIF($9, '')::age_rating, updated_at=now() WHERE id=$10;"
+
+// filmRatings selects the running totals of the user ratings, read into
+// ratingTotals after the other film columns.
+const filmRatings = "user_rating_count, user_rating_sum"
+
+// filmPoster selects the poster, read with imageDest after filmRatings.
+const filmPoster = "poster"
+
 type FilmRepository struct {
 	store *Store
 }
 
+// filmDest returns the scan destinations of the filmFields columns.
+func filmDest(f *models.Film) []any {
+	return []any{
+		&f.Id,
+		&f.Name,
+		&f.Description,
+		&f.ReleaseYear,
+		&f.Rating,
+		&f.Runtime,
+		&f.OriginalTitle,
+		&f.OriginalLanguage,
+		pq.Array(&f.Countries),
+		&f.AgeRating,
+		&f.UpdatedAt,
+	}
+}
+
+// ratingTotals holds the filmRatings columns of a film.
+type ratingTotals struct {
+	count, sum int
+}
+
+// summarize sets the user rating summary of f from the totals.
+func (s *Store) summarize(f *models.Film, t ratingTotals) {
+	summary := models.Summarize(f.Rating, t.count, t.sum, s.ratingWeight)
+	f.UserRating = &summary
+}
+
+// filmArgs returns the column values written by filmInsert and filmUpdate.
+func filmArgs(f models.Film) []any {
+	countries := f.Countries
+	if countries == nil {
+		countries = []string{}
+	}
+
+	return []any{
+		f.Name,
+		f.Description,
+		f.ReleaseYear,
+		f.Rating,
+		f.Runtime,
+		f.OriginalTitle,
+		f.OriginalLanguage,
+		pq.Array(countries),
+		f.AgeRating,
+	}
+}
+
 func (r *FilmRepository) Create(f models.Film) (int, error) {
 	if err := f.Validate(); err != nil {
 		// fmt.Println(ErrValidation.Error())
@@ -17,36 +82,102 @@ func (r *FilmRepository) Create(f models.Film) (int, error) {
 	}
 
 	var id int
-	if err := r.store.db.QueryRow(
-		"INSERT INTO films (name, description, release_year, rating) VALUES ($1, $2, $3, $4) RETURNING id;",
-		f.Name,
-		f.Description,
-		f.ReleaseYear,
-		f.Rating,
-	).Scan(&id); err != nil {
-		// sqlState := getSQLState(err)
-		if strings.Contains(err.Error(), "unique constraint") {
-			return 0, ErrUniqueConstraints
-		} else {
-			return 0, err
-		}
+	if err := r.store.retry(false, func() error {
+		return r.store.inTx(func(tx *sql.Tx) error {
+			if err := tx.QueryRow(
+				filmInsert+" RETURNING id;",
+				filmArgs(f)...,
+			).Scan(&id); err != nil {
+				return err
+			}
+			f.Id = id
+			if err := r.setGenres(tx, &f); err != nil {
+				return err
+			}
+
+			if err := r.store.audit(tx, store.EntityFilm, id, store.ActionCreate, nil, f); err != nil {
+				return err
+			}
+			return r.revise(tx, f)
+		})
+	}); err != nil {
+		return 0, err
 	}
 
 	return id, nil
 }
 
+func (r *FilmRepository) CreateBatch(films []models.Film) ([]int, error) {
+	var ids []int
+	err := r.store.retry(false, func() (err error) {
+		ids, err = r.createBatch(films)
+		return err
+	})
+
+	return ids, err
+}
+
+func (r *FilmRepository) createBatch(films []models.Film) ([]int, error) {
+	tx, err := r.store.db.Begin()
+	if err != nil {
+		return nil, translateError(err)
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.Prepare(
+		filmInsert + " ON CONFLICT (name, release_year) WHERE deleted_at IS NULL DO NOTHING RETURNING id;",
+	)
+	if err != nil {
+		return nil, translateError(err)
+	}
+	defer stmt.Close()
+
+	ids := make([]int, len(films))
+	for i, f := range films {
+		if err := f.Validate(); err != nil {
+			return nil, &store.BatchError{Row: i, Err: ErrValidation}
+		}
+
+		err := stmt.QueryRow(filmArgs(f)...).Scan(&ids[i])
+		switch {
+		case err == sql.ErrNoRows:
+			// duplicate (name, release_year), left as 0
+			continue
+		case err != nil:
+			return nil, &store.BatchError{Row: i, Err: translateError(err)}
+		}
+
+		f.Id = ids[i]
+		if err := r.setGenres(tx, &f); err != nil {
+			return nil, &store.BatchError{Row: i, Err: translateError(err)}
+		}
+		if err := r.addCredits(tx, f); err != nil {
+			return nil, &store.BatchError{Row: i, Err: translateError(err)}
+		}
+		if err := r.store.audit(tx, store.EntityFilm, f.Id, store.ActionCreate, nil, f); err != nil {
+			return nil, &store.BatchError{Row: i, Err: translateError(err)}
+		}
+		if err := r.revise(tx, f); err != nil {
+			return nil, &store.BatchError{Row: i, Err: translateError(err)}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, translateError(err)
+	}
+
+	return ids, nil
+}
+
 func (r *FilmRepository) Find(id int) (models.Film, error) {
 	f := models.Film{}
-	if err := r.store.db.QueryRow(
-		"SELECT id, name, description, release_year, rating FROM films WHERE id=$1",
-		id,
-	).Scan(
-		&f.Id,
-		&f.Name,
-		&f.Description,
-		&f.ReleaseYear,
-		&f.Rating,
-	); err != nil {
+	t := ratingTotals{}
+	if err := r.store.retry(true, func() error {
+		return r.store.reader().QueryRow(
+			"SELECT "+filmFields+", "+filmGenres+", "+filmRatings+", "+filmPoster+" FROM films WHERE id=$1 AND deleted_at IS NULL",
+			id,
+		).Scan(append(filmDest(&f), pq.Array(&f.Genres), &t.count, &t.sum, imageDest(&f.Poster))...)
+	}); err != nil {
 		switch err {
 		case sql.ErrNoRows:
 			return models.Film{}, ErrResourceNotFound
@@ -54,53 +185,169 @@ func (r *FilmRepository) Find(id int) (models.Film, error) {
 			return models.Film{}, err
 		}
 	}
+	r.store.summarize(&f, t)
 
 	return f, nil
 }
 
 func (r *FilmRepository) FindAll() ([]models.Film, error) {
+	var films []models.Film
+	err := r.store.retry(true, func() (err error) {
+		films, err = r.findAll()
+		return err
+	})
+
+	return films, err
+}
+
+func (r *FilmRepository) findAll() ([]models.Film, error) {
 	f := &models.Film{}
+	t := ratingTotals{}
 	films := make([]models.Film, 0)
-	rows, err := r.store.db.Query(
-		"SELECT id, name, description, release_year, rating FROM films;")
+	rows, err := r.store.reader().Query(
+		"SELECT " + filmFields + ", " + filmGenres + ", " + filmRatings + ", " + filmPoster + " FROM films WHERE deleted_at IS NULL;")
 	if err != nil {
-		return nil, err
+		return nil, translateError(err)
 	}
 	defer rows.Close()
 
 	for rows.Next() {
-		err := rows.Scan(
-			&f.Id,
-			&f.Name,
-			&f.Description,
-			&f.ReleaseYear,
-			&f.Rating,
-		)
+		err := rows.Scan(append(filmDest(f), pq.Array(&f.Genres), &t.count, &t.sum, imageDest(&f.Poster))...)
 		if err != nil {
-			return nil, err
+			return nil, translateError(err)
 		}
+		r.store.summarize(f, t)
 		films = append(films, *f)
 	}
 
 	return films, nil
 }
 
-func (r *FilmRepository) Delete(id int) error {
-	result, err := r.store.db.Exec("DELETE FROM films WHERE id=$1;", id)
-	if err != nil {
-		// fmt.Println(err.Error())
+func (r *FilmRepository) FindBy(filter store.FilmFilter) ([]models.Film, error) {
+	query, args := filmFilterQuery(filter)
+
+	var films []models.Film
+	err := r.store.retry(true, func() error {
+		rows, err := r.store.reader().Query(query, args...)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		films = make([]models.Film, 0)
+		for rows.Next() {
+			f := models.Film{}
+			t := ratingTotals{}
+			if err := rows.Scan(append(filmDest(&f), &f.DeletedAt, pq.Array(&f.Genres), &t.count, &t.sum, imageDest(&f.Poster))...); err != nil {
+				return err
+			}
+			r.store.summarize(&f, t)
+			films = append(films, f)
+		}
+
+		return rows.Err()
+	})
+
+	return films, err
+}
+
+func (r *FilmRepository) Each(fn func(models.Film) error) error {
+	// only opening the cursor is retried, rows already passed to fn
+	// cannot be taken back
+	var rows *sql.Rows
+	if err := r.store.retry(true, func() (err error) {
+		rows, err = r.store.reader().Query(
+			"SELECT " + filmFields + ", " + filmGenres + ", " + filmCredits + " FROM films WHERE deleted_at IS NULL ORDER BY id;")
+		return err
+	}); err != nil {
 		return err
 	}
+	defer rows.Close()
 
-	deletedRows, err := result.RowsAffected()
-	// fmt.Println("deleted: ", deletedRows)
-	if err != nil {
-		return err
+	for rows.Next() {
+		f := models.Film{}
+		err := rows.Scan(append(filmDest(&f), pq.Array(&f.Genres), creditsDest(&f.Credits))...)
+		if err != nil {
+			return translateError(err)
+		}
+		if err := fn(f); err != nil {
+			return err
+		}
 	}
-	if deletedRows == 0 {
-		return ErrResourceNotFound
+
+	return translateError(rows.Err())
+}
+
+func (r *FilmRepository) Delete(id int) error {
+	return r.store.retry(false, func() error {
+		return r.store.inTx(func(tx *sql.Tx) error {
+			before, err := r.lock(tx, id)
+			if err != nil {
+				return err
+			}
+			if _, err := tx.Exec("UPDATE films SET deleted_at=now(), updated_at=now() WHERE id=$1;", id); err != nil {
+				return err
+			}
+
+			return r.store.audit(tx, store.EntityFilm, id, store.ActionDelete, before, nil)
+		})
+	})
+}
+
+func (r *FilmRepository) Restore(id int) error {
+	return r.store.retry(false, func() error {
+		return r.store.inTx(func(tx *sql.Tx) error {
+			after := models.Film{}
+			err := tx.QueryRow(
+				"UPDATE films SET deleted_at=NULL, updated_at=now() WHERE id=$1 AND deleted_at IS NOT NULL RETURNING "+filmFields+", "+filmGenres+";",
+				id,
+			).Scan(append(filmDest(&after), pq.Array(&after.Genres))...)
+			switch {
+			case err == sql.ErrNoRows:
+				return ErrResourceNotFound
+			case err != nil:
+				return err
+			}
+
+			return r.store.audit(tx, store.EntityFilm, id, store.ActionRestore, nil, after)
+		})
+	})
+}
+
+func (r *FilmRepository) Purge(before time.Time) ([]int, error) {
+	var ids []int
+	// a replay after a lost commit would find nothing left, losing the
+	// ids whose image files are to be removed
+	if err := r.store.retry(false, func() error {
+		// one statement, so rows and their audit entries go together
+		rows, err := r.store.db.Query(
+			"WITH purged AS (DELETE FROM films WHERE deleted_at < $1 RETURNING id, name, description, release_year, rating, deleted_at) INSERT INTO audit_log (user_id, request_id, entity, entity_id, action, before) SELECT $2, $3, $4, id, $5, jsonb_build_object('id', id, 'name', name, 'description', description, 'release_year', release_year, 'rating', rating, 'deleted_at', deleted_at) FROM purged RETURNING entity_id;",
+			before,
+			r.store.principalID(),
+			r.store.principal.RequestID,
+			store.EntityFilm,
+			store.ActionPurge,
+		)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		ids = make([]int, 0)
+		for rows.Next() {
+			var id int
+			if err := rows.Scan(&id); err != nil {
+				return err
+			}
+			ids = append(ids, id)
+		}
+
+		return rows.Err()
+	}); err != nil {
+		return nil, err
 	}
-	return nil
+
+	return ids, nil
 }
 
 func (r *FilmRepository) Update(f models.Film) error {
@@ -108,29 +355,94 @@ func (r *FilmRepository) Update(f models.Film) error {
 		return err
 	}
 
-	result, err := r.store.db.Exec(
-		"UPDATE films SET name=$1, description=$2, release_year=$3, rating=$4 WHERE id=$5;",
-		f.Name,
-		f.Description,
-		f.ReleaseYear,
-		f.Rating,
-		f.Id,
-	)
-	if err != nil {
-		if strings.Contains(err.Error(), "unique constraint") {
-			return ErrUniqueConstraints
-		} else {
-			return err
-		}
+	return r.store.retry(false, func() error {
+		return r.store.inTx(func(tx *sql.Tx) error {
+			before, err := r.lock(tx, f.Id)
+			if err != nil {
+				return err
+			}
+			if _, err := tx.Exec(filmUpdate, append(filmArgs(f), f.Id)...); err != nil {
+				return err
+			}
+			if f.Genres == nil {
+				f.Genres = before.Genres
+			} else if err := r.setGenres(tx, &f); err != nil {
+				return err
+			}
+
+			if err := r.store.audit(tx, store.EntityFilm, f.Id, store.ActionUpdate, before, f); err != nil {
+				return err
+			}
+			return r.revise(tx, f)
+		})
+	})
+}
+
+// lock reads the live film and locks its row until tx ends.
+func (r *FilmRepository) lock(tx *sql.Tx, id int) (models.Film, error) {
+	f := models.Film{}
+	err := tx.QueryRow(
+		"SELECT "+filmFields+", "+filmGenres+" FROM films WHERE id=$1 AND deleted_at IS NULL FOR UPDATE;",
+		id,
+	).Scan(append(filmDest(&f), pq.Array(&f.Genres))...)
+	if err == sql.ErrNoRows {
+		return f, ErrResourceNotFound
 	}
 
-	updatedRows, err := result.RowsAffected()
-	if err != nil {
-		return err
+	return f, err
+}
+
+func (r *FilmRepository) Upsert(f models.Film) (int, bool, error) {
+	if err := f.Validate(); err != nil {
+		return 0, false, ErrValidation
 	}
-	if updatedRows == 0 {
-		return ErrResourceNotFound
+
+	var (
+		id      int
+		created bool
+	)
+	if err := r.store.retry(false, func() error {
+		return r.store.inTx(func(tx *sql.Tx) error {
+			var before any
+			prev := models.Film{}
+			err := tx.QueryRow(
+				"SELECT "+filmFields+", "+filmGenres+" FROM films WHERE name=$1 AND release_year=$2 AND deleted_at IS NULL FOR UPDATE;",
+				f.Name,
+				f.ReleaseYear,
+			).Scan(append(filmDest(&prev), pq.Array(&prev.Genres))...)
+			switch {
+			case err == nil:
+				before = prev
+			case err != sql.ErrNoRows:
+				return err
+			}
+
+			// xmax is 0 only for freshly inserted row versions
+			if err := tx.QueryRow(
+				filmInsert+" ON CONFLICT (name, release_year) WHERE deleted_at IS NULL DO UPDATE SET description=EXCLUDED.description, rating=EXCLUDED.rating, runtime=EXCLUDED.runtime, original_title=EXCLUDED.original_title, original_language=EXCLUDED.original_language, countries=EXCLUDED.countries, age_rating=EXCLUDED.age_rating, updated_at=now() RETURNING id, (xmax = 0);",
+				filmArgs(f)...,
+			).Scan(&id, &created); err != nil {
+				return err
+			}
+			f.Id = id
+			if f.Genres == nil && !created {
+				f.Genres = prev.Genres
+			} else if err := r.setGenres(tx, &f); err != nil {
+				return err
+			}
+
+			action := store.ActionUpdate
+			if created {
+				action, before = store.ActionCreate, nil
+			}
+			if err := r.store.audit(tx, store.EntityFilm, id, action, before, f); err != nil {
+				return err
+			}
+			return r.revise(tx, f)
+		})
+	}); err != nil {
+		return 0, false, err
 	}
 
-	return nil
+	return id, created, nil
 }
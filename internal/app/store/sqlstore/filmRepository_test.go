@@ -2,13 +2,20 @@ package sqlstore
 
 import (
 	"database/sql"
+	"database/sql/driver"
 	"filmoteka/internal/app/models"
+	"filmoteka/internal/app/store"
 	"testing"
+	"time"
 
 	sqlmock "github.com/DATA-DOG/go-sqlmock"
 	"github.com/stretchr/testify/assert"
 )
 
+const filmLock = "SELECT " + filmFields + ", " + filmGenres + " FROM films WHERE id=$1 AND deleted_at IS NULL FOR UPDATE;"
+
+var filmColumns = []string{"id", "name", "description", "release_year", "rating", "runtime", "original_title", "original_language", "countries", "age_rating", "updated_at", "genres"}
+
 func TestFilm_Create(t *testing.T) {
 	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
 	if err != nil {
@@ -44,14 +51,25 @@ func TestFilm_Create(t *testing.T) {
 			want: 1,
 			mock: func(args args, id int) {
 				rows := sqlmock.NewRows([]string{"id"}).AddRow(id)
+				mock.ExpectBegin()
 				mock.ExpectQuery(
-					"INSERT INTO films (name, description, release_year, rating) VALUES ($1, $2, $3, $4) RETURNING id;",
+					filmInsert+" RETURNING id;",
 				).WithArgs(
 					args.film.Name,
 					args.film.Description,
 					args.film.ReleaseYear,
 					args.film.Rating,
+					args.film.Runtime,
+					args.film.OriginalTitle,
+					args.film.OriginalLanguage,
+					"{}",
+					args.film.AgeRating,
 				).WillReturnRows(rows)
+				expectAudit(mock, store.EntityFilm, id, store.ActionCreate, "",
+					`{"id":1,"name":"Test Film 1","description":"Descr 1","release_year":2015,"rating":6.7}`)
+				expectRevision(mock, id,
+					`{"id":1,"name":"Test Film 1","description":"Descr 1","release_year":2015,"rating":6.7}`)
+				mock.ExpectCommit()
 			},
 		},
 		{
@@ -98,6 +116,7 @@ func TestFilm_GetAll(t *testing.T) {
 	defer db.Close()
 
 	r := New(db)
+	updatedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
 
 	tests := []struct {
 		name    string
@@ -109,28 +128,28 @@ func TestFilm_GetAll(t *testing.T) {
 			name: "Regular Select",
 			mock: func() {
 				rows := sqlmock.NewRows([]string{
-					"id", "name", "description", "release_year", "rating",
+					"id", "name", "description", "release_year", "rating", "runtime", "original_title", "original_language", "countries", "age_rating", "updated_at", "genres", "user_rating_count", "user_rating_sum", "poster",
 				}).
-					AddRow(1, "film1", "description1", 2000, 10).
-					AddRow(2, "film2", "description2", 2001, 4).
-					AddRow(3, "film3", "description3", 2002, 5)
+					AddRow(1, "film1", "description1", 2000, 10, 0, "", "", "{}", "", updatedAt, "{Drama}", 2, 17, nil).
+					AddRow(2, "film2", "description2", 2001, 4, 0, "", "", "{}", "", updatedAt, "{Comedy,Drama}", 0, 0, nil).
+					AddRow(3, "film3", "description3", 2002, 5, 0, "", "", "{}", "", updatedAt, "{}", 0, 0, nil)
 
-				mock.ExpectQuery("SELECT id, name, description, release_year, rating FROM films;").
+				mock.ExpectQuery("SELECT " + filmFields + ", " + filmGenres + ", " + filmRatings + ", " + filmPoster + " FROM films WHERE deleted_at IS NULL;").
 					WithArgs().WillReturnRows(rows)
 			},
 			want: []models.Film{
-				{Id: 1, Name: "film1", Description: "description1", ReleaseYear: 2000, Rating: 10},
-				{Id: 2, Name: "film2", Description: "description2", ReleaseYear: 2001, Rating: 4},
-				{Id: 3, Name: "film3", Description: "description3", ReleaseYear: 2002, Rating: 5},
+				{Id: 1, Name: "film1", Description: "description1", ReleaseYear: 2000, Rating: 10, UpdatedAt: updatedAt, Countries: []string{}, Genres: []string{"Drama"}, UserRating: &models.RatingSummary{Count: 2, Average: 8.5, Score: 8.5}},
+				{Id: 2, Name: "film2", Description: "description2", ReleaseYear: 2001, Rating: 4, UpdatedAt: updatedAt, Countries: []string{}, Genres: []string{"Comedy", "Drama"}, UserRating: &models.RatingSummary{Score: 4}},
+				{Id: 3, Name: "film3", Description: "description3", ReleaseYear: 2002, Rating: 5, UpdatedAt: updatedAt, Countries: []string{}, Genres: []string{}, UserRating: &models.RatingSummary{Score: 5}},
 			},
 		},
 		{
 			name: "No Records",
 			mock: func() {
 				rows := sqlmock.NewRows(
-					[]string{"id", "name", "description", "release_year, rating"})
+					[]string{"id", "name", "description", "release_year", "rating", "runtime", "original_title", "original_language", "countries", "age_rating", "updated_at", "genres", "user_rating_count", "user_rating_sum", "poster"})
 
-				mock.ExpectQuery("SELECT id, name, description, release_year, rating FROM films;").
+				mock.ExpectQuery("SELECT " + filmFields + ", " + filmGenres + ", " + filmRatings + ", " + filmPoster + " FROM films WHERE deleted_at IS NULL;").
 					WithArgs().WillReturnRows(rows)
 			},
 			want: []models.Film{},
@@ -163,6 +182,7 @@ func TestFind(t *testing.T) {
 	defer db.Close()
 
 	r := New(db)
+	updatedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
 
 	type args struct {
 		id int
@@ -178,17 +198,18 @@ func TestFind(t *testing.T) {
 			name: "Ok",
 			mock: func(args args) {
 				rows := sqlmock.NewRows([]string{
-					"id", "name", "description", "release_year", "rating",
-				}).AddRow(1, "film1", "description1", 2000, 10)
+					"id", "name", "description", "release_year", "rating", "runtime", "original_title", "original_language", "countries", "age_rating", "updated_at", "genres", "user_rating_count", "user_rating_sum", "poster",
+				}).AddRow(1, "film1", "description1", 2000, 10, 0, "", "", "{}", "", updatedAt, "{Drama}", 0, 0, nil)
 				mock.ExpectQuery( // regexp.QuoteMeta( -- also works
-					"SELECT id, name, description, release_year, rating FROM films WHERE id=$1",
+					"SELECT " + filmFields + ", " + filmGenres + ", " + filmRatings + ", " + filmPoster + " FROM films WHERE id=$1 AND deleted_at IS NULL",
 				).WithArgs(args.id).WillReturnRows(rows)
 			},
 			input: args{
 				id: 1,
 			},
 			want: models.Film{
-				Id: 1, Name: "film1", Description: "description1", ReleaseYear: 2000, Rating: 10,
+				Id: 1, Name: "film1", Description: "description1", ReleaseYear: 2000, Rating: 10, UpdatedAt: updatedAt,
+				Countries: []string{}, Genres: []string{"Drama"}, UserRating: &models.RatingSummary{Score: 10},
 			},
 		},
 		{
@@ -197,7 +218,7 @@ func TestFind(t *testing.T) {
 			mock: func(args args) {
 				// regexp.QuoteMeta -- also works
 				mock.ExpectQuery( // regexp.QuoteMeta(
-					"SELECT id, name, description, release_year, rating FROM films WHERE id=$1",
+					"SELECT " + filmFields + ", " + filmGenres + ", " + filmRatings + ", " + filmPoster + " FROM films WHERE id=$1 AND deleted_at IS NULL",
 				).WithArgs(args.id).WillReturnError(ErrResourceNotFound)
 			},
 			// want:    &models.Film{},
@@ -234,6 +255,8 @@ func TestDelete(t *testing.T) {
 
 	r := New(db)
 
+	updatedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
 	type args struct {
 		id int
 	}
@@ -249,8 +272,14 @@ func TestDelete(t *testing.T) {
 				id: 1,
 			},
 			mock: func(args args) {
-				mock.ExpectExec("DELETE FROM films WHERE id=$1;").
+				mock.ExpectBegin()
+				mock.ExpectQuery(filmLock).WithArgs(args.id).
+					WillReturnRows(sqlmock.NewRows(filmColumns).AddRow(args.id, "Film 1", "Descr 1", 2001, 5, 0, "", "", "{}", "", updatedAt, "{}"))
+				mock.ExpectExec("UPDATE films SET deleted_at=now(), updated_at=now() WHERE id=$1;").
 					WithArgs(args.id).WillReturnResult(sqlmock.NewResult(0, 1))
+				expectAudit(mock, store.EntityFilm, args.id, store.ActionDelete,
+					`{"id":1,"name":"Film 1","description":"Descr 1","release_year":2001,"rating":5}`, "")
+				mock.ExpectCommit()
 			},
 		},
 		{
@@ -259,8 +288,9 @@ func TestDelete(t *testing.T) {
 				id: 404,
 			},
 			mock: func(args args) {
-				mock.ExpectExec("DELETE FROM films WHERE id=$1;").
-					WithArgs(args.id).WillReturnError(sql.ErrNoRows)
+				mock.ExpectBegin()
+				mock.ExpectQuery(filmLock).WithArgs(args.id).WillReturnError(sql.ErrNoRows)
+				mock.ExpectRollback()
 			},
 			wantErr: true,
 		},
@@ -290,6 +320,8 @@ func TestUpdate(t *testing.T) {
 
 	r := New(db)
 
+	updatedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
 	updatedFilm := models.Film{
 		Id:          1,
 		Name:        "Updated Film",
@@ -316,14 +348,27 @@ func TestUpdate(t *testing.T) {
 				film: updatedFilm,
 			},
 			mock: func(args args, film *models.Film) {
-				mock.ExpectExec("UPDATE films SET name=$1, description=$2, release_year=$3, rating=$4 WHERE id=$5;").
+				mock.ExpectBegin()
+				mock.ExpectQuery(filmLock).WithArgs(args.id).
+					WillReturnRows(sqlmock.NewRows(filmColumns).AddRow(args.id, "Film 1", "Updated Description", 2015, 6.7, 0, "", "", "{}", "", updatedAt, "{}"))
+				mock.ExpectExec(filmUpdate).
 					WithArgs(
 						args.film.Name,
 						args.film.Description,
 						args.film.ReleaseYear,
 						args.film.Rating,
+						args.film.Runtime,
+						args.film.OriginalTitle,
+						args.film.OriginalLanguage,
+						"{}",
+						args.film.AgeRating,
 						args.id,
 					).WillReturnResult(sqlmock.NewResult(0, 1))
+				expectAudit(mock, store.EntityFilm, args.id, store.ActionUpdate,
+					`{"name":"Film 1"}`, `{"name":"Updated Film"}`)
+				expectRevision(mock, args.id,
+					`{"id":1,"name":"Updated Film","description":"Updated Description","release_year":2015,"rating":6.7}`)
+				mock.ExpectCommit()
 			},
 			want: &updatedFilm,
 		},
@@ -346,3 +391,353 @@ func TestUpdate(t *testing.T) {
 		})
 	}
 }
+
+func TestFilm_CreateBatch(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := New(db)
+
+	query := filmInsert + " ON CONFLICT (name, release_year) WHERE deleted_at IS NULL DO NOTHING RETURNING id;"
+	films := []models.Film{
+		{Name: "Film 1", Description: "Descr 1", ReleaseYear: 2001, Rating: 5},
+		{Name: "Film 2", Description: "Descr 2", ReleaseYear: 2002, Rating: 6},
+	}
+
+	tests := []struct {
+		name    string
+		mock    func()
+		want    []int
+		wantRow int
+		wantErr bool
+	}{
+		{
+			name: "Ok with duplicate",
+			mock: func() {
+				mock.ExpectBegin()
+				prep := mock.ExpectPrepare(query)
+				prep.ExpectQuery().WithArgs("Film 1", "Descr 1", uint16(2001), float32(5), uint16(0), "", "", "{}", "").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				expectAudit(mock, store.EntityFilm, 1, store.ActionCreate, "",
+					`{"id":1,"name":"Film 1","description":"Descr 1","release_year":2001,"rating":5}`)
+				expectRevision(mock, 1, `{"id":1,"name":"Film 1","description":"Descr 1","release_year":2001,"rating":5}`)
+				prep.ExpectQuery().WithArgs("Film 2", "Descr 2", uint16(2002), float32(6), uint16(0), "", "", "{}", "").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+				mock.ExpectCommit()
+			},
+			want: []int{1, 0},
+		},
+		{
+			name: "Rollback on error",
+			mock: func() {
+				mock.ExpectBegin()
+				prep := mock.ExpectPrepare(query)
+				prep.ExpectQuery().WithArgs("Film 1", "Descr 1", uint16(2001), float32(5), uint16(0), "", "", "{}", "").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				expectAudit(mock, store.EntityFilm, 1, store.ActionCreate, "",
+					`{"id":1,"name":"Film 1","description":"Descr 1","release_year":2001,"rating":5}`)
+				expectRevision(mock, 1, `{"id":1,"name":"Film 1","description":"Descr 1","release_year":2001,"rating":5}`)
+				prep.ExpectQuery().WithArgs("Film 2", "Descr 2", uint16(2002), float32(6), uint16(0), "", "", "{}", "").
+					WillReturnError(sql.ErrConnDone)
+				mock.ExpectRollback()
+			},
+			wantRow: 1,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+
+			got, err := r.FilmRepo().CreateBatch(films)
+			if tt.wantErr {
+				var be *store.BatchError
+				assert.ErrorAs(t, err, &be)
+				assert.Equal(t, tt.wantRow, be.Row)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestFilm_CreateBatchCredits(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := New(db)
+
+	query := filmInsert + " ON CONFLICT (name, release_year) WHERE deleted_at IS NULL DO NOTHING RETURNING id;"
+	credit := "INSERT INTO credits (film_id, person_id, job) SELECT $1, $2, $3::crew_job WHERE EXISTS (SELECT 1 FROM actors WHERE id=$2 AND deleted_at IS NULL);"
+	film := models.Film{
+		Name: "Film 1", Description: "Descr 1", ReleaseYear: 2001, Rating: 5,
+		Credits: []models.FilmCredit{{PersonID: 3, Job: "director"}, {PersonID: 9, Job: "writer"}},
+	}
+
+	// a credit of a missing person fails the row
+	mock.ExpectBegin()
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectQuery().WithArgs("Film 1", "Descr 1", uint16(2001), float32(5), uint16(0), "", "", "{}", "").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectExec(credit).WithArgs(1, 3, "director").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(credit).WithArgs(1, 9, "writer").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	_, err = r.FilmRepo().CreateBatch([]models.Film{film})
+	var be *store.BatchError
+	assert.ErrorAs(t, err, &be)
+	assert.ErrorIs(t, err, store.ErrResourceNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFilm_Each(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := New(db)
+	updatedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery("SELECT " + filmFields + ", " + filmGenres + ", " + filmCredits + " FROM films WHERE deleted_at IS NULL ORDER BY id;").
+		WillReturnRows(sqlmock.NewRows(append(filmColumns, "credits")).
+			AddRow(1, "Film 1", "Descr 1", 2001, 5, 0, "", "", "{}", "", updatedAt, "{Drama}", []byte(`[{"person_id":3,"job":"director"}]`)).
+			AddRow(2, "Film 2", "Descr 2", 2002, 6, 0, "", "", "{}", "", updatedAt, "{}", []byte(`[]`)))
+
+	var got []models.Film
+	assert.NoError(t, r.FilmRepo().Each(func(f models.Film) error {
+		got = append(got, f)
+		return nil
+	}))
+	assert.Len(t, got, 2)
+	assert.Equal(t, []string{"Drama"}, got[0].Genres)
+	assert.Equal(t, []models.FilmCredit{{PersonID: 3, Job: "director"}}, got[0].Credits)
+	assert.Empty(t, got[1].Credits)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFilm_Upsert(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := New(db)
+
+	film := models.Film{Name: "Film 1", Description: "Descr 1", ReleaseYear: 2001, Rating: 5}
+	updatedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	lockByKey := "SELECT " + filmFields + ", " + filmGenres + " FROM films WHERE name=$1 AND release_year=$2 AND deleted_at IS NULL FOR UPDATE;"
+	query := filmInsert + " ON CONFLICT (name, release_year) WHERE deleted_at IS NULL DO UPDATE SET description=EXCLUDED.description, rating=EXCLUDED.rating, runtime=EXCLUDED.runtime, original_title=EXCLUDED.original_title, original_language=EXCLUDED.original_language, countries=EXCLUDED.countries, age_rating=EXCLUDED.age_rating, updated_at=now() RETURNING id, (xmax = 0);"
+
+	tests := []struct {
+		name        string
+		created     bool
+		wantCreated bool
+	}{
+		{name: "Created", created: true, wantCreated: true},
+		{name: "Updated", created: false, wantCreated: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock.ExpectBegin()
+			lock := mock.ExpectQuery(lockByKey).WithArgs(film.Name, film.ReleaseYear)
+			if tt.created {
+				lock.WillReturnError(sql.ErrNoRows)
+			} else {
+				lock.WillReturnRows(sqlmock.NewRows(filmColumns).AddRow(7, film.Name, "Old", film.ReleaseYear, 4, 0, "", "", "{}", "", updatedAt, "{}"))
+			}
+			mock.ExpectQuery(query).
+				WithArgs(film.Name, film.Description, film.ReleaseYear, film.Rating, film.Runtime, "", "", "{}", "").
+				WillReturnRows(sqlmock.NewRows([]string{"id", "created"}).AddRow(7, tt.created))
+			if tt.created {
+				expectAudit(mock, store.EntityFilm, 7, store.ActionCreate, "",
+					`{"id":7,"name":"Film 1","description":"Descr 1","release_year":2001,"rating":5}`)
+			} else {
+				expectAudit(mock, store.EntityFilm, 7, store.ActionUpdate,
+					`{"description":"Old","rating":4}`, `{"description":"Descr 1","rating":5}`)
+			}
+			expectRevision(mock, 7, `{"id":7,"name":"Film 1","description":"Descr 1","release_year":2001,"rating":5}`)
+			mock.ExpectCommit()
+
+			id, created, err := r.FilmRepo().Upsert(film)
+			assert.NoError(t, err)
+			assert.Equal(t, 7, id)
+			assert.Equal(t, tt.wantCreated, created)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestFilm_FindBy(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := New(db)
+
+	updatedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	deletedAt := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	columns := []string{"id", "name", "description", "release_year", "rating", "runtime", "original_title", "original_language", "countries", "age_rating", "updated_at", "deleted_at", "genres", "user_rating_count", "user_rating_sum", "poster"}
+
+	tests := []struct {
+		name   string
+		filter store.FilmFilter
+		query  string
+		args   []driver.Value
+		rows   *sqlmock.Rows
+		want   []models.Film
+	}{
+		{
+			name:   "Live only",
+			filter: store.FilmFilter{},
+			query:  "SELECT " + filmFields + ", deleted_at, " + filmGenres + ", " + filmRatings + ", " + filmPoster + " FROM films WHERE films.deleted_at IS NULL ORDER BY id;",
+			rows:   sqlmock.NewRows(columns).AddRow(1, "Film 1", "Descr 1", 2001, 5, 0, "", "", "{}", "", updatedAt, nil, "{}", 0, 0, nil),
+			want: []models.Film{
+				{Id: 1, Name: "Film 1", Description: "Descr 1", ReleaseYear: 2001, Rating: 5, UpdatedAt: updatedAt, Countries: []string{}, Genres: []string{}, UserRating: &models.RatingSummary{Score: 5}},
+			},
+		},
+		{
+			name:   "Include deleted",
+			filter: store.FilmFilter{IncludeDeleted: true},
+			query:  "SELECT " + filmFields + ", deleted_at, " + filmGenres + ", " + filmRatings + ", " + filmPoster + " FROM films ORDER BY id;",
+			rows: sqlmock.NewRows(columns).
+				AddRow(1, "Film 1", "Descr 1", 2001, 5, 0, "", "", "{}", "", updatedAt, nil, "{}", 0, 0, nil).
+				AddRow(2, "Film 2", "Descr 2", 2002, 6, 0, "", "", "{}", "", updatedAt, deletedAt, "{}", 0, 0, nil),
+			want: []models.Film{
+				{Id: 1, Name: "Film 1", Description: "Descr 1", ReleaseYear: 2001, Rating: 5, UpdatedAt: updatedAt, Countries: []string{}, Genres: []string{}, UserRating: &models.RatingSummary{Score: 5}},
+				{Id: 2, Name: "Film 2", Description: "Descr 2", ReleaseYear: 2002, Rating: 6, UpdatedAt: updatedAt, DeletedAt: &deletedAt, Countries: []string{}, Genres: []string{}, UserRating: &models.RatingSummary{Score: 6}},
+			},
+		},
+		{
+			name:   "By genre",
+			filter: store.FilmFilter{Genre: "drama"},
+			query:  "SELECT " + filmFields + ", deleted_at, " + filmGenres + ", " + filmRatings + ", " + filmPoster + " FROM films WHERE films.deleted_at IS NULL AND EXISTS (SELECT 1 FROM film_genres fg JOIN genres g ON g.id = fg.genre_id WHERE fg.film_id = films.id AND lower(g.name) = lower($1)) ORDER BY id;",
+			args:   []driver.Value{"drama"},
+			rows:   sqlmock.NewRows(columns).AddRow(1, "Film 1", "Descr 1", 2001, 5, 0, "", "", "{}", "", updatedAt, nil, "{Drama}", 0, 0, nil),
+			want: []models.Film{
+				{Id: 1, Name: "Film 1", Description: "Descr 1", ReleaseYear: 2001, Rating: 5, UpdatedAt: updatedAt, Countries: []string{}, Genres: []string{"Drama"}, UserRating: &models.RatingSummary{Score: 5}},
+			},
+		},
+		{
+			name:   "By award won",
+			filter: store.FilmFilter{WonCeremony: "Academy Awards", WonCategory: "best picture"},
+			query:  "SELECT " + filmFields + ", deleted_at, " + filmGenres + ", " + filmRatings + ", " + filmPoster + " FROM films WHERE films.deleted_at IS NULL AND EXISTS (SELECT 1 FROM nominations n JOIN awards aw ON aw.id = n.award_id WHERE n.film_id = films.id AND n.won AND lower(aw.ceremony) = lower($1) AND lower(aw.category) = lower($2)) ORDER BY id;",
+			args:   []driver.Value{"Academy Awards", "best picture"},
+			rows:   sqlmock.NewRows(columns).AddRow(1, "Film 1", "Descr 1", 2001, 5, 0, "", "", "{}", "", updatedAt, nil, "{}", 0, 0, nil),
+			want: []models.Film{
+				{Id: 1, Name: "Film 1", Description: "Descr 1", ReleaseYear: 2001, Rating: 5, UpdatedAt: updatedAt, Countries: []string{}, Genres: []string{}, UserRating: &models.RatingSummary{Score: 5}},
+			},
+		},
+		{
+			name:   "By metadata",
+			filter: store.FilmFilter{Language: "fr", Country: "FR", AgeRating: "R", MinRuntime: 90, MaxRuntime: 120},
+			query:  "SELECT " + filmFields + ", deleted_at, " + filmGenres + ", " + filmRatings + ", " + filmPoster + " FROM films WHERE films.deleted_at IS NULL AND films.original_language = $1 AND films.countries @> $2::char(2)[] AND films.age_rating::text = $3 AND films.runtime >= $4 AND films.runtime <= $5 ORDER BY id;",
+			args:   []driver.Value{"fr", `{"FR"}`, "R", uint16(90), uint16(120)},
+			rows:   sqlmock.NewRows(columns).AddRow(1, "Film 1", "Descr 1", 2001, 5, 104, "Le Film", "fr", "{FR,BE}", "R", updatedAt, nil, "{}", 0, 0, nil),
+			want: []models.Film{
+				{
+					Id: 1, Name: "Film 1", Description: "Descr 1", ReleaseYear: 2001, Rating: 5,
+					Runtime: 104, OriginalTitle: "Le Film", OriginalLanguage: "fr", Countries: []string{"FR", "BE"}, AgeRating: "R",
+					UpdatedAt: updatedAt, Genres: []string{}, UserRating: &models.RatingSummary{Score: 5},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock.ExpectQuery(tt.query).WithArgs(tt.args...).WillReturnRows(tt.rows)
+
+			got, err := r.FilmRepo().FindBy(tt.filter)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestFilm_Restore(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := New(db)
+
+	query := "UPDATE films SET deleted_at=NULL, updated_at=now() WHERE id=$1 AND deleted_at IS NOT NULL RETURNING " + filmFields + ", " + filmGenres + ";"
+	updatedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		id      int
+		mock    func(id int)
+		wantErr error
+	}{
+		{
+			name: "Ok",
+			id:   1,
+			mock: func(id int) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(query).WithArgs(id).
+					WillReturnRows(sqlmock.NewRows(filmColumns).AddRow(id, "Film 1", "Descr 1", 2001, 5, 0, "", "", "{}", "", updatedAt, "{}"))
+				expectAudit(mock, store.EntityFilm, id, store.ActionRestore, "",
+					`{"id":1,"name":"Film 1","description":"Descr 1","release_year":2001,"rating":5}`)
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "Not deleted",
+			id:   2,
+			mock: func(id int) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(query).WithArgs(id).WillReturnError(sql.ErrNoRows)
+				mock.ExpectRollback()
+			},
+			wantErr: ErrResourceNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock(tt.id)
+
+			err := r.FilmRepo().Restore(tt.id)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestFilm_Purge(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := New(db)
+
+	before := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	mock.ExpectQuery("WITH purged AS (DELETE FROM films WHERE deleted_at < $1 RETURNING id, name, description, release_year, rating, deleted_at) INSERT INTO audit_log (user_id, request_id, entity, entity_id, action, before) SELECT $2, $3, $4, id, $5, jsonb_build_object('id', id, 'name', name, 'description', description, 'release_year', release_year, 'rating', rating, 'deleted_at', deleted_at) FROM purged RETURNING entity_id;").
+		WithArgs(before, nil, "", store.EntityFilm, store.ActionPurge).WillReturnRows(sqlmock.NewRows([]string{"entity_id"}).AddRow(1).AddRow(2).AddRow(5))
+
+	ids, err := r.FilmRepo().Purge(before)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 5}, ids)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,185 @@
+package sqlstore
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/lib/pq"
+
+	"filmoteka/internal/app/store"
+)
+
+func filmFilterQuery(f store.FilmFilter) (string, []any) {
+	where, args := filmWhere(f)
+
+	query := "SELECT " + filmFields + ", deleted_at, " + filmGenres + ", " + filmRatings + ", " + filmPoster + " FROM films"
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	return query + " ORDER BY id;", args
+}
+
+// filmWhere returns the conditions on the films table selected by f.
+// args holds the parameters the query already uses; the conditions
+// number theirs after them.
+func filmWhere(f store.FilmFilter, args ...any) (string, []any) {
+	var where []string
+	if !f.IncludeDeleted {
+		where = append(where, "films.deleted_at IS NULL")
+	}
+	if f.Genre != "" {
+		args = append(args, f.Genre)
+		where = append(where, fmt.Sprintf(
+			"EXISTS (SELECT 1 FROM film_genres fg JOIN genres g ON g.id = fg.genre_id WHERE fg.film_id = films.id AND lower(g.name) = lower($%d))",
+			len(args)))
+	}
+	if f.Language != "" {
+		args = append(args, f.Language)
+		where = append(where, fmt.Sprintf("films.original_language = $%d", len(args)))
+	}
+	if f.Country != "" {
+		args = append(args, pq.Array([]string{f.Country}))
+		where = append(where, fmt.Sprintf("films.countries @> $%d::char(2)[]", len(args)))
+	}
+	if f.AgeRating != "" {
+		args = append(args, f.AgeRating)
+		where = append(where, fmt.Sprintf("films.age_rating::text = $%d", len(args)))
+	}
+	if f.MinRuntime != 0 {
+		args = append(args, f.MinRuntime)
+		where = append(where, fmt.Sprintf("films.runtime >= $%d", len(args)))
+	}
+	if f.MaxRuntime != 0 {
+		args = append(args, f.MaxRuntime)
+		where = append(where, fmt.Sprintf("films.runtime <= $%d", len(args)))
+	}
+	if f.WonCeremony != "" || f.WonCategory != "" {
+		won := "EXISTS (SELECT 1 FROM nominations n JOIN awards aw ON aw.id = n.award_id WHERE n.film_id = films.id AND n.won"
+		if f.WonCeremony != "" {
+			args = append(args, f.WonCeremony)
+			won += fmt.Sprintf(" AND lower(aw.ceremony) = lower($%d)", len(args))
+		}
+		if f.WonCategory != "" {
+			args = append(args, f.WonCategory)
+			won += fmt.Sprintf(" AND lower(aw.category) = lower($%d)", len(args))
+		}
+		where = append(where, won+")")
+	}
+
+	return strings.Join(where, " AND "), args
+}
+
+// watchlistQuery lists the live films on the user's watchlist matching f,
+// latest additions first.
+func watchlistQuery(userID int, f store.FilmFilter) (string, []any) {
+	f.IncludeDeleted = false
+	where, args := filmWhere(f, userID)
+
+	return "SELECT " + filmFields + ", " + filmGenres + ", " + filmRatings + ", " + filmPoster + ", w.added_at FROM watchlist w JOIN films ON films.id = w.film_id WHERE w.user_id = $1 AND " +
+		where + " ORDER BY w.added_at DESC, films.id;", args
+}
+
+func actorFilterQuery(f store.ActorFilter) (string, []any) {
+	var (
+		where []string
+		args  []any
+	)
+	if !f.IncludeDeleted {
+		where = append(where, "deleted_at IS NULL")
+	}
+	if f.Name != "" {
+		args = append(args, "%"+escapeLike(f.Name)+"%")
+		where = append(where, fmt.Sprintf(
+			"(name ILIKE $%[1]d OR EXISTS (SELECT 1 FROM unnest(aliases) alias WHERE alias ILIKE $%[1]d))",
+			len(args)))
+	}
+
+	query := "SELECT id, name, gender, birth_date, " + actorProfile + ", updated_at, " + actorPhoto + ", deleted_at FROM actors"
+	if len(where) > 0 {
+		query += " WHERE " + strings.Join(where, " AND ")
+	}
+
+	return query + " ORDER BY id;", args
+}
+
+// escapeLike escapes the LIKE wildcards in s.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
+
+// reviewFilterQuery returns the page query and a query counting every
+// match, each with its arguments.
+func reviewFilterQuery(f store.ReviewFilter) (query string, args []any, count string, countArgs []any) {
+	where := []string{"f.deleted_at IS NULL"}
+	if f.FilmID != 0 {
+		args = append(args, f.FilmID)
+		where = append(where, fmt.Sprintf("r.film_id = $%d", len(args)))
+	}
+	if f.Status != "" {
+		args = append(args, f.Status)
+		where = append(where, fmt.Sprintf("r.status::text = $%d", len(args)))
+	}
+
+	// counted apart from the page, a window count has no row to ride on
+	// for pages past the end
+	from := " FROM reviews r JOIN films f ON f.id = r.film_id WHERE " + strings.Join(where, " AND ")
+	count = "SELECT count(*)" + from + ";"
+	countArgs = args[:len(args):len(args)]
+
+	query = "SELECT " + reviewFields + from + " ORDER BY r.created_at DESC, r.id DESC"
+	if f.Limit > 0 {
+		args = append(args, f.Limit)
+		query += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+	if f.Offset > 0 {
+		args = append(args, f.Offset)
+		query += fmt.Sprintf(" OFFSET $%d", len(args))
+	}
+
+	return query + ";", args, count, countArgs
+}
+
+func auditFilterQuery(f store.AuditFilter) (string, []any) {
+	var (
+		where []string
+		args  []any
+	)
+	if f.Entity != "" {
+		args = append(args, f.Entity)
+		where = append(where, fmt.Sprintf("entity = $%d", len(args)))
+	}
+	if f.EntityID != 0 {
+		args = append(args, f.EntityID)
+		where = append(where, fmt.Sprintf("entity_id = $%d", len(args)))
+	}
+
+	query := "SELECT id, user_id, request_id, entity, entity_id, action, before, after, created_at FROM audit_log"
+	if len(where) > 0 {
+		query += " WHERE " + strings.Join(where, " AND ")
+	}
+
+	return query + " ORDER BY id;", args
+}
+
+func collectionFilterQuery(f store.CollectionFilter) (string, []any) {
+	var (
+		where []string
+		args  []any
+	)
+	if !f.IncludePrivate {
+		args = append(args, f.ViewerID)
+		where = append(where, fmt.Sprintf("(c.visibility = 'public' OR c.owner_id = $%d)", len(args)))
+	}
+	if f.OwnerID != 0 {
+		args = append(args, f.OwnerID)
+		where = append(where, fmt.Sprintf("c.owner_id = $%d", len(args)))
+	}
+
+	query := "SELECT " + collectionFields + " FROM collections c"
+	if len(where) > 0 {
+		query += " WHERE " + strings.Join(where, " AND ")
+	}
+
+	return query + " ORDER BY c.updated_at DESC, c.id DESC;", args
+}
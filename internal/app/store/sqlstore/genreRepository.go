@@ -0,0 +1,221 @@
+package sqlstore
+
+import (
+	"database/sql"
+	"strings"
+
+	"github.com/lib/pq"
+
+	"filmoteka/internal/app/models"
+	"filmoteka/internal/app/store"
+)
+
+// filmGenres selects the genre names of the film in the current row.
+const filmGenres = "ARRAY(SELECT g.name FROM film_genres fg JOIN genres g ON g.id = fg.genre_id WHERE fg.film_id = films.id ORDER BY g.name)"
+
+type GenreRepository struct {
+	store *Store
+}
+
+func (r *GenreRepository) Create(g models.Genre) (int, error) {
+	if err := g.Validate(); err != nil {
+		return 0, ErrValidation
+	}
+
+	var id int
+	if err := r.store.retry(false, func() error {
+		return r.store.db.QueryRow(
+			"INSERT INTO genres (name) VALUES ($1) RETURNING id;",
+			g.Name,
+		).Scan(&id)
+	}); err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (r *GenreRepository) Find(id int) (models.Genre, error) {
+	g := models.Genre{}
+	if err := r.store.retry(true, func() error {
+		return r.store.reader().QueryRow(
+			"SELECT id, name FROM genres WHERE id=$1;",
+			id,
+		).Scan(&g.Id, &g.Name)
+	}); err != nil {
+		switch err {
+		case sql.ErrNoRows:
+			return models.Genre{}, ErrResourceNotFound
+		default:
+			return models.Genre{}, err
+		}
+	}
+
+	return g, nil
+}
+
+func (r *GenreRepository) FindAll() ([]models.Genre, error) {
+	var genres []models.Genre
+	err := r.store.retry(true, func() error {
+		rows, err := r.store.reader().Query("SELECT id, name FROM genres ORDER BY name;")
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		genres = make([]models.Genre, 0)
+		for rows.Next() {
+			g := models.Genre{}
+			if err := rows.Scan(&g.Id, &g.Name); err != nil {
+				return err
+			}
+			genres = append(genres, g)
+		}
+
+		return rows.Err()
+	})
+
+	return genres, err
+}
+
+func (r *GenreRepository) Update(g models.Genre) error {
+	if err := g.Validate(); err != nil {
+		return ErrValidation
+	}
+
+	return r.store.retry(true, func() error {
+		return r.store.inTx(func(tx *sql.Tx) error {
+			result, err := tx.Exec("UPDATE genres SET name=$1 WHERE id=$2;", g.Name, g.Id)
+			if err != nil {
+				return err
+			}
+			updatedRows, err := result.RowsAffected()
+			if err != nil {
+				return err
+			}
+			if updatedRows == 0 {
+				return ErrResourceNotFound
+			}
+
+			return touchGenreFilms(tx, g.Id)
+		})
+	})
+}
+
+func (r *GenreRepository) Delete(id int) error {
+	return r.store.retry(false, func() error {
+		return r.store.inTx(func(tx *sql.Tx) error {
+			// before the cascade drops the links
+			if err := touchGenreFilms(tx, id); err != nil {
+				return err
+			}
+
+			result, err := tx.Exec("DELETE FROM genres WHERE id=$1;", id)
+			if err != nil {
+				return err
+			}
+			deletedRows, err := result.RowsAffected()
+			if err != nil {
+				return err
+			}
+			if deletedRows == 0 {
+				return ErrResourceNotFound
+			}
+
+			return nil
+		})
+	})
+}
+
+// touchGenreFilms moves updated_at of the films linked to the genre, as
+// their JSON carries its name.
+func touchGenreFilms(tx *sql.Tx, id int) error {
+	_, err := tx.Exec("UPDATE films SET updated_at=now() WHERE id IN (SELECT film_id FROM film_genres WHERE genre_id=$1);", id)
+	return err
+}
+
+func (r *FilmRepository) GenreFacets(filter store.FilmFilter) ([]models.GenreCount, error) {
+	where, args := filmWhere(filter)
+	query := "SELECT g.name, count(*) FROM genres g JOIN film_genres fg ON fg.genre_id = g.id JOIN films ON films.id = fg.film_id"
+	if where != "" {
+		query += " WHERE " + where
+	}
+	query += " GROUP BY g.name ORDER BY count(*) DESC, g.name;"
+
+	var facets []models.GenreCount
+	err := r.store.retry(true, func() error {
+		rows, err := r.store.reader().Query(query, args...)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		facets = make([]models.GenreCount, 0)
+		for rows.Next() {
+			c := models.GenreCount{}
+			if err := rows.Scan(&c.Genre, &c.Count); err != nil {
+				return err
+			}
+			facets = append(facets, c)
+		}
+
+		return rows.Err()
+	})
+
+	return facets, err
+}
+
+// setGenres replaces the genres of the film with f.Genres and stores
+// their canonical names back in f. A nil f.Genres leaves them as they
+// are. Unknown names fail with store.ErrUnknownGenre.
+func (r *FilmRepository) setGenres(tx *sql.Tx, f *models.Film) error {
+	if f.Genres == nil {
+		return nil
+	}
+
+	if _, err := tx.Exec("DELETE FROM film_genres WHERE film_id=$1;", f.Id); err != nil {
+		return err
+	}
+
+	seen := map[string]bool{}
+	names := make([]string, 0, len(f.Genres))
+	for _, name := range f.Genres {
+		name = strings.ToLower(name)
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		f.Genres = []string{}
+		return nil
+	}
+
+	rows, err := tx.Query(
+		"WITH linked AS (INSERT INTO film_genres (film_id, genre_id) SELECT $1, id FROM genres WHERE lower(name) = ANY($2) RETURNING genre_id) SELECT g.name FROM linked JOIN genres g ON g.id = linked.genre_id ORDER BY g.name;",
+		f.Id,
+		pq.Array(names),
+	)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	genres := make([]string, 0, len(names))
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return err
+		}
+		genres = append(genres, name)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	if len(genres) != len(names) {
+		return store.ErrUnknownGenre
+	}
+	f.Genres = genres
+
+	return nil
+}
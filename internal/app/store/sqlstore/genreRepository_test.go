@@ -0,0 +1,219 @@
+package sqlstore
+
+import (
+	"testing"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+
+	"filmoteka/internal/app/models"
+	"filmoteka/internal/app/store"
+)
+
+const genreLink = "WITH linked AS (INSERT INTO film_genres (film_id, genre_id) SELECT $1, id FROM genres WHERE lower(name) = ANY($2) RETURNING genre_id) SELECT g.name FROM linked JOIN genres g ON g.id = linked.genre_id ORDER BY g.name;"
+
+func TestGenre_Create(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := New(db)
+	insert := "INSERT INTO genres (name) VALUES ($1) RETURNING id;"
+
+	tests := []struct {
+		name    string
+		mock    func()
+		input   models.Genre
+		want    int
+		wantErr error
+	}{
+		{
+			name:  "Ok",
+			input: models.Genre{Name: "Drama"},
+			mock: func() {
+				mock.ExpectQuery(insert).WithArgs("Drama").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+			},
+			want: 1,
+		},
+		{
+			name:  "Duplicate name",
+			input: models.Genre{Name: "drama"},
+			mock: func() {
+				mock.ExpectQuery(insert).WithArgs("drama").
+					WillReturnError(&pq.Error{Code: "23505"})
+			},
+			wantErr: store.ErrUniqueConstraints,
+		},
+		{
+			name:    "Empty name",
+			input:   models.Genre{},
+			mock:    func() {},
+			wantErr: store.ErrValidation,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+
+			got, err := r.GenreRepo().Create(tt.input)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestGenre_Delete(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := New(db)
+	query := "DELETE FROM genres WHERE id=$1;"
+
+	mock.ExpectBegin()
+	mock.ExpectExec(touchFilms).WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec(query).WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	assert.NoError(t, r.GenreRepo().Delete(1))
+
+	mock.ExpectBegin()
+	mock.ExpectExec(touchFilms).WithArgs(2).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(query).WithArgs(2).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+	assert.ErrorIs(t, r.GenreRepo().Delete(2), store.ErrResourceNotFound)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+const touchFilms = "UPDATE films SET updated_at=now() WHERE id IN (SELECT film_id FROM film_genres WHERE genre_id=$1);"
+
+func TestGenre_Update(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := New(db)
+	query := "UPDATE genres SET name=$1 WHERE id=$2;"
+
+	// films carrying the genre show the new name
+	mock.ExpectBegin()
+	mock.ExpectExec(query).WithArgs("Melodrama", 1).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(touchFilms).WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+	assert.NoError(t, r.GenreRepo().Update(models.Genre{Id: 1, Name: "Melodrama"}))
+
+	mock.ExpectBegin()
+	mock.ExpectExec(query).WithArgs("Melodrama", 2).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+	assert.ErrorIs(t, r.GenreRepo().Update(models.Genre{Id: 2, Name: "Melodrama"}), store.ErrResourceNotFound)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFilm_SetGenres(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := New(db)
+	unlink := "DELETE FROM film_genres WHERE film_id=$1;"
+
+	tests := []struct {
+		name    string
+		genres  []string
+		mock    func()
+		want    []string
+		wantErr error
+	}{
+		{
+			name:   "Nil keeps genres",
+			genres: nil,
+			mock:   func() {},
+			want:   nil,
+		},
+		{
+			name:   "Empty clears genres",
+			genres: []string{},
+			mock: func() {
+				mock.ExpectExec(unlink).WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 2))
+			},
+			want: []string{},
+		},
+		{
+			name:   "Names canonicalised and deduplicated",
+			genres: []string{"drama", "Comedy", "DRAMA"},
+			mock: func() {
+				mock.ExpectExec(unlink).WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery(genreLink).WithArgs(1, "{\"drama\",\"comedy\"}").
+					WillReturnRows(sqlmock.NewRows([]string{"name"}).AddRow("Comedy").AddRow("Drama"))
+			},
+			want: []string{"Comedy", "Drama"},
+		},
+		{
+			name:   "Unknown genre",
+			genres: []string{"Drama", "Space Opera"},
+			mock: func() {
+				mock.ExpectExec(unlink).WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery(genreLink).WithArgs(1, "{\"drama\",\"space opera\"}").
+					WillReturnRows(sqlmock.NewRows([]string{"name"}).AddRow("Drama"))
+			},
+			wantErr: store.ErrUnknownGenre,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock.ExpectBegin()
+			tt.mock()
+			mock.ExpectRollback()
+
+			tx, err := db.Begin()
+			assert.NoError(t, err)
+
+			f := models.Film{Id: 1, Genres: tt.genres}
+			err = r.FilmRepo().(*FilmRepository).setGenres(tx, &f)
+			assert.NoError(t, tx.Rollback())
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, f.Genres)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestFilm_GenreFacets(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := New(db)
+
+	mock.ExpectQuery("SELECT g.name, count(*) FROM genres g JOIN film_genres fg ON fg.genre_id = g.id JOIN films ON films.id = fg.film_id WHERE films.deleted_at IS NULL GROUP BY g.name ORDER BY count(*) DESC, g.name;").
+		WillReturnRows(sqlmock.NewRows([]string{"name", "count"}).AddRow("Drama", 3).AddRow("Comedy", 1))
+
+	got, err := r.FilmRepo().GenreFacets(store.FilmFilter{})
+	assert.NoError(t, err)
+	assert.Equal(t, []models.GenreCount{{Genre: "Drama", Count: 3}, {Genre: "Comedy", Count: 1}}, got)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,93 @@
+package sqlstore
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+
+	"filmoteka/internal/app/models"
+)
+
+// storedImage is the JSON an image is kept as, with the storage key the
+// API leaves out.
+type storedImage struct {
+	Key        string            `json:"key"`
+	URL        string            `json:"url"`
+	Thumbnails map[string]string `json:"thumbnails"`
+}
+
+// imageColumn scans a nullable image column into an image pointer.
+type imageColumn struct {
+	img **models.Image
+}
+
+func imageDest(img **models.Image) imageColumn {
+	return imageColumn{img: img}
+}
+
+func (c imageColumn) Scan(src any) error {
+	var data []byte
+	switch v := src.(type) {
+	case nil:
+		*c.img = nil
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("cannot scan %T into an image", src)
+	}
+
+	var s storedImage
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	*c.img = &models.Image{Key: s.Key, URL: s.URL, Thumbnails: s.Thumbnails}
+
+	return nil
+}
+
+// imageValue returns the column value an image is written as.
+func imageValue(img models.Image) (driver.Value, error) {
+	return json.Marshal(storedImage{Key: img.Key, URL: img.URL, Thumbnails: img.Thumbnails})
+}
+
+type MediaRepository struct {
+	store *Store
+}
+
+func (r *MediaRepository) SetPoster(filmID int, img models.Image) (*models.Image, error) {
+	return r.set("films", "poster", filmID, img)
+}
+
+func (r *MediaRepository) SetPhoto(actorID int, img models.Image) (*models.Image, error) {
+	return r.set("actors", "photo", actorID, img)
+}
+
+// set writes the image column of a live row of table, reading the image
+// it replaces from the row locked beforehand. updated_at moves with it, so
+// clients revalidating the row do not keep links to removed files.
+func (r *MediaRepository) set(table, column string, id int, img models.Image) (*models.Image, error) {
+	value, err := imageValue(img)
+	if err != nil {
+		return nil, err
+	}
+
+	var prev *models.Image
+	if err := r.store.retry(true, func() error {
+		return r.store.db.QueryRow(
+			"UPDATE "+table+" t SET "+column+"=$2, updated_at=now() FROM (SELECT id, "+column+" FROM "+table+" WHERE id=$1 AND deleted_at IS NULL FOR UPDATE) old WHERE t.id = old.id RETURNING old."+column+";",
+			id,
+			value,
+		).Scan(imageDest(&prev))
+	}); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrResourceNotFound
+		}
+		return nil, err
+	}
+
+	return prev, nil
+}
@@ -0,0 +1,73 @@
+package sqlstore
+
+import (
+	"testing"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	"filmoteka/internal/app/models"
+)
+
+func TestMedia_SetPoster(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := New(db)
+	query := "UPDATE films t SET poster=$2, updated_at=now() FROM (SELECT id, poster FROM films WHERE id=$1 AND deleted_at IS NULL FOR UPDATE) old WHERE t.id = old.id RETURNING old.poster;"
+	img := models.Image{
+		Key:        "films/1/poster/bb",
+		URL:        "/media/films/1/poster/bb/original.png",
+		Thumbnails: map[string]string{"small": "/media/films/1/poster/bb/w92.jpg"},
+	}
+	stored := `{"key":"films/1/poster/bb","url":"/media/films/1/poster/bb/original.png","thumbnails":{"small":"/media/films/1/poster/bb/w92.jpg"}}`
+
+	tests := []struct {
+		name    string
+		id      int
+		mock    func()
+		want    *models.Image
+		wantErr error
+	}{
+		{
+			name: "First",
+			id:   1,
+			mock: func() {
+				mock.ExpectQuery(query).WithArgs(1, []byte(stored)).
+					WillReturnRows(sqlmock.NewRows([]string{"poster"}).AddRow(nil))
+			},
+		},
+		{
+			name: "Replaced",
+			id:   1,
+			mock: func() {
+				mock.ExpectQuery(query).WithArgs(1, []byte(stored)).
+					WillReturnRows(sqlmock.NewRows([]string{"poster"}).AddRow([]byte(`{"key":"films/1/poster/aa","url":"/media/films/1/poster/aa/original.jpg","thumbnails":{}}`)))
+			},
+			want: &models.Image{Key: "films/1/poster/aa", URL: "/media/films/1/poster/aa/original.jpg", Thumbnails: map[string]string{}},
+		},
+		{
+			name: "Not Found",
+			id:   9,
+			mock: func() {
+				mock.ExpectQuery(query).WithArgs(9, []byte(stored)).
+					WillReturnRows(sqlmock.NewRows([]string{"poster"}))
+			},
+			wantErr: ErrResourceNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+
+			got, err := r.MediaRepo().SetPoster(tt.id, img)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
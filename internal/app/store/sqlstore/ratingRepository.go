@@ -0,0 +1,132 @@
+package sqlstore
+
+import (
+	"database/sql"
+
+	"filmoteka/internal/app/models"
+)
+
+type RatingRepository struct {
+	store *Store
+}
+
+func (r *RatingRepository) Rate(ur models.UserRating) (models.RatingSummary, error) {
+	if err := ur.Validate(); err != nil {
+		return models.RatingSummary{}, ErrValidation
+	}
+
+	var s models.RatingSummary
+	err := r.store.retry(true, func() error {
+		return r.store.inTx(func(tx *sql.Tx) error {
+			if err := lockFilm(tx, ur.FilmID); err != nil {
+				return err
+			}
+
+			added, prev := 0, 0
+			err := tx.QueryRow(
+				"SELECT rating FROM user_ratings WHERE film_id=$1 AND user_id=$2;",
+				ur.FilmID,
+				ur.UserID,
+			).Scan(&prev)
+			switch {
+			case err == sql.ErrNoRows:
+				added = 1
+			case err != nil:
+				return err
+			}
+
+			if _, err := tx.Exec(
+				"INSERT INTO user_ratings (film_id, user_id, rating) VALUES ($1, $2, $3) ON CONFLICT (film_id, user_id) DO UPDATE SET rating=EXCLUDED.rating, updated_at=now();",
+				ur.FilmID,
+				ur.UserID,
+				ur.Rating,
+			); err != nil {
+				return err
+			}
+
+			s, err = r.adjust(tx, ur.FilmID, added, ur.Rating-prev)
+			return err
+		})
+	})
+
+	return s, err
+}
+
+func (r *RatingRepository) Find(filmID, userID int) (models.UserRating, error) {
+	ur := models.UserRating{}
+	if err := r.store.retry(true, func() error {
+		return r.store.reader().QueryRow(
+			"SELECT ur.film_id, ur.user_id, ur.rating, ur.updated_at FROM user_ratings ur JOIN films f ON f.id = ur.film_id WHERE ur.film_id=$1 AND ur.user_id=$2 AND f.deleted_at IS NULL;",
+			filmID,
+			userID,
+		).Scan(&ur.FilmID, &ur.UserID, &ur.Rating, &ur.UpdatedAt)
+	}); err != nil {
+		if err == sql.ErrNoRows {
+			return models.UserRating{}, ErrResourceNotFound
+		}
+		return models.UserRating{}, err
+	}
+
+	return ur, nil
+}
+
+func (r *RatingRepository) Remove(filmID, userID int) (models.RatingSummary, error) {
+	var s models.RatingSummary
+	err := r.store.retry(false, func() error {
+		return r.store.inTx(func(tx *sql.Tx) error {
+			if err := lockFilm(tx, filmID); err != nil {
+				return err
+			}
+
+			var prev int
+			err := tx.QueryRow(
+				"DELETE FROM user_ratings WHERE film_id=$1 AND user_id=$2 RETURNING rating;",
+				filmID,
+				userID,
+			).Scan(&prev)
+			switch {
+			case err == sql.ErrNoRows:
+				return ErrResourceNotFound
+			case err != nil:
+				return err
+			}
+
+			s, err = r.adjust(tx, filmID, -1, -prev)
+			return err
+		})
+	})
+
+	return s, err
+}
+
+// adjust moves the running totals of the film by the given deltas and
+// returns its new summary. The film's updated_at moves too, its ETag and
+// Last-Modified cover the summary.
+func (r *RatingRepository) adjust(tx *sql.Tx, filmID, count, sum int) (models.RatingSummary, error) {
+	var (
+		editor float32
+		t      ratingTotals
+	)
+	if err := tx.QueryRow(
+		"UPDATE films SET user_rating_count=user_rating_count+$2, user_rating_sum=user_rating_sum+$3, updated_at=now() WHERE id=$1 RETURNING COALESCE(rating, 0), "+filmRatings+";",
+		filmID,
+		count,
+		sum,
+	).Scan(&editor, &t.count, &t.sum); err != nil {
+		return models.RatingSummary{}, err
+	}
+
+	return models.Summarize(editor, t.count, t.sum, r.store.ratingWeight), nil
+}
+
+// lockFilm locks the live film row until tx ends, so concurrent ratings
+// of the film apply their deltas one after another.
+func lockFilm(tx *sql.Tx, id int) error {
+	var one int
+	err := tx.QueryRow("SELECT 1 FROM films WHERE id=$1 AND deleted_at IS NULL FOR UPDATE;", id).Scan(&one)
+	if err == sql.ErrNoRows {
+		return ErrResourceNotFound
+	}
+
+	return err
+}
@@ -0,0 +1,125 @@
+package sqlstore
+
+import (
+	"testing"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	"filmoteka/internal/app/models"
+	"filmoteka/internal/app/store"
+)
+
+const (
+	ratingLock   = "SELECT 1 FROM films WHERE id=$1 AND deleted_at IS NULL FOR UPDATE;"
+	ratingPrev   = "SELECT rating FROM user_ratings WHERE film_id=$1 AND user_id=$2;"
+	ratingUpsert = "INSERT INTO user_ratings (film_id, user_id, rating) VALUES ($1, $2, $3) ON CONFLICT (film_id, user_id) DO UPDATE SET rating=EXCLUDED.rating, updated_at=now();"
+	ratingAdjust = "UPDATE films SET user_rating_count=user_rating_count+$2, user_rating_sum=user_rating_sum+$3, updated_at=now() WHERE id=$1 RETURNING COALESCE(rating, 0), " + filmRatings + ";"
+)
+
+func TestRating_Rate(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := New(db, WithRatingWeight(2))
+	totals := []string{"rating", "user_rating_count", "user_rating_sum"}
+
+	tests := []struct {
+		name    string
+		input   models.UserRating
+		mock    func()
+		want    models.RatingSummary
+		wantErr error
+	}{
+		{
+			name:  "First rating",
+			input: models.UserRating{FilmID: 1, UserID: 2, Rating: 9},
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(ratingLock).WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"?column?"}).AddRow(1))
+				mock.ExpectQuery(ratingPrev).WithArgs(1, 2).WillReturnRows(sqlmock.NewRows([]string{"rating"}))
+				mock.ExpectExec(ratingUpsert).WithArgs(1, 2, 9).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery(ratingAdjust).WithArgs(1, 1, 9).
+					WillReturnRows(sqlmock.NewRows(totals).AddRow(6, 2, 17))
+				mock.ExpectCommit()
+			},
+			// (2*6 + 17) / (2 + 2)
+			want: models.RatingSummary{Count: 2, Average: 8.5, Score: 7.25},
+		},
+		{
+			name:  "Changed rating",
+			input: models.UserRating{FilmID: 1, UserID: 2, Rating: 4},
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(ratingLock).WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"?column?"}).AddRow(1))
+				mock.ExpectQuery(ratingPrev).WithArgs(1, 2).WillReturnRows(sqlmock.NewRows([]string{"rating"}).AddRow(9))
+				mock.ExpectExec(ratingUpsert).WithArgs(1, 2, 4).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery(ratingAdjust).WithArgs(1, 0, -5).
+					WillReturnRows(sqlmock.NewRows(totals).AddRow(6, 2, 12))
+				mock.ExpectCommit()
+			},
+			want: models.RatingSummary{Count: 2, Average: 6, Score: 6},
+		},
+		{
+			name:  "Deleted film",
+			input: models.UserRating{FilmID: 1, UserID: 2, Rating: 4},
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(ratingLock).WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"?column?"}))
+				mock.ExpectRollback()
+			},
+			wantErr: store.ErrResourceNotFound,
+		},
+		{
+			name:    "Out of range",
+			input:   models.UserRating{FilmID: 1, UserID: 2, Rating: 11},
+			mock:    func() {},
+			wantErr: store.ErrValidation,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+
+			got, err := r.RatingRepo().Rate(tt.input)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestRating_Remove(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := New(db)
+	remove := "DELETE FROM user_ratings WHERE film_id=$1 AND user_id=$2 RETURNING rating;"
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(ratingLock).WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"?column?"}).AddRow(1))
+	mock.ExpectQuery(remove).WithArgs(1, 2).WillReturnRows(sqlmock.NewRows([]string{"rating"}).AddRow(9))
+	mock.ExpectQuery(ratingAdjust).WithArgs(1, -1, -9).
+		WillReturnRows(sqlmock.NewRows([]string{"rating", "user_rating_count", "user_rating_sum"}).AddRow(6, 0, 0))
+	mock.ExpectCommit()
+
+	got, err := r.RatingRepo().Remove(1, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, models.RatingSummary{Score: 6}, got)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(ratingLock).WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"?column?"}).AddRow(1))
+	mock.ExpectQuery(remove).WithArgs(1, 3).WillReturnRows(sqlmock.NewRows([]string{"rating"}))
+	mock.ExpectRollback()
+
+	_, err = r.RatingRepo().Remove(1, 3)
+	assert.ErrorIs(t, err, store.ErrResourceNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
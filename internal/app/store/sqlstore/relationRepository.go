@@ -0,0 +1,151 @@
+package sqlstore
+
+import (
+	"database/sql"
+
+	"filmoteka/internal/app/models"
+	"filmoteka/internal/app/store"
+)
+
+// sequelLock is the advisory lock key taken while adding sequels, so two
+// concurrent additions cannot close a cycle between them.
+const sequelLock = 470001
+
+type RelationRepository struct {
+	store *Store
+}
+
+func (r *RelationRepository) Add(rel models.FilmRelation) error {
+	if err := rel.Validate(); err != nil {
+		return ErrValidation
+	}
+	rel = rel.Canonical()
+
+	return r.store.retry(false, func() error {
+		return r.store.inTx(func(tx *sql.Tx) error {
+			if rel.Type == models.RelationSequel {
+				if err := checkSequel(tx, rel); err != nil {
+					return err
+				}
+			}
+
+			result, err := tx.Exec(
+				"INSERT INTO film_relations (film_id, related_id, type) SELECT $1, $2, $3::film_relation WHERE EXISTS (SELECT 1 FROM films WHERE id=$1 AND deleted_at IS NULL) AND EXISTS (SELECT 1 FROM films WHERE id=$2 AND deleted_at IS NULL);",
+				rel.FilmID,
+				rel.RelatedID,
+				rel.Type,
+			)
+			if err != nil {
+				return err
+			}
+
+			insertedRows, err := result.RowsAffected()
+			if err != nil {
+				return err
+			}
+			if insertedRows == 0 {
+				return ErrResourceNotFound
+			}
+
+			return nil
+		})
+	})
+}
+
+// checkSequel fails with ErrSequelCycle when the film already follows its
+// new sequel somewhere down the chain of sequels.
+func checkSequel(tx *sql.Tx, rel models.FilmRelation) error {
+	if _, err := tx.Exec("SELECT pg_advisory_xact_lock($1);", sequelLock); err != nil {
+		return err
+	}
+
+	var cycle bool
+	if err := tx.QueryRow(
+		"WITH RECURSIVE later(id) AS (SELECT $1::integer UNION SELECT r.related_id FROM film_relations r JOIN later l ON r.film_id = l.id WHERE r.type = 'sequel') SELECT EXISTS (SELECT 1 FROM later WHERE id=$2);",
+		rel.RelatedID,
+		rel.FilmID,
+	).Scan(&cycle); err != nil {
+		return err
+	}
+	if cycle {
+		return store.ErrSequelCycle
+	}
+
+	return nil
+}
+
+func (r *RelationRepository) Remove(rel models.FilmRelation) error {
+	rel = rel.Canonical()
+
+	var result sql.Result
+	if err := r.store.retry(false, func() (err error) {
+		result, err = r.store.db.Exec(
+			"DELETE FROM film_relations WHERE film_id=$1 AND related_id=$2 AND type::text=$3;",
+			rel.FilmID,
+			rel.RelatedID,
+			rel.Type,
+		)
+		return err
+	}); err != nil {
+		return err
+	}
+
+	deletedRows, err := result.RowsAffected()
+	if err != nil {
+		return translateError(err)
+	}
+	if deletedRows == 0 {
+		return ErrResourceNotFound
+	}
+
+	return nil
+}
+
+func (r *RelationRepository) ByFilm(filmID int) (models.RelatedFilms, error) {
+	var related models.RelatedFilms
+	err := r.store.retry(true, func() error {
+		db := r.store.reader()
+		var found bool
+		if err := db.QueryRow(
+			"SELECT EXISTS (SELECT 1 FROM films WHERE id=$1 AND deleted_at IS NULL);",
+			filmID,
+		).Scan(&found); err != nil {
+			return err
+		}
+		if !found {
+			return ErrResourceNotFound
+		}
+
+		// relations stored from the other film are read inverted
+		rows, err := db.Query(
+			"SELECT r.type::text, false, f.id, f.name, f.release_year FROM film_relations r JOIN films f ON f.id = r.related_id WHERE r.film_id=$1 AND f.deleted_at IS NULL "+
+				"UNION ALL SELECT r.type::text, true, f.id, f.name, f.release_year FROM film_relations r JOIN films f ON f.id = r.film_id WHERE r.related_id=$1 AND f.deleted_at IS NULL "+
+				"ORDER BY 5, 4;",
+			filmID,
+		)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		related = models.RelatedFilms{}
+		for rows.Next() {
+			var (
+				kind     string
+				inverted bool
+				f        models.RelatedFilm
+			)
+			if err := rows.Scan(&kind, &inverted, &f.Id, &f.Name, &f.ReleaseYear); err != nil {
+				return err
+			}
+			if inverted {
+				kind = models.InverseRelation(kind)
+			}
+			related[kind] = append(related[kind], f)
+		}
+
+		return rows.Err()
+	})
+
+	return related, err
+}
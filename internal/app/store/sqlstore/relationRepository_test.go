@@ -0,0 +1,127 @@
+package sqlstore
+
+import (
+	"testing"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	"filmoteka/internal/app/models"
+	"filmoteka/internal/app/store"
+)
+
+const (
+	relationInsert = "INSERT INTO film_relations (film_id, related_id, type) SELECT $1, $2, $3::film_relation WHERE EXISTS (SELECT 1 FROM films WHERE id=$1 AND deleted_at IS NULL) AND EXISTS (SELECT 1 FROM films WHERE id=$2 AND deleted_at IS NULL);"
+	relationLock   = "SELECT pg_advisory_xact_lock($1);"
+	relationCycle  = "WITH RECURSIVE later(id) AS (SELECT $1::integer UNION SELECT r.related_id FROM film_relations r JOIN later l ON r.film_id = l.id WHERE r.type = 'sequel') SELECT EXISTS (SELECT 1 FROM later WHERE id=$2);"
+)
+
+func TestRelation_Add(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := New(db)
+
+	tests := []struct {
+		name    string
+		input   models.FilmRelation
+		mock    func()
+		wantErr error
+	}{
+		{
+			name:  "Prequel stored as sequel",
+			input: models.FilmRelation{FilmID: 2, RelatedID: 1, Type: "prequel"},
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(relationLock).WithArgs(sequelLock).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery(relationCycle).WithArgs(2, 1).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+				mock.ExpectExec(relationInsert).WithArgs(1, 2, "sequel").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:  "Sequel cycle",
+			input: models.FilmRelation{FilmID: 3, RelatedID: 1, Type: "sequel"},
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(relationLock).WithArgs(sequelLock).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery(relationCycle).WithArgs(1, 3).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+				mock.ExpectRollback()
+			},
+			wantErr: store.ErrSequelCycle,
+		},
+		{
+			name:  "Franchise from the lower id",
+			input: models.FilmRelation{FilmID: 4, RelatedID: 2, Type: "franchise"},
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(relationInsert).WithArgs(2, 4, "franchise").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:  "Deleted film",
+			input: models.FilmRelation{FilmID: 1, RelatedID: 9, Type: "remake"},
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(relationInsert).WithArgs(1, 9, "remake").WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+			},
+			wantErr: store.ErrResourceNotFound,
+		},
+		{
+			name:    "Self",
+			input:   models.FilmRelation{FilmID: 1, RelatedID: 1, Type: "sequel"},
+			mock:    func() {},
+			wantErr: store.ErrValidation,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+
+			err := r.RelationRepo().Add(tt.input)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestRelation_ByFilm(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := New(db)
+	exists := "SELECT EXISTS (SELECT 1 FROM films WHERE id=$1 AND deleted_at IS NULL);"
+
+	mock.ExpectQuery(exists).WithArgs(2).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	mock.ExpectQuery("SELECT r.type::text, false, f.id, f.name, f.release_year FROM film_relations r JOIN films f ON f.id = r.related_id WHERE r.film_id=$1 AND f.deleted_at IS NULL " +
+		"UNION ALL SELECT r.type::text, true, f.id, f.name, f.release_year FROM film_relations r JOIN films f ON f.id = r.film_id WHERE r.related_id=$1 AND f.deleted_at IS NULL " +
+		"ORDER BY 5, 4;").
+		WithArgs(2).
+		WillReturnRows(sqlmock.NewRows([]string{"type", "bool", "id", "name", "release_year"}).
+			AddRow("sequel", true, 1, "Alien", 1979).
+			AddRow("sequel", false, 3, "Alien 3", 1992).
+			AddRow("franchise", true, 5, "Prometheus", 2012))
+
+	got, err := r.RelationRepo().ByFilm(2)
+	assert.NoError(t, err)
+	assert.Equal(t, models.RelatedFilms{
+		"prequel":   {{Id: 1, Name: "Alien", ReleaseYear: 1979}},
+		"sequel":    {{Id: 3, Name: "Alien 3", ReleaseYear: 1992}},
+		"franchise": {{Id: 5, Name: "Prometheus", ReleaseYear: 2012}},
+	}, got)
+
+	mock.ExpectQuery(exists).WithArgs(9).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+	_, err = r.RelationRepo().ByFilm(9)
+	assert.ErrorIs(t, err, store.ErrResourceNotFound)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,104 @@
+package sqlstore
+
+import (
+	"database/sql"
+	"log/slog"
+	"sync/atomic"
+	"time"
+)
+
+type replica struct {
+	db      *sql.DB
+	healthy atomic.Bool
+}
+
+// replicaSet balances reads over the healthy replicas round-robin.
+type replicaSet struct {
+	replicas []*replica
+	next     atomic.Uint64
+}
+
+func newReplicaSet(dbs []*sql.DB) *replicaSet {
+	rs := &replicaSet{}
+	for _, db := range dbs {
+		r := &replica{db: db}
+		r.healthy.Store(true)
+		rs.replicas = append(rs.replicas, r)
+	}
+
+	return rs
+}
+
+// pick returns the next healthy replica or nil when none is available.
+func (rs *replicaSet) pick() *sql.DB {
+	n := len(rs.replicas)
+	start := rs.next.Add(1)
+	for i := 0; i < n; i++ {
+		r := rs.replicas[(start+uint64(i))%uint64(n)]
+		if r.healthy.Load() {
+			return r.db
+		}
+	}
+
+	return nil
+}
+
+func (rs *replicaSet) check() {
+	for i, r := range rs.replicas {
+		err := r.db.Ping()
+		if was := r.healthy.Swap(err == nil); was != (err == nil) {
+			if err != nil {
+				slog.Warn("read replica unhealthy", "replica", i, "error", err)
+			} else {
+				slog.Info("read replica healthy again", "replica", i)
+			}
+		}
+	}
+}
+
+// WithReplicas routes reads outside transactions to the given replicas.
+func WithReplicas(dbs ...*sql.DB) Option {
+	return func(s *Store) {
+		if len(dbs) > 0 {
+			s.replicas = newReplicaSet(dbs)
+		}
+	}
+}
+
+// StartHealthChecks pings the replicas every interval, taking failing
+// ones out of rotation until they answer again. Call stop to end it.
+func (s *Store) StartHealthChecks(interval time.Duration) (stop func()) {
+	if s.replicas == nil || interval <= 0 {
+		return func() {}
+	}
+
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		s.replicas.check()
+		for {
+			select {
+			case <-ticker.C:
+				s.replicas.check()
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return func() { close(done) }
+}
+
+// reader returns the connection pool reads should use.
+func (s *Store) reader() *sql.DB {
+	if s.replicas == nil || s.pinned {
+		return s.db
+	}
+	if db := s.replicas.pick(); db != nil {
+		return db
+	}
+
+	return s.db
+}
@@ -0,0 +1,65 @@
+package sqlstore
+
+import (
+	"testing"
+	"time"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReplicaRouting(t *testing.T) {
+	primary, primaryMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer primary.Close()
+	replica, replicaMock, err := sqlmock.New(
+		sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual),
+		sqlmock.MonitorPingsOption(true),
+	)
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer replica.Close()
+
+	s := New(primary, WithReplicas(replica))
+
+	find := "SELECT id, name, gender, birth_date, " + actorProfile + ", updated_at, " + actorPhoto + " FROM actors WHERE id = $1 AND deleted_at IS NULL;"
+	columns := []string{"id", "name", "gender", "birth_date", "death_date", "birthplace", "biography", "aliases", "updated_at"}
+	found := append(append([]string{}, columns...), "photo")
+	updatedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	// reads go to the replica
+	replicaMock.ExpectQuery(find).WithArgs(1).
+		WillReturnRows(sqlmock.NewRows(found).AddRow(1, "Name 1", "male", "1980-01-01", "", "", "", "{}", updatedAt, nil))
+	_, err = s.ActorRepo().Find(1)
+	assert.NoError(t, err)
+
+	// writes go to the primary
+	primaryMock.ExpectBegin()
+	primaryMock.ExpectQuery(actorLock).WithArgs(1).
+		WillReturnRows(sqlmock.NewRows(columns).AddRow(1, "Name 1", "male", "1980-01-01", "", "", "", "{}", updatedAt))
+	primaryMock.ExpectExec("UPDATE actors SET deleted_at=now(), updated_at=now() WHERE id=$1;").
+		WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+	primaryMock.ExpectExec(auditInsert).WillReturnResult(sqlmock.NewResult(1, 1))
+	primaryMock.ExpectCommit()
+	assert.NoError(t, s.ActorRepo().Delete(1))
+
+	// read your writes pins reads to the primary
+	primaryMock.ExpectQuery(find).WithArgs(1).
+		WillReturnRows(sqlmock.NewRows(found).AddRow(1, "Name 1", "male", "1980-01-01", "", "", "", "{}", updatedAt, nil))
+	_, err = s.Primary().ActorRepo().Find(1)
+	assert.NoError(t, err)
+
+	// a replica failing its health check is taken out of rotation
+	replicaMock.ExpectPing().WillReturnError(sqlmock.ErrCancelled)
+	s.replicas.check()
+	primaryMock.ExpectQuery(find).WithArgs(1).
+		WillReturnRows(sqlmock.NewRows(found).AddRow(1, "Name 1", "male", "1980-01-01", "", "", "", "{}", updatedAt, nil))
+	_, err = s.ActorRepo().Find(1)
+	assert.NoError(t, err)
+
+	assert.NoError(t, primaryMock.ExpectationsWereMet())
+	assert.NoError(t, replicaMock.ExpectationsWereMet())
+}
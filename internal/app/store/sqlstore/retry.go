@@ -0,0 +1,57 @@
+package sqlstore
+
+import (
+	"errors"
+	"math/rand"
+	"time"
+
+	"filmoteka/internal/app/store"
+)
+
+// RetryPolicy controls how transient database failures are retried.
+type RetryPolicy struct {
+	// MaxAttempts counts the first try; values below 2 disable retries.
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+}
+
+// retry runs fn until it succeeds, fails permanently or the policy gives
+// up, sleeping with exponential backoff and full jitter between attempts.
+// Serialization failures and deadlocks abort the statement server side
+// and are always safe to replay. A lost connection leaves the outcome
+// unknown, so it is only retried for idempotent operations; deletes
+// reporting a missing row are not, as a replay would find nothing left.
+func (s *Store) retry(idempotent bool, fn func() error) error {
+	var err error
+	for attempt := 0; ; attempt++ {
+		err = translateError(fn())
+		if err == nil || !s.retryable(err, idempotent) || attempt+1 >= s.retryPolicy.MaxAttempts {
+			return err
+		}
+
+		s.sleep(s.backoff(attempt))
+	}
+}
+
+func (s *Store) retryable(err error, idempotent bool) bool {
+	if errors.Is(err, store.ErrSerialization) || errors.Is(err, store.ErrDeadlock) {
+		return true
+	}
+
+	return idempotent && errors.Is(err, store.ErrConnectionLost)
+}
+
+func (s *Store) backoff(attempt int) time.Duration {
+	p := s.retryPolicy
+	if p.BaseDelay <= 0 {
+		return 0
+	}
+
+	d := p.BaseDelay << attempt
+	if d <= 0 || (p.MaxDelay > 0 && d > p.MaxDelay) {
+		d = p.MaxDelay
+	}
+
+	return time.Duration(rand.Int63n(int64(d) + 1))
+}
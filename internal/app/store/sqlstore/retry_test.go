@@ -0,0 +1,142 @@
+package sqlstore
+
+import (
+	"testing"
+	"time"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+
+	"filmoteka/internal/app/models"
+	"filmoteka/internal/app/store"
+)
+
+func TestRetry(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	var slept []time.Duration
+	r := New(db, WithRetry(RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 2 * time.Millisecond}))
+	r.sleep = func(d time.Duration) { slept = append(slept, d) }
+
+	film := models.Film{Name: "Film 1", Description: "Descr 1", ReleaseYear: 2001, Rating: 5}
+	insert := filmInsert + " RETURNING id;"
+	find := "SELECT " + filmFields + ", " + filmGenres + ", " + filmRatings + ", " + filmPoster + " FROM films WHERE id=$1 AND deleted_at IS NULL"
+
+	tests := []struct {
+		name      string
+		mock      func()
+		call      func() error
+		wantErr   error
+		wantSleep int
+	}{
+		{
+			name: "Serialization failure retried on insert",
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(insert).WillReturnError(&pq.Error{Code: "40001"})
+				mock.ExpectRollback()
+				mock.ExpectBegin()
+				mock.ExpectQuery(insert).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				mock.ExpectExec(auditInsert).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec(revisionInsert).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+			call: func() error {
+				_, err := r.FilmRepo().Create(film)
+				return err
+			},
+			wantSleep: 1,
+		},
+		{
+			name: "Lost connection not replayed on insert",
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(insert).WillReturnError(&pq.Error{Code: "08006"})
+				mock.ExpectRollback()
+			},
+			call: func() error {
+				_, err := r.FilmRepo().Create(film)
+				return err
+			},
+			wantErr: store.ErrConnectionLost,
+		},
+		{
+			name: "Lost commit not replayed on audited delete",
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(filmLock).WithArgs(1).
+					WillReturnRows(sqlmock.NewRows(filmColumns).AddRow(1, "Film 1", "Descr 1", 2001, 5, 0, "", "", "{}", "", time.Now(), "{}"))
+				mock.ExpectExec("UPDATE films SET deleted_at=now(), updated_at=now() WHERE id=$1;").
+					WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(auditInsert).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit().WillReturnError(&pq.Error{Code: "08006"})
+			},
+			call: func() error {
+				return r.FilmRepo().Delete(1)
+			},
+			wantErr: store.ErrConnectionLost,
+		},
+		{
+			name: "Lost connection not replayed on delete",
+			mock: func() {
+				mock.ExpectExec("DELETE FROM watchlist WHERE user_id=$1 AND film_id=$2;").
+					WithArgs(1, 2).WillReturnError(&pq.Error{Code: "08006"})
+			},
+			call: func() error {
+				return r.WatchlistRepo().Remove(1, 2)
+			},
+			wantErr: store.ErrConnectionLost,
+		},
+		{
+			name: "Lost connection retried on read until attempts run out",
+			mock: func() {
+				mock.ExpectQuery(find).WithArgs(1).WillReturnError(&pq.Error{Code: "57P01"})
+				mock.ExpectQuery(find).WithArgs(1).WillReturnError(&pq.Error{Code: "57P01"})
+				mock.ExpectQuery(find).WithArgs(1).WillReturnError(&pq.Error{Code: "57P01"})
+			},
+			call: func() error {
+				_, err := r.FilmRepo().Find(1)
+				return err
+			},
+			wantErr:   store.ErrConnectionLost,
+			wantSleep: 2,
+		},
+		{
+			name: "Permanent error not retried",
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(insert).WillReturnError(&pq.Error{Code: "23505"})
+				mock.ExpectRollback()
+			},
+			call: func() error {
+				_, err := r.FilmRepo().Create(film)
+				return err
+			},
+			wantErr: store.ErrUniqueConstraints,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			slept = nil
+			tt.mock()
+
+			err := tt.call()
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Len(t, slept, tt.wantSleep)
+			for _, d := range slept {
+				assert.LessOrEqual(t, d, 2*time.Millisecond)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
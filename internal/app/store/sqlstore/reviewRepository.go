@@ -0,0 +1,187 @@
+package sqlstore
+
+import (
+	"database/sql"
+
+	"filmoteka/internal/app/models"
+	"filmoteka/internal/app/store"
+)
+
+// reviewFields selects the review columns in the order reviewDest scans
+// them, from reviews aliased r.
+const reviewFields = "r.id, r.film_id, r.user_id, COALESCE(r.title, ''), r.body, r.status::text, r.created_at, r.updated_at"
+
+// reviewLock is the advisory lock class under which checkRate locks a
+// user, keyed by the user id.
+const reviewLock = 470002
+
+type ReviewRepository struct {
+	store *Store
+}
+
+func reviewDest(rv *models.Review) []any {
+	return []any{
+		&rv.Id,
+		&rv.FilmID,
+		&rv.UserID,
+		&rv.Title,
+		&rv.Body,
+		&rv.Status,
+		&rv.CreatedAt,
+		&rv.UpdatedAt,
+	}
+}
+
+func (r *ReviewRepository) Create(rv models.Review) (models.Review, error) {
+	if err := rv.Validate(); err != nil {
+		return models.Review{}, ErrValidation
+	}
+
+	if err := r.store.retry(false, func() error {
+		return r.store.inTx(func(tx *sql.Tx) error {
+			if err := lockFilm(tx, rv.FilmID); err != nil {
+				return err
+			}
+			if err := r.checkRate(tx, rv.UserID); err != nil {
+				return err
+			}
+
+			return tx.QueryRow(
+				"INSERT INTO reviews (film_id, user_id, title, body) VALUES ($1, $2, NULLIF($3, ''), $4) RETURNING id, status::text, created_at, updated_at;",
+				rv.FilmID,
+				rv.UserID,
+				rv.Title,
+				rv.Body,
+			).Scan(&rv.Id, &rv.Status, &rv.CreatedAt, &rv.UpdatedAt)
+		})
+	}); err != nil {
+		return models.Review{}, err
+	}
+
+	return rv, nil
+}
+
+// checkRate fails with ErrRateLimited once the user has written the
+// allowed number of reviews within the window. It holds a lock on the
+// user until commit, so concurrent requests count each other's reviews.
+func (r *ReviewRepository) checkRate(tx *sql.Tx, userID int) error {
+	limit := r.store.reviewLimit
+	if limit.Count <= 0 {
+		return nil
+	}
+
+	if _, err := tx.Exec("SELECT pg_advisory_xact_lock($1, $2);", reviewLock, userID); err != nil {
+		return err
+	}
+
+	var written int
+	if err := tx.QueryRow(
+		"SELECT count(*) FROM reviews WHERE user_id=$1 AND created_at > now() - $2 * interval '1 second';",
+		userID,
+		limit.Window.Seconds(),
+	).Scan(&written); err != nil {
+		return err
+	}
+	if written >= limit.Count {
+		return store.ErrRateLimited
+	}
+
+	return nil
+}
+
+func (r *ReviewRepository) Find(id int) (models.Review, error) {
+	rv := models.Review{}
+	if err := r.store.retry(true, func() error {
+		return r.store.reader().QueryRow(
+			"SELECT "+reviewFields+" FROM reviews r JOIN films f ON f.id = r.film_id WHERE r.id=$1 AND f.deleted_at IS NULL;",
+			id,
+		).Scan(reviewDest(&rv)...)
+	}); err != nil {
+		if err == sql.ErrNoRows {
+			return models.Review{}, ErrResourceNotFound
+		}
+		return models.Review{}, err
+	}
+
+	return rv, nil
+}
+
+func (r *ReviewRepository) FindBy(filter store.ReviewFilter) ([]models.Review, int, error) {
+	query, args, count, countArgs := reviewFilterQuery(filter)
+
+	var (
+		reviews []models.Review
+		total   int
+	)
+	err := r.store.retry(true, func() error {
+		db := r.store.reader()
+		if err := db.QueryRow(count, countArgs...).Scan(&total); err != nil {
+			return err
+		}
+
+		rows, err := db.Query(query, args...)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		reviews = make([]models.Review, 0)
+		for rows.Next() {
+			rv := models.Review{}
+			if err := rows.Scan(reviewDest(&rv)...); err != nil {
+				return err
+			}
+			reviews = append(reviews, rv)
+		}
+
+		return rows.Err()
+	})
+
+	return reviews, total, err
+}
+
+func (r *ReviewRepository) Update(rv models.Review) (models.Review, error) {
+	if err := rv.Validate(); err != nil {
+		return models.Review{}, ErrValidation
+	}
+
+	updated := models.Review{}
+	if err := r.store.retry(true, func() error {
+		return r.store.db.QueryRow(
+			"UPDATE reviews r SET title=NULLIF($1, ''), body=$2, status='pending', updated_at=now() FROM films f WHERE r.id=$3 AND r.user_id=$4 AND f.id = r.film_id AND f.deleted_at IS NULL RETURNING "+reviewFields+";",
+			rv.Title,
+			rv.Body,
+			rv.Id,
+			rv.UserID,
+		).Scan(reviewDest(&updated)...)
+	}); err != nil {
+		if err == sql.ErrNoRows {
+			return models.Review{}, ErrResourceNotFound
+		}
+		return models.Review{}, err
+	}
+
+	return updated, nil
+}
+
+func (r *ReviewRepository) Moderate(id int, status string) (models.Review, error) {
+	if !models.ValidReviewStatus(status) {
+		return models.Review{}, ErrValidation
+	}
+
+	moderated := models.Review{}
+	if err := r.store.retry(true, func() error {
+		return r.store.db.QueryRow(
+			"UPDATE reviews r SET status=$1::review_status FROM films f WHERE r.id=$2 AND f.id = r.film_id AND f.deleted_at IS NULL RETURNING "+reviewFields+";",
+			status,
+			id,
+		).Scan(reviewDest(&moderated)...)
+	}); err != nil {
+		if err == sql.ErrNoRows {
+			return models.Review{}, ErrResourceNotFound
+		}
+		return models.Review{}, err
+	}
+
+	return moderated, nil
+}
@@ -0,0 +1,172 @@
+package sqlstore
+
+import (
+	"database/sql/driver"
+	"testing"
+	"time"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	"filmoteka/internal/app/models"
+	"filmoteka/internal/app/store"
+)
+
+var reviewColumns = []string{"id", "film_id", "user_id", "title", "body", "status", "created_at", "updated_at"}
+
+func TestReview_Create(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := New(db, WithReviewLimit(RateLimit{Count: 3, Window: time.Hour}))
+	createdAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	userLock := "SELECT pg_advisory_xact_lock($1, $2);"
+	written := "SELECT count(*) FROM reviews WHERE user_id=$1 AND created_at > now() - $2 * interval '1 second';"
+	insert := "INSERT INTO reviews (film_id, user_id, title, body) VALUES ($1, $2, NULLIF($3, ''), $4) RETURNING id, status::text, created_at, updated_at;"
+	input := models.Review{FilmID: 1, UserID: 2, Body: "A slow burn worth the wait."}
+
+	tests := []struct {
+		name    string
+		input   models.Review
+		mock    func()
+		want    models.Review
+		wantErr error
+	}{
+		{
+			name:  "Ok",
+			input: input,
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(ratingLock).WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"?column?"}).AddRow(1))
+				mock.ExpectExec(userLock).WithArgs(reviewLock, 2).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery(written).WithArgs(2, float64(3600)).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+				mock.ExpectQuery(insert).WithArgs(1, 2, "", input.Body).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "status", "created_at", "updated_at"}).AddRow(5, "pending", createdAt, createdAt))
+				mock.ExpectCommit()
+			},
+			want: models.Review{Id: 5, FilmID: 1, UserID: 2, Body: input.Body, Status: models.ReviewPending, CreatedAt: createdAt, UpdatedAt: createdAt},
+		},
+		{
+			name:  "Rate limited",
+			input: input,
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(ratingLock).WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"?column?"}).AddRow(1))
+				mock.ExpectExec(userLock).WithArgs(reviewLock, 2).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery(written).WithArgs(2, float64(3600)).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+				mock.ExpectRollback()
+			},
+			wantErr: store.ErrRateLimited,
+		},
+		{
+			name:    "Body too short",
+			input:   models.Review{FilmID: 1, UserID: 2, Body: "Meh"},
+			mock:    func() {},
+			wantErr: store.ErrValidation,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+
+			got, err := r.ReviewRepo().Create(tt.input)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestReview_FindBy(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := New(db)
+	createdAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	from := " FROM reviews r JOIN films f ON f.id = r.film_id WHERE f.deleted_at IS NULL"
+
+	tests := []struct {
+		name      string
+		filter    store.ReviewFilter
+		count     string
+		countArgs []driver.Value
+		query     string
+		args      []driver.Value
+		rows      *sqlmock.Rows
+		want      []models.Review
+		wantTotal int
+	}{
+		{
+			name:      "Approved of film, second page",
+			filter:    store.ReviewFilter{FilmID: 1, Status: models.ReviewApproved, Limit: 1, Offset: 1},
+			count:     "SELECT count(*)" + from + " AND r.film_id = $1 AND r.status::text = $2;",
+			countArgs: []driver.Value{1, "approved"},
+			query:     "SELECT " + reviewFields + from + " AND r.film_id = $1 AND r.status::text = $2 ORDER BY r.created_at DESC, r.id DESC LIMIT $3 OFFSET $4;",
+			args:      []driver.Value{1, "approved", 1, 1},
+			rows:      sqlmock.NewRows(reviewColumns).AddRow(4, 1, 2, "Great", "A slow burn worth the wait.", "approved", createdAt, createdAt),
+			want: []models.Review{
+				{Id: 4, FilmID: 1, UserID: 2, Title: "Great", Body: "A slow burn worth the wait.", Status: models.ReviewApproved, CreatedAt: createdAt, UpdatedAt: createdAt},
+			},
+			wantTotal: 2,
+		},
+		{
+			name:      "Past the end",
+			filter:    store.ReviewFilter{Status: models.ReviewPending, Limit: 10, Offset: 20},
+			count:     "SELECT count(*)" + from + " AND r.status::text = $1;",
+			countArgs: []driver.Value{"pending"},
+			query:     "SELECT " + reviewFields + from + " AND r.status::text = $1 ORDER BY r.created_at DESC, r.id DESC LIMIT $2 OFFSET $3;",
+			args:      []driver.Value{"pending", 10, 20},
+			rows:      sqlmock.NewRows(reviewColumns),
+			want:      []models.Review{},
+			wantTotal: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock.ExpectQuery(tt.count).WithArgs(tt.countArgs...).
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(tt.wantTotal))
+			mock.ExpectQuery(tt.query).WithArgs(tt.args...).WillReturnRows(tt.rows)
+
+			got, total, err := r.ReviewRepo().FindBy(tt.filter)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantTotal, total)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestReview_Moderate(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := New(db)
+	createdAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	query := "UPDATE reviews r SET status=$1::review_status FROM films f WHERE r.id=$2 AND f.id = r.film_id AND f.deleted_at IS NULL RETURNING " + reviewFields + ";"
+
+	mock.ExpectQuery(query).WithArgs("approved", 4).
+		WillReturnRows(sqlmock.NewRows(reviewColumns).AddRow(4, 1, 2, "", "A slow burn worth the wait.", "approved", createdAt, createdAt))
+	got, err := r.ReviewRepo().Moderate(4, models.ReviewApproved)
+	assert.NoError(t, err)
+	assert.Equal(t, models.ReviewApproved, got.Status)
+
+	// reviews of soft deleted films are left alone
+	mock.ExpectQuery(query).WithArgs("rejected", 5).WillReturnRows(sqlmock.NewRows(reviewColumns))
+	_, err = r.ReviewRepo().Moderate(5, models.ReviewRejected)
+	assert.ErrorIs(t, err, store.ErrResourceNotFound)
+
+	_, err = r.ReviewRepo().Moderate(4, "spam")
+	assert.ErrorIs(t, err, store.ErrValidation)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
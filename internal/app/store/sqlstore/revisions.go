@@ -0,0 +1,158 @@
+package sqlstore
+
+import (
+	"database/sql"
+	"encoding/json"
+
+	"filmoteka/internal/app/models"
+	"filmoteka/internal/app/store"
+)
+
+func (r *FilmRepository) Revisions(id int) ([]models.FilmRevision, error) {
+	var revisions []models.FilmRevision
+	err := r.store.retry(true, func() error {
+		rows, err := r.store.reader().Query(
+			"SELECT film_id, revision, snapshot, user_id, created_at FROM film_revisions WHERE film_id=$1 ORDER BY revision;",
+			id,
+		)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		revisions = make([]models.FilmRevision, 0)
+		for rows.Next() {
+			rev, err := scanRevision(rows)
+			if err != nil {
+				return err
+			}
+			revisions = append(revisions, rev)
+		}
+
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(revisions) == 0 {
+		return nil, ErrResourceNotFound
+	}
+
+	return revisions, nil
+}
+
+func (r *FilmRepository) Revision(id, n int) (models.FilmRevision, error) {
+	var rev models.FilmRevision
+	err := r.store.retry(true, func() (err error) {
+		rev, err = scanRevision(r.store.reader().QueryRow(
+			"SELECT film_id, revision, snapshot, user_id, created_at FROM film_revisions WHERE film_id=$1 AND revision=$2;",
+			id,
+			n,
+		))
+		return err
+	})
+	if err == sql.ErrNoRows {
+		return models.FilmRevision{}, ErrResourceNotFound
+	}
+
+	return rev, err
+}
+
+func (r *FilmRepository) Revert(id, n int) (models.Film, error) {
+	var after models.Film
+	err := r.store.retry(false, func() error {
+		return r.store.inTx(func(tx *sql.Tx) error {
+			before, err := r.lock(tx, id)
+			if err != nil {
+				return err
+			}
+
+			rev, err := scanRevision(tx.QueryRow(
+				"SELECT film_id, revision, snapshot, user_id, created_at FROM film_revisions WHERE film_id=$1 AND revision=$2;",
+				id,
+				n,
+			))
+			switch {
+			case err == sql.ErrNoRows:
+				return ErrResourceNotFound
+			case err != nil:
+				return err
+			}
+
+			after = rev.Film
+			after.Id = id
+			after.DeletedAt = nil
+			// snapshots taken before genres existed keep the current ones
+			keepGenres := after.Genres == nil
+			if keepGenres {
+				after.Genres = before.Genres
+			}
+			// snapshots predate later validation rules, do not write
+			// back what Update would refuse
+			if err := after.Validate(); err != nil {
+				return ErrValidation
+			}
+
+			if _, err := tx.Exec(filmUpdate, append(filmArgs(after), id)...); err != nil {
+				return err
+			}
+			if !keepGenres {
+				if err := r.setGenres(tx, &after); err != nil {
+					return err
+				}
+			}
+
+			if err := r.store.audit(tx, store.EntityFilm, id, store.ActionUpdate, before, after); err != nil {
+				return err
+			}
+			return r.revise(tx, after)
+		})
+	})
+	if err != nil {
+		return models.Film{}, err
+	}
+
+	return after, nil
+}
+
+// revise records f as the next revision of the film. Callers hold the
+// film row lock, or have just inserted it, so numbers do not collide.
+func (r *FilmRepository) revise(tx *sql.Tx, f models.Film) error {
+	snapshot, err := json.Marshal(f)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(
+		"INSERT INTO film_revisions (film_id, revision, snapshot, user_id) SELECT $1, COALESCE(MAX(revision), 0) + 1, $2, $3 FROM film_revisions WHERE film_id=$1;",
+		f.Id,
+		string(snapshot),
+		r.store.principalID(),
+	)
+
+	return err
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanRevision(row rowScanner) (models.FilmRevision, error) {
+	var (
+		rev      models.FilmRevision
+		snapshot []byte
+		userID   sql.NullInt64
+	)
+	if err := row.Scan(&rev.FilmID, &rev.Number, &snapshot, &userID, &rev.CreatedAt); err != nil {
+		return models.FilmRevision{}, err
+	}
+	if err := json.Unmarshal(snapshot, &rev.Film); err != nil {
+		return models.FilmRevision{}, err
+	}
+	if userID.Valid {
+		id := int(userID.Int64)
+		rev.UserID = &id
+	}
+
+	return rev, nil
+}
@@ -0,0 +1,151 @@
+package sqlstore
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	"filmoteka/internal/app/models"
+	"filmoteka/internal/app/store"
+)
+
+const (
+	revisionInsert = "INSERT INTO film_revisions (film_id, revision, snapshot, user_id) SELECT $1, COALESCE(MAX(revision), 0) + 1, $2, $3 FROM film_revisions WHERE film_id=$1;"
+	revisionSelect = "SELECT film_id, revision, snapshot, user_id, created_at FROM film_revisions WHERE film_id=$1 AND revision=$2;"
+)
+
+var revisionColumns = []string{"film_id", "revision", "snapshot", "user_id", "created_at"}
+
+func expectRevision(mock sqlmock.Sqlmock, id int, snapshot jsonMatch) {
+	mock.ExpectExec(revisionInsert).
+		WithArgs(id, snapshot, nil).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+}
+
+func TestFilm_Revisions(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := New(db)
+
+	createdAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	query := "SELECT film_id, revision, snapshot, user_id, created_at FROM film_revisions WHERE film_id=$1 ORDER BY revision;"
+
+	tests := []struct {
+		name    string
+		rows    *sqlmock.Rows
+		want    []models.FilmRevision
+		wantErr error
+	}{
+		{
+			name: "Ok",
+			rows: sqlmock.NewRows(revisionColumns).
+				AddRow(1, 1, []byte(`{"id":1,"name":"Film 1","description":"Descr 1","release_year":2001,"rating":5}`), nil, createdAt).
+				AddRow(1, 2, []byte(`{"id":1,"name":"Film 1","description":"Descr 1","release_year":2001,"rating":7}`), 3, createdAt),
+			want: []models.FilmRevision{
+				{FilmID: 1, Number: 1, Film: models.Film{Id: 1, Name: "Film 1", Description: "Descr 1", ReleaseYear: 2001, Rating: 5}, CreatedAt: createdAt},
+				{FilmID: 1, Number: 2, Film: models.Film{Id: 1, Name: "Film 1", Description: "Descr 1", ReleaseYear: 2001, Rating: 7}, UserID: intPtr(3), CreatedAt: createdAt},
+			},
+		},
+		{
+			name:    "Not Found",
+			rows:    sqlmock.NewRows(revisionColumns),
+			wantErr: ErrResourceNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock.ExpectQuery(query).WithArgs(1).WillReturnRows(tt.rows)
+
+			got, err := r.FilmRepo().Revisions(1)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestFilm_Revert(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := New(db)
+
+	updatedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	reverted := models.Film{Id: 1, Name: "Film 1", Description: "Descr 1", ReleaseYear: 2001, Rating: 5, Genres: []string{}}
+
+	tests := []struct {
+		name    string
+		mock    func()
+		want    models.Film
+		wantErr error
+	}{
+		{
+			name: "Ok",
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(filmLock).WithArgs(1).
+					WillReturnRows(sqlmock.NewRows(filmColumns).AddRow(1, "Film 1", "Descr 1", 2001, 7, 0, "", "", "{}", "", updatedAt, "{}"))
+				mock.ExpectQuery(revisionSelect).WithArgs(1, 1).
+					WillReturnRows(sqlmock.NewRows(revisionColumns).
+						AddRow(1, 1, []byte(`{"id":1,"name":"Film 1","description":"Descr 1","release_year":2001,"rating":5}`), nil, updatedAt))
+				mock.ExpectExec(filmUpdate).
+					WithArgs("Film 1", "Descr 1", uint16(2001), float32(5), uint16(0), "", "", "{}", "", 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectAudit(mock, store.EntityFilm, 1, store.ActionUpdate, `{"rating":7}`, `{"rating":5}`)
+				expectRevision(mock, 1, `{"id":1,"name":"Film 1","description":"Descr 1","release_year":2001,"rating":5}`)
+				mock.ExpectCommit()
+			},
+			want: reverted,
+		},
+		{
+			name: "Unknown Revision",
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(filmLock).WithArgs(1).
+					WillReturnRows(sqlmock.NewRows(filmColumns).AddRow(1, "Film 1", "Descr 1", 2001, 7, 0, "", "", "{}", "", updatedAt, "{}"))
+				mock.ExpectQuery(revisionSelect).WithArgs(1, 1).WillReturnError(sql.ErrNoRows)
+				mock.ExpectRollback()
+			},
+			wantErr: ErrResourceNotFound,
+		},
+		{
+			name: "Invalid Snapshot",
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(filmLock).WithArgs(1).
+					WillReturnRows(sqlmock.NewRows(filmColumns).AddRow(1, "Film 1", "Descr 1", 2001, 7, 0, "", "", "{}", "", updatedAt, "{}"))
+				mock.ExpectQuery(revisionSelect).WithArgs(1, 1).
+					WillReturnRows(sqlmock.NewRows(revisionColumns).
+						AddRow(1, 1, []byte(`{"id":1,"name":"F","description":"Descr 1","release_year":2001,"rating":5}`), nil, updatedAt))
+				mock.ExpectRollback()
+			},
+			wantErr: ErrValidation,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+
+			got, err := r.FilmRepo().Revert(1, 1)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func intPtr(i int) *int {
+	return &i
+}
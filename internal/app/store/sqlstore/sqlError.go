@@ -1,21 +1,81 @@
 package sqlstore
 
 import (
+	"database/sql/driver"
 	"errors"
-)
+	"io"
+	"net"
+	"syscall"
+
+	"github.com/lib/pq"
 
-// func getSQLState(err error) string {
-// 	type checker interface {
-// 		SQLState() string
-// 	}
-// 	pe := err.(checker)
-// 	log.Println("SQLState:", pe.SQLState())
-// 	return pe.SQLState()
-// }
+	"filmoteka/internal/app/store"
+)
 
 var (
-	ErrResourceNotFound = errors.New("resource not found")
+	ErrResourceNotFound = store.ErrResourceNotFound
 	// ErrResourceNotCreated = errors.New("resource not created")
-	ErrUniqueConstraints = errors.New("unique constraints violation")
-	ErrValidation        = errors.New("validation error")
+	ErrUniqueConstraints = store.ErrUniqueConstraints
+	ErrValidation        = store.ErrValidation
 )
+
+// SQLSTATE codes, see https://www.postgresql.org/docs/current/errcodes-appendix.html
+var sqlStateKinds = map[pq.ErrorCode]error{
+	"23505": store.ErrUniqueConstraints,
+	"23503": store.ErrForeignKey,
+	"23514": store.ErrCheckConstraint,
+	"23502": store.ErrNotNull,
+	"40001": store.ErrSerialization,
+	"40P01": store.ErrDeadlock,
+	"57P01": store.ErrConnectionLost, // admin_shutdown
+	"57P02": store.ErrConnectionLost, // crash_shutdown
+	"57P03": store.ErrConnectionLost, // cannot_connect_now
+}
+
+// translateError maps driver errors to *store.DBError carrying a domain
+// error kind. Errors it cannot classify are returned unchanged.
+func translateError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var dbErr *store.DBError
+	if errors.As(err, &dbErr) {
+		return err
+	}
+
+	var pe *pq.Error
+	if errors.As(err, &pe) {
+		kind, ok := sqlStateKinds[pe.Code]
+		if !ok && pe.Code.Class() == "08" { // connection_exception
+			kind, ok = store.ErrConnectionLost, true
+		}
+		if !ok {
+			return err
+		}
+		return &store.DBError{
+			Kind:       kind,
+			Code:       string(pe.Code),
+			Table:      pe.Table,
+			Constraint: pe.Constraint,
+			Column:     pe.Column,
+			Err:        err,
+		}
+	}
+
+	if isConnectionError(err) {
+		return &store.DBError{Kind: store.ErrConnectionLost, Err: err}
+	}
+
+	return err
+}
+
+func isConnectionError(err error) bool {
+	var ne net.Error
+	return errors.Is(err, driver.ErrBadConn) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.As(err, &ne)
+}
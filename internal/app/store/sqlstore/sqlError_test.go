@@ -0,0 +1,81 @@
+package sqlstore
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+
+	"filmoteka/internal/app/store"
+)
+
+func TestTranslateError(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		wantKind       error
+		wantConstraint string
+		wantTransient  bool
+	}{
+		{
+			name:           "unique",
+			err:            &pq.Error{Code: "23505", Constraint: "films_name_release_year_idx"},
+			wantKind:       store.ErrUniqueConstraints,
+			wantConstraint: "films_name_release_year_idx",
+		},
+		{
+			name:           "foreign key",
+			err:            &pq.Error{Code: "23503", Constraint: "fk_film"},
+			wantKind:       store.ErrForeignKey,
+			wantConstraint: "fk_film",
+		},
+		{
+			name:     "not null",
+			err:      &pq.Error{Code: "23502", Table: "films", Column: "name"},
+			wantKind: store.ErrNotNull,
+		},
+		{
+			name:          "serialization",
+			err:           &pq.Error{Code: "40001"},
+			wantKind:      store.ErrSerialization,
+			wantTransient: true,
+		},
+		{
+			name:          "deadlock",
+			err:           fmt.Errorf("wrapped: %w", &pq.Error{Code: "40P01"}),
+			wantKind:      store.ErrDeadlock,
+			wantTransient: true,
+		},
+		{
+			name:          "connection exception class",
+			err:           &pq.Error{Code: "08006"},
+			wantKind:      store.ErrConnectionLost,
+			wantTransient: true,
+		},
+		{
+			name:          "bad connection",
+			err:           driver.ErrBadConn,
+			wantKind:      store.ErrConnectionLost,
+			wantTransient: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := translateError(tt.err)
+
+			var dbErr *store.DBError
+			assert.ErrorAs(t, got, &dbErr)
+			assert.ErrorIs(t, got, tt.wantKind)
+			assert.Equal(t, tt.wantConstraint, dbErr.Constraint)
+			assert.Equal(t, tt.wantTransient, store.IsTransient(got))
+		})
+	}
+
+	other := errors.New("something went wrong")
+	assert.Equal(t, other, translateError(other))
+	assert.Nil(t, translateError(nil))
+}
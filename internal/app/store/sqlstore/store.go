@@ -2,20 +2,78 @@ package sqlstore
 
 import (
 	"database/sql"
+	"time"
 
 	"filmoteka/internal/app/store"
 )
 
 type Store struct {
-	db              *sql.DB
-	filmRepository  *FilmRepository
-	actorRepository *ActorRepository
+	db                    *sql.DB
+	replicas              *replicaSet
+	pinned                bool
+	retryPolicy           RetryPolicy
+	sleep                 func(time.Duration)
+	principal             store.Principal
+	ratingWeight          int
+	reviewLimit           RateLimit
+	filmRepository        *FilmRepository
+	actorRepository       *ActorRepository
+	genreRepository       *GenreRepository
+	creditRepository      *CreditRepository
+	ratingRepository      *RatingRepository
+	reviewRepository      *ReviewRepository
+	watchlistRepository   *WatchlistRepository
+	watchLogRepository    *WatchLogRepository
+	mediaRepository       *MediaRepository
+	relationRepository    *RelationRepository
+	collectionRepository  *CollectionRepository
+	translationRepository *TranslationRepository
+	awardRepository       *AwardRepository
+	auditRepository       *AuditRepository
 }
 
-func New(db *sql.DB) *Store {
-	return &Store{
-		db: db,
+// Option configures optional store behaviour.
+type Option func(*Store)
+
+// WithRetry sets the policy applied to transient database failures.
+func WithRetry(p RetryPolicy) Option {
+	return func(s *Store) {
+		s.retryPolicy = p
+	}
+}
+
+// WithRatingWeight sets how many user ratings the editor rating of a film
+// counts as in its score. 0 scores rated films by the plain mean.
+func WithRatingWeight(n int) Option {
+	return func(s *Store) {
+		s.ratingWeight = n
+	}
+}
+
+// RateLimit allows Count actions per Window; a zero Count is unlimited.
+type RateLimit struct {
+	Count  int
+	Window time.Duration
+}
+
+// WithReviewLimit limits how many reviews a user may write in a window.
+func WithReviewLimit(l RateLimit) Option {
+	return func(s *Store) {
+		s.reviewLimit = l
+	}
+}
+
+func New(db *sql.DB, opts ...Option) *Store {
+	s := &Store{
+		db:    db,
+		sleep: time.Sleep,
+	}
+
+	for _, opt := range opts {
+		opt(s)
 	}
+
+	return s
 }
 
 func (s *Store) FilmRepo() store.IFilmRepository {
@@ -41,3 +99,183 @@ func (s *Store) ActorRepo() store.IActorRepository {
 
 	return s.actorRepository
 }
+
+func (s *Store) GenreRepo() store.IGenreRepository {
+	if s.genreRepository != nil {
+		return s.genreRepository
+	}
+
+	s.genreRepository = &GenreRepository{
+		store: s,
+	}
+
+	return s.genreRepository
+}
+
+func (s *Store) CreditRepo() store.ICreditRepository {
+	if s.creditRepository != nil {
+		return s.creditRepository
+	}
+
+	s.creditRepository = &CreditRepository{
+		store: s,
+	}
+
+	return s.creditRepository
+}
+
+func (s *Store) RatingRepo() store.IRatingRepository {
+	if s.ratingRepository != nil {
+		return s.ratingRepository
+	}
+
+	s.ratingRepository = &RatingRepository{
+		store: s,
+	}
+
+	return s.ratingRepository
+}
+
+func (s *Store) ReviewRepo() store.IReviewRepository {
+	if s.reviewRepository != nil {
+		return s.reviewRepository
+	}
+
+	s.reviewRepository = &ReviewRepository{
+		store: s,
+	}
+
+	return s.reviewRepository
+}
+
+func (s *Store) WatchlistRepo() store.IWatchlistRepository {
+	if s.watchlistRepository != nil {
+		return s.watchlistRepository
+	}
+
+	s.watchlistRepository = &WatchlistRepository{
+		store: s,
+	}
+
+	return s.watchlistRepository
+}
+
+func (s *Store) WatchLogRepo() store.IWatchLogRepository {
+	if s.watchLogRepository != nil {
+		return s.watchLogRepository
+	}
+
+	s.watchLogRepository = &WatchLogRepository{
+		store: s,
+	}
+
+	return s.watchLogRepository
+}
+
+func (s *Store) MediaRepo() store.IMediaRepository {
+	if s.mediaRepository != nil {
+		return s.mediaRepository
+	}
+
+	s.mediaRepository = &MediaRepository{
+		store: s,
+	}
+
+	return s.mediaRepository
+}
+
+func (s *Store) RelationRepo() store.IRelationRepository {
+	if s.relationRepository != nil {
+		return s.relationRepository
+	}
+
+	s.relationRepository = &RelationRepository{
+		store: s,
+	}
+
+	return s.relationRepository
+}
+
+func (s *Store) CollectionRepo() store.ICollectionRepository {
+	if s.collectionRepository != nil {
+		return s.collectionRepository
+	}
+
+	s.collectionRepository = &CollectionRepository{
+		store: s,
+	}
+
+	return s.collectionRepository
+}
+
+func (s *Store) TranslationRepo() store.ITranslationRepository {
+	if s.translationRepository != nil {
+		return s.translationRepository
+	}
+
+	s.translationRepository = &TranslationRepository{
+		store: s,
+	}
+
+	return s.translationRepository
+}
+
+func (s *Store) AwardRepo() store.IAwardRepository {
+	if s.awardRepository != nil {
+		return s.awardRepository
+	}
+
+	s.awardRepository = &AwardRepository{
+		store: s,
+	}
+
+	return s.awardRepository
+}
+
+func (s *Store) AuditRepo() store.IAuditRepository {
+	if s.auditRepository != nil {
+		return s.auditRepository
+	}
+
+	s.auditRepository = &AuditRepository{
+		store: s,
+	}
+
+	return s.auditRepository
+}
+
+// Primary returns a view of the store that reads from the primary, so a
+// caller sees its own writes regardless of replica lag.
+func (s *Store) Primary() store.IStore {
+	if s.replicas == nil || s.pinned {
+		return s
+	}
+
+	v := s.view()
+	v.pinned = true
+
+	return v
+}
+
+// As returns a view of the store recording p as the author of changes
+// in the audit log.
+func (s *Store) As(p store.Principal) store.IStore {
+	v := s.view()
+	v.principal = p
+
+	return v
+}
+
+// view copies the store settings; repositories are created lazily.
+func (s *Store) view() *Store {
+	return &Store{
+		db:           s.db,
+		replicas:     s.replicas,
+		pinned:       s.pinned,
+		retryPolicy:  s.retryPolicy,
+		sleep:        s.sleep,
+		principal:    s.principal,
+		ratingWeight: s.ratingWeight,
+		reviewLimit:  s.reviewLimit,
+	}
+}
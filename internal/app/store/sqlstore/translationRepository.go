@@ -0,0 +1,80 @@
+package sqlstore
+
+import (
+	"database/sql"
+
+	"github.com/lib/pq"
+
+	"filmoteka/internal/app/models"
+)
+
+type TranslationRepository struct {
+	store *Store
+}
+
+func (r *TranslationRepository) Set(filmID int, t models.FilmTranslation) error {
+	if err := t.Validate(); err != nil {
+		return ErrValidation
+	}
+
+	var result sql.Result
+	if err := r.store.retry(true, func() (err error) {
+		result, err = r.store.db.Exec(
+			"INSERT INTO film_translations (film_id, locale, name, description) SELECT $1, $2, $3, NULLIF($4, '') WHERE EXISTS (SELECT 1 FROM films WHERE id=$1 AND deleted_at IS NULL) "+
+				"ON CONFLICT (film_id, locale) DO UPDATE SET name=EXCLUDED.name, description=EXCLUDED.description, updated_at=now();",
+			filmID,
+			t.Locale,
+			t.Name,
+			t.Description,
+		)
+		return err
+	}); err != nil {
+		return err
+	}
+
+	insertedRows, err := result.RowsAffected()
+	if err != nil {
+		return translateError(err)
+	}
+	if insertedRows == 0 {
+		return ErrResourceNotFound
+	}
+
+	return nil
+}
+
+func (r *TranslationRepository) ForFilms(filmIDs []int, locales []string) (map[int]models.FilmTranslation, error) {
+	translations := map[int]models.FilmTranslation{}
+	if len(filmIDs) == 0 || len(locales) == 0 {
+		return translations, nil
+	}
+
+	err := r.store.retry(true, func() error {
+		// the most preferred locale of each film comes first
+		rows, err := r.store.reader().Query(
+			"SELECT DISTINCT ON (film_id) film_id, locale, name, COALESCE(description, ''), updated_at FROM film_translations WHERE film_id = ANY($1) AND locale = ANY($2) ORDER BY film_id, array_position($2, locale);",
+			pq.Array(filmIDs),
+			pq.Array(locales),
+		)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		clear(translations)
+		for rows.Next() {
+			var (
+				filmID int
+				t      models.FilmTranslation
+			)
+			if err := rows.Scan(&filmID, &t.Locale, &t.Name, &t.Description, &t.UpdatedAt); err != nil {
+				return err
+			}
+			translations[filmID] = t
+		}
+
+		return rows.Err()
+	})
+
+	return translations, err
+}
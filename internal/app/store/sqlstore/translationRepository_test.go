@@ -0,0 +1,93 @@
+package sqlstore
+
+import (
+	"testing"
+	"time"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+
+	"filmoteka/internal/app/models"
+	"filmoteka/internal/app/store"
+)
+
+func TestTranslation_Set(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := New(db)
+	query := "INSERT INTO film_translations (film_id, locale, name, description) SELECT $1, $2, $3, NULLIF($4, '') WHERE EXISTS (SELECT 1 FROM films WHERE id=$1 AND deleted_at IS NULL) " +
+		"ON CONFLICT (film_id, locale) DO UPDATE SET name=EXCLUDED.name, description=EXCLUDED.description, updated_at=now();"
+
+	tests := []struct {
+		name    string
+		filmID  int
+		input   models.FilmTranslation
+		mock    func()
+		wantErr error
+	}{
+		{
+			name:   "OK",
+			filmID: 1,
+			input:  models.FilmTranslation{Locale: "de", Name: "Stirb langsam", Description: "Ein Polizist gegen Terroristen."},
+			mock: func() {
+				mock.ExpectExec(query).WithArgs(1, "de", "Stirb langsam", "Ein Polizist gegen Terroristen.").WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name:   "Deleted film",
+			filmID: 9,
+			input:  models.FilmTranslation{Locale: "pt-BR", Name: "Duro de Matar"},
+			mock: func() {
+				mock.ExpectExec(query).WithArgs(9, "pt-BR", "Duro de Matar", "").WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			wantErr: store.ErrResourceNotFound,
+		},
+		{
+			name:    "Bad locale",
+			filmID:  1,
+			input:   models.FilmTranslation{Locale: "german", Name: "Stirb langsam"},
+			mock:    func() {},
+			wantErr: store.ErrValidation,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+
+			err := r.TranslationRepo().Set(tt.filmID, tt.input)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTranslation_ForFilms(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := New(db)
+	updatedAt := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery("SELECT DISTINCT ON (film_id) film_id, locale, name, COALESCE(description, ''), updated_at FROM film_translations WHERE film_id = ANY($1) AND locale = ANY($2) ORDER BY film_id, array_position($2, locale);").
+		WithArgs(pq.Array([]int{1, 2, 3}), pq.Array([]string{"de-AT", "de"})).
+		WillReturnRows(sqlmock.NewRows([]string{"film_id", "locale", "name", "description", "updated_at"}).
+			AddRow(1, "de", "Stirb langsam", "", updatedAt).
+			AddRow(3, "de-AT", "Der weiße Hai", "Ein Hai terrorisiert eine Küstenstadt.", updatedAt))
+
+	got, err := r.TranslationRepo().ForFilms([]int{1, 2, 3}, []string{"de-AT", "de"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[int]models.FilmTranslation{
+		1: {Locale: "de", Name: "Stirb langsam", UpdatedAt: updatedAt},
+		3: {Locale: "de-AT", Name: "Der weiße Hai", Description: "Ein Hai terrorisiert eine Küstenstadt.", UpdatedAt: updatedAt},
+	}, got)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,164 @@
+package sqlstore
+
+import (
+	"database/sql"
+
+	"github.com/lib/pq"
+
+	"filmoteka/internal/app/models"
+	"filmoteka/internal/app/store"
+)
+
+type WatchlistRepository struct {
+	store *Store
+}
+
+func (r *WatchlistRepository) Add(userID, filmID int) error {
+	var live int
+	if err := r.store.retry(true, func() error {
+		// counting the live film tells a missing film from a film
+		// already on the list
+		return r.store.db.QueryRow(
+			"WITH film AS (SELECT id FROM films WHERE id=$2 AND deleted_at IS NULL), added AS (INSERT INTO watchlist (user_id, film_id) SELECT $1, id FROM film ON CONFLICT DO NOTHING) SELECT count(*) FROM film;",
+			userID,
+			filmID,
+		).Scan(&live)
+	}); err != nil {
+		return err
+	}
+	if live == 0 {
+		return ErrResourceNotFound
+	}
+
+	return nil
+}
+
+func (r *WatchlistRepository) Remove(userID, filmID int) error {
+	var result sql.Result
+	if err := r.store.retry(false, func() (err error) {
+		result, err = r.store.db.Exec(
+			"DELETE FROM watchlist WHERE user_id=$1 AND film_id=$2;",
+			userID,
+			filmID,
+		)
+		return err
+	}); err != nil {
+		return err
+	}
+
+	deletedRows, err := result.RowsAffected()
+	if err != nil {
+		return translateError(err)
+	}
+	if deletedRows == 0 {
+		return ErrResourceNotFound
+	}
+
+	return nil
+}
+
+func (r *WatchlistRepository) Films(userID int, filter store.FilmFilter) ([]models.WatchlistItem, error) {
+	query, args := watchlistQuery(userID, filter)
+
+	var items []models.WatchlistItem
+	err := r.store.retry(true, func() error {
+		rows, err := r.store.reader().Query(query, args...)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		items = make([]models.WatchlistItem, 0)
+		for rows.Next() {
+			item := models.WatchlistItem{}
+			t := ratingTotals{}
+			if err := rows.Scan(append(filmDest(&item.Film), pq.Array(&item.Genres), &t.count, &t.sum, imageDest(&item.Poster), &item.AddedAt)...); err != nil {
+				return err
+			}
+			r.store.summarize(&item.Film, t)
+			items = append(items, item)
+		}
+
+		return rows.Err()
+	})
+
+	return items, err
+}
+
+type WatchLogRepository struct {
+	store *Store
+}
+
+func (r *WatchLogRepository) Add(w models.Watch) (int, error) {
+	if err := w.Validate(); err != nil {
+		return 0, ErrValidation
+	}
+
+	var id int
+	if err := r.store.retry(false, func() error {
+		return r.store.db.QueryRow(
+			"INSERT INTO watch_log (user_id, film_id, watched_on) SELECT $1, $2, $3 WHERE EXISTS (SELECT 1 FROM films WHERE id=$2 AND deleted_at IS NULL) RETURNING id;",
+			w.UserID,
+			w.FilmID,
+			w.WatchedOn,
+		).Scan(&id)
+	}); err != nil {
+		if err == sql.ErrNoRows {
+			return 0, ErrResourceNotFound
+		}
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (r *WatchLogRepository) Remove(userID, id int) error {
+	var result sql.Result
+	if err := r.store.retry(false, func() (err error) {
+		result, err = r.store.db.Exec(
+			"DELETE FROM watch_log WHERE id=$1 AND user_id=$2;",
+			id,
+			userID,
+		)
+		return err
+	}); err != nil {
+		return err
+	}
+
+	deletedRows, err := result.RowsAffected()
+	if err != nil {
+		return translateError(err)
+	}
+	if deletedRows == 0 {
+		return ErrResourceNotFound
+	}
+
+	return nil
+}
+
+func (r *WatchLogRepository) ByUser(userID int) ([]models.Watch, error) {
+	var watches []models.Watch
+	err := r.store.retry(true, func() error {
+		rows, err := r.store.reader().Query(
+			"SELECT l.id, l.user_id, l.film_id, f.name, f.release_year, to_char(l.watched_on, 'YYYY-MM-DD'), l.created_at FROM watch_log l JOIN films f ON f.id = l.film_id WHERE l.user_id=$1 AND f.deleted_at IS NULL ORDER BY l.watched_on DESC, l.id DESC;",
+			userID,
+		)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		watches = make([]models.Watch, 0)
+		for rows.Next() {
+			w := models.Watch{}
+			if err := rows.Scan(&w.Id, &w.UserID, &w.FilmID, &w.Film, &w.ReleaseYear, &w.WatchedOn, &w.CreatedAt); err != nil {
+				return err
+			}
+			watches = append(watches, w)
+		}
+
+		return rows.Err()
+	})
+
+	return watches, err
+}
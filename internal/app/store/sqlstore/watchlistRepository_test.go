@@ -0,0 +1,115 @@
+package sqlstore
+
+import (
+	"testing"
+	"time"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	"filmoteka/internal/app/models"
+	"filmoteka/internal/app/store"
+)
+
+func TestWatchlist_Add(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := New(db)
+	query := "WITH film AS (SELECT id FROM films WHERE id=$2 AND deleted_at IS NULL), added AS (INSERT INTO watchlist (user_id, film_id) SELECT $1, id FROM film ON CONFLICT DO NOTHING) SELECT count(*) FROM film;"
+
+	mock.ExpectQuery(query).WithArgs(2, 1).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	assert.NoError(t, r.WatchlistRepo().Add(2, 1))
+
+	mock.ExpectQuery(query).WithArgs(2, 9).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	assert.ErrorIs(t, r.WatchlistRepo().Add(2, 9), store.ErrResourceNotFound)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWatchlist_Films(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := New(db)
+	updatedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	addedAt := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	columns := append(filmColumns, "user_rating_count", "user_rating_sum", "poster", "added_at")
+
+	// include_deleted does not apply, the filter is numbered after the user
+	mock.ExpectQuery("SELECT "+filmFields+", "+filmGenres+", "+filmRatings+", "+filmPoster+", w.added_at FROM watchlist w JOIN films ON films.id = w.film_id WHERE w.user_id = $1 AND films.deleted_at IS NULL AND EXISTS (SELECT 1 FROM film_genres fg JOIN genres g ON g.id = fg.genre_id WHERE fg.film_id = films.id AND lower(g.name) = lower($2)) ORDER BY w.added_at DESC, films.id;").
+		WithArgs(2, "drama").
+		WillReturnRows(sqlmock.NewRows(columns).AddRow(1, "Film 1", "Descr 1", 2001, 5, 0, "", "", "{}", "", updatedAt, "{Drama}", 0, 0, nil, addedAt))
+
+	got, err := r.WatchlistRepo().Films(2, store.FilmFilter{IncludeDeleted: true, Genre: "drama"})
+	assert.NoError(t, err)
+	assert.Equal(t, []models.WatchlistItem{
+		{
+			Film: models.Film{
+				Id: 1, Name: "Film 1", Description: "Descr 1", ReleaseYear: 2001, Rating: 5, UpdatedAt: updatedAt,
+				Countries: []string{}, Genres: []string{"Drama"}, UserRating: &models.RatingSummary{Score: 5},
+			},
+			AddedAt: addedAt,
+		},
+	}, got)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWatchLog_Add(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := New(db)
+	insert := "INSERT INTO watch_log (user_id, film_id, watched_on) SELECT $1, $2, $3 WHERE EXISTS (SELECT 1 FROM films WHERE id=$2 AND deleted_at IS NULL) RETURNING id;"
+
+	tests := []struct {
+		name    string
+		input   models.Watch
+		mock    func()
+		want    int
+		wantErr error
+	}{
+		{
+			name:  "Ok",
+			input: models.Watch{UserID: 2, FilmID: 1, WatchedOn: "2024-05-01"},
+			mock: func() {
+				mock.ExpectQuery(insert).WithArgs(2, 1, "2024-05-01").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+			},
+			want: 3,
+		},
+		{
+			name:  "Deleted film",
+			input: models.Watch{UserID: 2, FilmID: 9, WatchedOn: "2024-05-01"},
+			mock: func() {
+				mock.ExpectQuery(insert).WithArgs(2, 9, "2024-05-01").WillReturnRows(sqlmock.NewRows([]string{"id"}))
+			},
+			wantErr: store.ErrResourceNotFound,
+		},
+		{
+			name:    "Bad date",
+			input:   models.Watch{UserID: 2, FilmID: 1, WatchedOn: "01/05/2024"},
+			mock:    func() {},
+			wantErr: store.ErrValidation,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+
+			got, err := r.WatchLogRepo().Add(tt.input)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -3,4 +3,30 @@ package store
 type IStore interface {
 	FilmRepo() IFilmRepository
 	ActorRepo() IActorRepository
+	GenreRepo() IGenreRepository
+	CreditRepo() ICreditRepository
+	RatingRepo() IRatingRepository
+	ReviewRepo() IReviewRepository
+	WatchlistRepo() IWatchlistRepository
+	WatchLogRepo() IWatchLogRepository
+	MediaRepo() IMediaRepository
+	RelationRepo() IRelationRepository
+	CollectionRepo() ICollectionRepository
+	TranslationRepo() ITranslationRepository
+	AwardRepo() IAwardRepository
+	AuditRepo() IAuditRepository
+}
+
+// IPrimaryStore is implemented by stores that may serve reads from lagging
+// replicas. Primary returns a view that reads from the primary database.
+type IPrimaryStore interface {
+	IStore
+	Primary() IStore
+}
+
+// IAuditedStore is implemented by stores keeping an audit log. As returns
+// a view attributing the changes made through it to p.
+type IAuditedStore interface {
+	IStore
+	As(p Principal) IStore
 }